@@ -5,8 +5,10 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
 	"os"
 	"razor/logger"
 	"razor/path"
@@ -71,3 +73,23 @@ func (am *AccountManager) SignData(hash []byte, address string, password string)
 	}
 	return crypto.Sign(hash, privateKey)
 }
+
+//This function takes message, account and password as input and returns the EIP-191 signature of the message as array of byte
+func (am *AccountManager) SignMessage(message []byte, address string, password string) ([]byte, error) {
+	return am.SignData(accounts.TextHash(message), address, password)
+}
+
+//This function takes address of account, password and chain id as input and returns the transaction options which sign transactions with the private key of account
+func (am *AccountManager) NewTransactor(address string, password string, chainId *big.Int) (*bind.TransactOpts, error) {
+	privateKey, err := am.GetPrivateKey(address, password)
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewKeyedTransactorWithChainID(privateKey, chainId)
+}
+
+//This function checks whether the keystore of the account can be decrypted with the given password
+func (am *AccountManager) VerifyAccount(address string, password string) error {
+	_, err := am.GetPrivateKey(address, password)
+	return err
+}
@@ -7,6 +7,10 @@ import (
 
 	accounts "github.com/ethereum/go-ethereum/accounts"
 
+	big "math/big"
+
+	bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -79,6 +83,32 @@ func (_m *AccountManagerInterface) NewAccount(passphrase string) (accounts.Accou
 	return r0, r1
 }
 
+// NewTransactor provides a mock function with given fields: address, password, chainId
+func (_m *AccountManagerInterface) NewTransactor(address string, password string, chainId *big.Int) (*bind.TransactOpts, error) {
+	ret := _m.Called(address, password, chainId)
+
+	var r0 *bind.TransactOpts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, *big.Int) (*bind.TransactOpts, error)); ok {
+		return rf(address, password, chainId)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *big.Int) *bind.TransactOpts); ok {
+		r0 = rf(address, password, chainId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bind.TransactOpts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *big.Int) error); ok {
+		r1 = rf(address, password, chainId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignData provides a mock function with given fields: hash, address, password
 func (_m *AccountManagerInterface) SignData(hash []byte, address string, password string) ([]byte, error) {
 	ret := _m.Called(hash, address, password)
@@ -105,6 +135,46 @@ func (_m *AccountManagerInterface) SignData(hash []byte, address string, passwor
 	return r0, r1
 }
 
+// SignMessage provides a mock function with given fields: message, address, password
+func (_m *AccountManagerInterface) SignMessage(message []byte, address string, password string) ([]byte, error) {
+	ret := _m.Called(message, address, password)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, string, string) ([]byte, error)); ok {
+		return rf(message, address, password)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string, string) []byte); ok {
+		r0 = rf(message, address, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string, string) error); ok {
+		r1 = rf(message, address, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyAccount provides a mock function with given fields: address, password
+func (_m *AccountManagerInterface) VerifyAccount(address string, password string) error {
+	ret := _m.Called(address, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(address, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAccountManagerInterface creates a new instance of AccountManagerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAccountManagerInterface(t interface {
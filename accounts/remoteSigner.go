@@ -0,0 +1,99 @@
+//Package account provides all account related functions
+package accounts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+	Types "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	errRemoteSignerNotSupported = errors.New("operation not supported with remote signer")
+	errAccountNotInRemoteSigner = errors.New("account is not managed by remote signer")
+)
+
+// RemoteSigner signs transactions and messages with an external signer speaking the Clef JSON-RPC API
+// (account_list, account_signTransaction and account_signData), so that the private key never enters the process.
+// Passwords are not used as the external signer is responsible for unlocking its accounts.
+type RemoteSigner struct {
+	Signer *external.ExternalSigner
+}
+
+// NewRemoteSigner connects to the external signer at the given URL. The URL can be an http(s), ws(s) or IPC endpoint.
+func NewRemoteSigner(signerURL string) (*RemoteSigner, error) {
+	signer, err := external.NewExternalSigner(signerURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{
+		Signer: signer,
+	}, nil
+}
+
+//This function returns an error as accounts have to be created in the external signer
+func (rs *RemoteSigner) CreateAccount(keystorePath string, password string) accounts.Account {
+	newAcc, err := rs.NewAccount(password)
+	if err != nil {
+		log.Fatal("Error in creating account: ", err)
+	}
+	return newAcc
+}
+
+//This function returns an error as accounts have to be created in the external signer
+func (rs *RemoteSigner) NewAccount(passphrase string) (accounts.Account, error) {
+	return accounts.Account{}, errRemoteSignerNotSupported
+}
+
+//This function returns an error as the private key is never exposed by the external signer
+func (rs *RemoteSigner) GetPrivateKey(address string, password string) (*ecdsa.PrivateKey, error) {
+	return nil, errRemoteSignerNotSupported
+}
+
+//This function returns an error as the external signer only signs messages and not raw hashes, use SignMessage instead
+func (rs *RemoteSigner) SignData(hash []byte, address string, password string) ([]byte, error) {
+	return nil, errRemoteSignerNotSupported
+}
+
+//This function takes message and account as input and returns the EIP-191 signature of the message produced by the external signer
+func (rs *RemoteSigner) SignMessage(message []byte, address string, password string) ([]byte, error) {
+	account := accounts.Account{Address: common.HexToAddress(address)}
+	return rs.Signer.SignText(account, message)
+}
+
+//This function takes address of account and chain id as input and returns the transaction options which sign transactions with the external signer
+func (rs *RemoteSigner) NewTransactor(address string, password string, chainId *big.Int) (*bind.TransactOpts, error) {
+	if err := rs.VerifyAccount(address, password); err != nil {
+		return nil, err
+	}
+	account := accounts.Account{Address: common.HexToAddress(address)}
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(from common.Address, tx *Types.Transaction) (*Types.Transaction, error) {
+			if from != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return rs.Signer.SignTx(account, tx, chainId)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+//This function checks whether the account is managed by the external signer
+func (rs *RemoteSigner) VerifyAccount(address string, password string) error {
+	if !common.IsHexAddress(address) {
+		return errAccountNotInRemoteSigner
+	}
+	for _, account := range rs.Signer.Accounts() {
+		if account.Address == common.HexToAddress(address) {
+			return nil
+		}
+	}
+	return errAccountNotInRemoteSigner
+}
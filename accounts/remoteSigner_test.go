@@ -0,0 +1,232 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// mockSignerAPI implements the subset of the Clef external API used by RemoteSigner
+type mockSignerAPI struct {
+	privateKey *ecdsa.PrivateKey
+	chainId    *big.Int
+}
+
+type mockSignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *Types.Transaction `json:"tx"`
+}
+
+func (api *mockSignerAPI) Version() string {
+	return "6.0.0"
+}
+
+func (api *mockSignerAPI) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(api.privateKey.PublicKey)}
+}
+
+func (api *mockSignerAPI) SignData(contentType string, address common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if address.Address() != crypto.PubkeyToAddress(api.privateKey.PublicKey) {
+		return nil, errors.New("unknown account")
+	}
+	signature, err := crypto.Sign(accounts.TextHash(data), api.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	// Clef returns the signature with V in the 27/28 form
+	signature[64] += 27
+	return signature, nil
+}
+
+func (api *mockSignerAPI) SignTransaction(args apitypes.SendTxArgs) (*mockSignTransactionResult, error) {
+	if args.From.Address() != crypto.PubkeyToAddress(api.privateKey.PublicKey) {
+		return nil, errors.New("unknown account")
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := Types.SignTx(tx, Types.LatestSignerForChainID(api.chainId), api.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &mockSignTransactionResult{Raw: raw, Tx: signedTx}, nil
+}
+
+func startMockSigner(t *testing.T, chainId *big.Int) (*RemoteSigner, common.Address) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &mockSignerAPI{privateKey: privateKey, chainId: chainId}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	remoteSigner, err := NewRemoteSigner(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remoteSigner, crypto.PubkeyToAddress(privateKey.PublicKey)
+}
+
+func TestNewRemoteSigner(t *testing.T) {
+	httpServer := httptest.NewServer(rpc.NewServer())
+	defer httpServer.Close()
+
+	_, err := NewRemoteSigner(httpServer.URL)
+	if err == nil {
+		t.Errorf("NewRemoteSigner() expected error when signer doesn't serve the external API")
+	}
+}
+
+func TestRemoteSignerSignMessage(t *testing.T) {
+	remoteSigner, signerAddress := startMockSigner(t, big.NewInt(31337))
+	message := crypto.Keccak256([]byte("razororacle"))
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr bool
+	}{
+		{
+			name:    "Test 1: When message is signed by remote signer",
+			address: signerAddress.Hex(),
+			wantErr: false,
+		},
+		{
+			name:    "Test 2: When account is not managed by remote signer",
+			address: "0x911654feb423363fb771e04e18d1e7325ae10a91",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := remoteSigner.SignMessage(message, tt.address, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SignMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if signature[64] != 0 && signature[64] != 1 {
+				t.Errorf("SignMessage() got V = %d, want 0 or 1", signature[64])
+			}
+			publicKey, err := crypto.SigToPub(accounts.TextHash(message), signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if crypto.PubkeyToAddress(*publicKey) != signerAddress {
+				t.Errorf("SignMessage() signature recovers to %s, want %s", crypto.PubkeyToAddress(*publicKey).Hex(), signerAddress.Hex())
+			}
+		})
+	}
+}
+
+func TestRemoteSignerNewTransactor(t *testing.T) {
+	chainId := big.NewInt(31337)
+	remoteSigner, signerAddress := startMockSigner(t, chainId)
+	to := common.HexToAddress("0x911654feb423363fb771e04e18d1e7325ae10a91")
+	tx := Types.NewTx(&Types.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(10),
+	})
+
+	tests := []struct {
+		name           string
+		address        string
+		from           common.Address
+		wantErr        bool
+		wantSignerErr  error
+		wantSignedFrom common.Address
+	}{
+		{
+			name:           "Test 1: When transaction is signed by remote signer",
+			address:        signerAddress.Hex(),
+			from:           signerAddress,
+			wantSignedFrom: signerAddress,
+		},
+		{
+			name:    "Test 2: When account is not managed by remote signer",
+			address: to.Hex(),
+			wantErr: true,
+		},
+		{
+			name:          "Test 3: When transaction is signed for a different account",
+			address:       signerAddress.Hex(),
+			from:          to,
+			wantSignerErr: bind.ErrNotAuthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txnOpts, err := remoteSigner.NewTransactor(tt.address, "", chainId)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTransactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if txnOpts.From != signerAddress {
+				t.Errorf("NewTransactor() got From = %s, want %s", txnOpts.From.Hex(), signerAddress.Hex())
+			}
+			signedTx, err := txnOpts.Signer(tt.from, tx)
+			if !errors.Is(err, tt.wantSignerErr) {
+				t.Fatalf("Signer() error = %v, want %v", err, tt.wantSignerErr)
+			}
+			if tt.wantSignerErr != nil {
+				return
+			}
+			sender, err := Types.Sender(Types.LatestSignerForChainID(chainId), signedTx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sender != tt.wantSignedFrom {
+				t.Errorf("Signer() signed by %s, want %s", sender.Hex(), tt.wantSignedFrom.Hex())
+			}
+			if signedTx.Hash() == tx.Hash() {
+				t.Errorf("Signer() returned unsigned transaction")
+			}
+		})
+	}
+}
+
+func TestRemoteSignerUnsupportedOperations(t *testing.T) {
+	remoteSigner, signerAddress := startMockSigner(t, big.NewInt(31337))
+
+	if _, err := remoteSigner.GetPrivateKey(signerAddress.Hex(), ""); err == nil {
+		t.Errorf("GetPrivateKey() expected error with remote signer")
+	}
+	if _, err := remoteSigner.SignData(crypto.Keccak256([]byte("razororacle")), signerAddress.Hex(), ""); err == nil {
+		t.Errorf("SignData() expected error with remote signer")
+	}
+	if _, err := remoteSigner.NewAccount(""); err == nil {
+		t.Errorf("NewAccount() expected error with remote signer")
+	}
+	if err := remoteSigner.VerifyAccount("0x_invalid_address", ""); err == nil {
+		t.Errorf("VerifyAccount() expected error for invalid address")
+	}
+}
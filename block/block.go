@@ -2,16 +2,21 @@ package block
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/sirupsen/logrus"
+	"razor/core"
 	"razor/rpc"
 )
 
 // BlockMonitor monitors the latest block and handles stale blocks.
+// The latest block is received from newHeads subscriptions over the websocket endpoints if there are any,
+// and polled from the best RPC endpoint otherwise or while no subscription is active.
 type BlockMonitor struct {
 	client         *ethclient.Client
 	rpcManager     *rpc.RPCManager
@@ -19,6 +24,10 @@ type BlockMonitor struct {
 	mu             sync.Mutex
 	checkInterval  time.Duration
 	staleThreshold time.Duration
+	// subscribers receive every new latest block, each channel only buffers the most recent header
+	subscribers []chan *types.Header
+	// isSubscribed is set while a newHeads subscription is active, the latest block isn't polled then
+	isSubscribed atomic.Bool
 }
 
 // NewBlockMonitor initializes a BlockMonitor with RPC integration.
@@ -35,11 +44,17 @@ func NewBlockMonitor(client *ethclient.Client, rpcManager *rpc.RPCManager, check
 func (bm *BlockMonitor) Start() {
 	go func() {
 		for {
-			bm.updateLatestBlock()
+			if !bm.isSubscribed.Load() {
+				bm.updateLatestBlock()
+			}
 			bm.checkForStaleBlock()
 			time.Sleep(bm.checkInterval)
 		}
 	}()
+
+	if bm.rpcManager != nil && len(bm.rpcManager.GetWebSocketEndpointURLs()) > 0 {
+		go bm.subscribeToNewHeads()
+	}
 }
 
 // GetLatestBlock retrieves the most recent block header safely.
@@ -49,6 +64,47 @@ func (bm *BlockMonitor) GetLatestBlock() *types.Header {
 	return bm.latestBlock
 }
 
+// Subscribe returns a channel receiving every new latest block header, starting with the current one.
+// The channel only buffers the most recent header, so a subscriber busy with a block receives the latest header afterwards.
+func (bm *BlockMonitor) Subscribe() <-chan *types.Header {
+	headers := make(chan *types.Header, 1)
+
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	bm.subscribers = append(bm.subscribers, headers)
+	if bm.latestBlock != nil {
+		headers <- bm.latestBlock
+	}
+	return headers
+}
+
+// Unsubscribe stops sending new latest block headers to a channel returned by Subscribe.
+func (bm *BlockMonitor) Unsubscribe(headers <-chan *types.Header) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	for i, subscriber := range bm.subscribers {
+		if subscriber == headers {
+			bm.subscribers = append(bm.subscribers[:i], bm.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// setLatestBlock updates the latest block and sends it to the subscribers, the caller must hold the lock.
+func (bm *BlockMonitor) setLatestBlock(header *types.Header) {
+	bm.latestBlock = header
+	for _, subscriber := range bm.subscribers {
+		// Replacing a header the subscriber hasn't received yet, only the latest block is relevant
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- header
+	}
+}
+
 // updateLatestBlock fetches the latest block and updates the state.
 func (bm *BlockMonitor) updateLatestBlock() {
 	if bm.client == nil {
@@ -86,7 +142,76 @@ func (bm *BlockMonitor) updateLatestBlock() {
 
 	// Update the latest block only if it changes.
 	if bm.latestBlock == nil || header.Number.Uint64() != bm.latestBlock.Number.Uint64() {
-		bm.latestBlock = header
+		bm.setLatestBlock(header)
+	}
+}
+
+// subscribeToNewHeads follows newHeads subscriptions over the websocket endpoints, the best endpoint first.
+// When a subscription drops, the next endpoint is tried and the latest block is polled until a subscription is active again.
+func (bm *BlockMonitor) subscribeToNewHeads() {
+	for {
+		for _, url := range bm.rpcManager.GetWebSocketEndpointURLs() {
+			err := bm.followNewHeads(url)
+			logrus.Warnf("newHeads subscription over %s ended, falling back to polling: %v", url, err)
+		}
+		time.Sleep(core.BlockSubscriptionRetryInterval * time.Second)
+	}
+}
+
+// followNewHeads subscribes to new heads over a websocket endpoint and updates the latest block until the subscription drops.
+func (bm *BlockMonitor) followNewHeads(url string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), core.EndpointsContextTimeout*time.Second)
+	client, err := ethclient.DialContext(ctx, url)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+
+	headers := make(chan *types.Header)
+	subscription, err := client.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	defer subscription.Unsubscribe()
+
+	logrus.Infof("Subscribed to new heads over %s", url)
+	bm.isSubscribed.Store(true)
+	defer bm.isSubscribed.Store(false)
+
+	// A subscription which doesn't deliver new heads within the stale threshold is dropped
+	var (
+		staleTimer *time.Timer
+		stale      <-chan time.Time
+	)
+	if bm.staleThreshold > 0 {
+		staleTimer = time.NewTimer(bm.staleThreshold)
+		defer staleTimer.Stop()
+		stale = staleTimer.C
+	}
+
+	for {
+		select {
+		case err := <-subscription.Err():
+			return err
+		case header := <-headers:
+			bm.updateSubscribedBlock(header)
+			if staleTimer != nil {
+				staleTimer.Reset(bm.staleThreshold)
+			}
+		case <-stale:
+			return fmt.Errorf("no new head received for %s", bm.staleThreshold)
+		}
+	}
+}
+
+// updateSubscribedBlock updates the latest block with a header received from a subscription if it is newer.
+func (bm *BlockMonitor) updateSubscribedBlock(header *types.Header) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.latestBlock == nil || header.Number.Uint64() > bm.latestBlock.Number.Uint64() {
+		bm.setLatestBlock(header)
 	}
 }
 
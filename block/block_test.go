@@ -1,13 +1,16 @@
 package block
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -238,3 +241,108 @@ func TestBlockMonitorSwitchFailure(t *testing.T) {
 		t.Errorf("Expected best endpoint to remain %s, got %s", ts1.URL, bestURL)
 	}
 }
+
+// TestBlockMonitorSubscribe tests that subscribers receive the current block and only the latest of the new blocks.
+func TestBlockMonitorSubscribe(t *testing.T) {
+	bm := &BlockMonitor{}
+	bm.updateSubscribedBlock(&types.Header{Number: big.NewInt(100)})
+
+	headers := bm.Subscribe()
+	if header := <-headers; header.Number.Uint64() != 100 {
+		t.Errorf("Expected the current block 100 on subscribing, got %d", header.Number.Uint64())
+	}
+
+	// A subscriber busy handling a block only receives the latest block afterwards
+	bm.updateSubscribedBlock(&types.Header{Number: big.NewInt(101)})
+	bm.updateSubscribedBlock(&types.Header{Number: big.NewInt(102)})
+	// An older block received from a subscription is ignored
+	bm.updateSubscribedBlock(&types.Header{Number: big.NewInt(99)})
+	if header := <-headers; header.Number.Uint64() != 102 {
+		t.Errorf("Expected the latest block 102, got %d", header.Number.Uint64())
+	}
+	select {
+	case header := <-headers:
+		t.Errorf("Expected no further block, got %d", header.Number.Uint64())
+	default:
+	}
+
+	bm.Unsubscribe(headers)
+	bm.updateSubscribedBlock(&types.Header{Number: big.NewInt(103)})
+	select {
+	case header := <-headers:
+		t.Errorf("Expected no block after unsubscribing, got %d", header.Number.Uint64())
+	default:
+	}
+}
+
+// newHeadsService serves eth_subscribe newHeads, sending one header per block number.
+type newHeadsService struct {
+	blockNumbers []uint64
+}
+
+func (s *newHeadsService) NewHeads(ctx context.Context) (*gethRPC.Subscription, error) {
+	notifier, supported := gethRPC.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethRPC.ErrNotificationsUnsupported
+	}
+	subscription := notifier.CreateSubscription()
+	go func() {
+		for _, blockNumber := range s.blockNumbers {
+			header := &types.Header{Number: new(big.Int).SetUint64(blockNumber), Time: uint64(time.Now().Unix()), Difficulty: big.NewInt(2)}
+			if err := notifier.Notify(subscription.ID, header); err != nil {
+				return
+			}
+		}
+	}()
+	return subscription, nil
+}
+
+// TestBlockMonitorFollowNewHeads tests that the latest block is updated from a newHeads subscription until it drops.
+func TestBlockMonitorFollowNewHeads(t *testing.T) {
+	server := gethRPC.NewServer()
+	if err := server.RegisterName("eth", &newHeadsService{blockNumbers: []uint64{200, 201}}); err != nil {
+		t.Fatalf("RegisterName failed: %v", err)
+	}
+	ts := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(func() { ts.Close() })
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	bm := NewBlockMonitor(nil, &rpc.RPCManager{Endpoints: []*rpc.RPCEndpoint{{URL: wsURL}}}, 1, 10)
+	headers := bm.Subscribe()
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- bm.followNewHeads(wsURL)
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case header := <-headers:
+			if !bm.isSubscribed.Load() {
+				t.Error("Expected the block monitor to be subscribed while receiving new heads")
+			}
+			if header.Number.Uint64() != 201 {
+				continue
+			}
+		case <-deadline:
+			t.Fatal("Timed out waiting for block 201 from the subscription")
+		}
+		break
+	}
+
+	// Dropping the connection ends the subscription, the block monitor then falls back to polling
+	server.Stop()
+	ts.CloseClientConnections()
+	select {
+	case err := <-errChan:
+		if err == nil {
+			t.Error("Expected an error when the subscription drops")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the subscription to drop")
+	}
+	if bm.isSubscribed.Load() {
+		t.Error("Expected the block monitor not to be subscribed after the subscription dropped")
+	}
+}
@@ -0,0 +1,147 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/notifier"
+	"razor/rpc"
+	"razor/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/pflag"
+)
+
+// balanceGuard keeps the sFUEL balance of the stakers above a minimum balance by topping it up from a funding account
+type balanceGuard struct {
+	minBalance  *big.Int
+	topUpAmount *big.Int
+	// fundingAccount is nil if the balance is only guarded and not topped up
+	fundingAccount *types.Account
+}
+
+// sFuelBalanceGuard is nil unless the vote or supervise command is run with a minimum sFUEL balance
+var sFuelBalanceGuard *balanceGuard
+
+//This function creates the balance guard from the flags, it returns nil if no minimum sFUEL balance is passed
+func getBalanceGuard(flagSet *pflag.FlagSet, config types.Configurations) (*balanceGuard, error) {
+	minBalanceFlag, err := flagSetUtils.GetStringMinSFuelBalance(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	fundingAddress, err := flagSetUtils.GetStringFundingAccount(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	if minBalanceFlag == "" {
+		if fundingAddress != "" {
+			return nil, errors.New("minSFuelBalance is required to top up the sFUEL balance from the funding account")
+		}
+		return nil, nil
+	}
+
+	minBalance, err := parseSFuelAmount(minBalanceFlag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minSFuelBalance: %w", err)
+	}
+	guard := &balanceGuard{
+		minBalance:  minBalance,
+		topUpAmount: minBalance,
+	}
+	topUpAmountFlag, err := flagSetUtils.GetStringTopUpAmount(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	if topUpAmountFlag != "" {
+		guard.topUpAmount, err = parseSFuelAmount(topUpAmountFlag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topUpAmount: %w", err)
+		}
+	}
+	if fundingAddress == "" {
+		return guard, nil
+	}
+
+	fundingPasswordPath, err := flagSetUtils.GetStringFundingPassword(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	fundingAccount, err := initialiseAccountWithPassword(config, fundingAddress, func() string {
+		if fundingPasswordPath != "" {
+			return utils.GetPasswordFromFile(fundingPasswordPath)
+		}
+		log.Info("Enter the password of the funding account")
+		return razorUtils.PasswordPrompt()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in initialising funding account: %w", err)
+	}
+	guard.fundingAccount = &fundingAccount
+	return guard, nil
+}
+
+//This function converts an amount in sFUEL with up to 18 decimals to wei
+func parseSFuelAmount(amount string) (*big.Int, error) {
+	valueInWei, err := parseAmountInWei(amount, "sFUEL")
+	if err != nil {
+		return nil, err
+	}
+	if valueInWei.Sign() == 0 {
+		return nil, fmt.Errorf("%q is not a positive amount of sFUEL", amount)
+	}
+	return valueInWei, nil
+}
+
+//This function converts a non negative amount of sFUEL or RZR with up to 18 decimals to wei
+func parseAmountInWei(amount string, currency string) (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not a valid amount of %s", amount, currency)
+	}
+	valueInWei := value.Mul(value, new(big.Rat).SetInt(big.NewInt(1e18)))
+	if !valueInWei.IsInt() {
+		return nil, fmt.Errorf("%q has more than 18 decimals", amount)
+	}
+	return valueInWei.Num(), nil
+}
+
+//This function tops up the sFUEL balance of the staker once per epoch if it is below the minimum balance of the balance guard.
+//It returns whether the balance is too low to guarantee the commit and reveal transactions of the epoch, in which case non-essential transactions are paused.
+func guardSFuelBalance(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, epoch uint32, balance *big.Int) bool {
+	guard := sFuelBalanceGuard
+	if guard == nil {
+		return false
+	}
+
+	state := getStakerState(rpcParameters.Ctx)
+	if balance.Cmp(guard.minBalance) < 0 && guard.fundingAccount != nil && state.lastTopUpEpoch < epoch {
+		// Topping up at most once per epoch, so that a failing top up doesn't drain the funding account
+		state.lastTopUpEpoch = epoch
+		log.Warnf("sFUEL balance %g is lower than the minimum balance %g, topping up %g sFUEL from %s", utils.GetAmountInDecimal(balance), utils.GetAmountInDecimal(guard.minBalance), utils.GetAmountInDecimal(guard.topUpAmount), guard.fundingAccount.Address)
+		fundingBalance, err := clientUtils.BalanceAtWithRetry(rpcParameters, common.HexToAddress(guard.fundingAccount.Address))
+		if err != nil {
+			log.Error("Error in fetching sFUEL balance of the funding account: ", err)
+		} else {
+			_, err = cmdUtils.TransferSFuel(rpcParameters, config, types.TransferInput{
+				Account:    *guard.fundingAccount,
+				ToAddress:  account.Address,
+				ValueInWei: guard.topUpAmount,
+				Balance:    fundingBalance,
+			})
+			if err != nil {
+				log.Error("Error in topping up sFUEL balance: ", err)
+				eventNotifier.Notify(notifier.EventLowSFuelBalance, account.Address, epoch, fmt.Sprintf("sFUEL balance couldn't be topped up from %s: %v", guard.fundingAccount.Address, err))
+			}
+		}
+	}
+
+	reserve := new(big.Int).Mul(gasUtils.GetGasPrice(rpcParameters, config), big.NewInt(core.SFuelReserveGasLimit))
+	if balance.Cmp(reserve) < 0 {
+		log.Warnf("sFUEL balance %g is lower than the %g sFUEL needed to commit and reveal, pausing non-essential transactions", utils.GetAmountInDecimal(balance), utils.GetAmountInDecimal(reserve))
+		return true
+	}
+	return false
+}
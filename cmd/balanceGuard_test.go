@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"razor/core/types"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseSFuelAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			name:   "Test 1: When the amount is a whole number of sFUEL",
+			amount: "2",
+			want:   big.NewInt(2e18),
+		},
+		{
+			name:   "Test 2: When the amount has decimals",
+			amount: "0.001",
+			want:   big.NewInt(1e15),
+		},
+		{
+			name:    "Test 3: When the amount is not a number",
+			amount:  "one",
+			wantErr: true,
+		},
+		{
+			name:    "Test 4: When the amount is not positive",
+			amount:  "0",
+			wantErr: true,
+		},
+		{
+			name:    "Test 5: When the amount has more than 18 decimals",
+			amount:  "0.0000000000000000001",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSFuelAmount(tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSFuelAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Cmp(tt.want) != 0 {
+				t.Errorf("parseSFuelAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardSFuelBalance(t *testing.T) {
+	var config types.Configurations
+	account := types.Account{Address: "0x000000000000000000000000000000000000dead"}
+	fundingAccount := types.Account{Address: "0x000000000000000000000000000000000000beef"}
+
+	type args struct {
+		guard             *balanceGuard
+		balance           *big.Int
+		lastTopUpEpoch    uint32
+		fundingBalance    *big.Int
+		fundingBalanceErr error
+		gasPrice          *big.Int
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantTopUp        bool
+		wantIsLowOnFunds bool
+	}{
+		{
+			name: "Test 1: When there is no balance guard",
+			args: args{
+				balance: big.NewInt(0),
+			},
+			wantTopUp:        false,
+			wantIsLowOnFunds: false,
+		},
+		{
+			name: "Test 2: When the balance is below the minimum balance and is topped up",
+			args: args{
+				guard:          &balanceGuard{minBalance: big.NewInt(1e16), topUpAmount: big.NewInt(1e17), fundingAccount: &fundingAccount},
+				balance:        big.NewInt(1e15),
+				fundingBalance: big.NewInt(1e18),
+				gasPrice:       big.NewInt(100000),
+			},
+			wantTopUp:        true,
+			wantIsLowOnFunds: false,
+		},
+		{
+			name: "Test 3: When the balance was already topped up in the epoch",
+			args: args{
+				guard:          &balanceGuard{minBalance: big.NewInt(1e16), topUpAmount: big.NewInt(1e17), fundingAccount: &fundingAccount},
+				balance:        big.NewInt(1e15),
+				lastTopUpEpoch: 5,
+				gasPrice:       big.NewInt(100000),
+			},
+			wantTopUp:        false,
+			wantIsLowOnFunds: false,
+		},
+		{
+			name: "Test 4: When the balance is above the minimum balance",
+			args: args{
+				guard:    &balanceGuard{minBalance: big.NewInt(1e16), topUpAmount: big.NewInt(1e17), fundingAccount: &fundingAccount},
+				balance:  big.NewInt(1e17),
+				gasPrice: big.NewInt(100000),
+			},
+			wantTopUp:        false,
+			wantIsLowOnFunds: false,
+		},
+		{
+			name: "Test 5: When there is no funding account and the balance can't pay for commit and reveal",
+			args: args{
+				guard:    &balanceGuard{minBalance: big.NewInt(1e16), topUpAmount: big.NewInt(1e16)},
+				balance:  big.NewInt(1e15),
+				gasPrice: big.NewInt(1e10),
+			},
+			wantTopUp:        false,
+			wantIsLowOnFunds: true,
+		},
+		{
+			name: "Test 6: When there is an error in fetching the balance of the funding account",
+			args: args{
+				guard:             &balanceGuard{minBalance: big.NewInt(1e16), topUpAmount: big.NewInt(1e17), fundingAccount: &fundingAccount},
+				balance:           big.NewInt(1e15),
+				fundingBalanceErr: errors.New("balance error"),
+				gasPrice:          big.NewInt(100000),
+			},
+			wantTopUp:        false,
+			wantIsLowOnFunds: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+			sFuelBalanceGuard = tt.args.guard
+			defer func() { sFuelBalanceGuard = nil }()
+
+			state := &stakerState{lastTopUpEpoch: tt.args.lastTopUpEpoch}
+			guardRPCParameters := rpcParameters
+			guardRPCParameters.Ctx = withStakerState(context.Background(), state)
+
+			clientUtilsMock.On("BalanceAtWithRetry", mock.Anything, common.HexToAddress(fundingAccount.Address)).Return(tt.args.fundingBalance, tt.args.fundingBalanceErr)
+			cmdUtilsMock.On("TransferSFuel", mock.Anything, mock.Anything, mock.Anything).Return(common.BigToHash(big.NewInt(1)), nil)
+			gasUtilsMock.On("GetGasPrice", mock.Anything, mock.Anything).Return(tt.args.gasPrice)
+
+			if got := guardSFuelBalance(guardRPCParameters, config, account, 5, tt.args.balance); got != tt.wantIsLowOnFunds {
+				t.Errorf("guardSFuelBalance() = %v, want %v", got, tt.wantIsLowOnFunds)
+			}
+			if tt.wantTopUp {
+				cmdUtilsMock.AssertCalled(t, "TransferSFuel", mock.Anything, config, types.TransferInput{
+					Account:    fundingAccount,
+					ToAddress:  account.Address,
+					ValueInWei: tt.args.guard.topUpAmount,
+					Balance:    tt.args.fundingBalance,
+				})
+				if state.lastTopUpEpoch != 5 {
+					t.Errorf("Expected last top up epoch to be 5, got %d", state.lastTopUpEpoch)
+				}
+			} else {
+				cmdUtilsMock.AssertNotCalled(t, "TransferSFuel", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
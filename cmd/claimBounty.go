@@ -68,28 +68,29 @@ func (*UtilsStruct) HandleClaimBounty(rpcParameters rpc.RPCParameters, config ty
 	if err != nil {
 		return err
 	}
+	state := getStakerState(rpcParameters.Ctx)
 	log.Debug("HandleClaimBounty: Dispute data file path: ", disputeFilePath)
 	if _, err := path.OSUtilsInterface.Stat(disputeFilePath); !errors.Is(err, os.ErrNotExist) {
 		log.Debug("Fetching the dispute data from dispute data file...")
-		disputeData, err = fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
+		state.disputeData, err = fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
 		if err != nil {
 			return err
 		}
 
-		log.Debugf("HandleClaimBounty: DisputeData: %+v", disputeData)
+		log.Debugf("HandleClaimBounty: DisputeData: %+v", state.disputeData)
 	}
 
-	if disputeData.BountyIdQueue == nil {
+	if state.disputeData.BountyIdQueue == nil {
 		log.Error("No bounty id's present")
 		return errors.New("no bounty earned")
 	}
 
-	if disputeData.BountyIdQueue != nil {
-		log.Info("Bounty ids that needs be claimed: ", disputeData.BountyIdQueue)
-		length := len(disputeData.BountyIdQueue)
-		log.Info("Claiming bounty for bountyId ", disputeData.BountyIdQueue[length-1])
+	if state.disputeData.BountyIdQueue != nil {
+		log.Info("Bounty ids that needs be claimed: ", state.disputeData.BountyIdQueue)
+		length := len(state.disputeData.BountyIdQueue)
+		log.Info("Claiming bounty for bountyId ", state.disputeData.BountyIdQueue[length-1])
 		redeemBountyInput := types.RedeemBountyInput{
-			BountyId: disputeData.BountyIdQueue[length-1],
+			BountyId: state.disputeData.BountyIdQueue[length-1],
 			Account:  account,
 		}
 		log.Debugf("HandleClaimBounty: Calling ClaimBounty() with arguments redeemBountyInput: %+v", redeemBountyInput)
@@ -100,18 +101,22 @@ func (*UtilsStruct) HandleClaimBounty(rpcParameters rpc.RPCParameters, config ty
 		if claimBountyTxn != core.NilHash {
 			claimBountyErr := razorUtils.WaitForBlockCompletion(rpcParameters, claimBountyTxn.Hex())
 			if claimBountyErr == nil {
-				if len(disputeData.BountyIdQueue) > 1 {
+				if len(state.disputeData.BountyIdQueue) > 1 {
 					//Removing the bountyId from the queue as the bounty is being claimed
-					disputeData.BountyIdQueue = disputeData.BountyIdQueue[:length-1]
+					state.disputeData.BountyIdQueue = state.disputeData.BountyIdQueue[:length-1]
 				} else {
-					disputeData.BountyIdQueue = nil
+					state.disputeData.BountyIdQueue = nil
 				}
 			}
 		}
 	}
 
+	if utils.DryRun {
+		log.Info("Dry run: Not saving the dispute data file, the bounty was not claimed")
+		return nil
+	}
 	log.Debug("Saving the updated dispute data to dispute data file...")
-	err = fileUtils.SaveDataToDisputeJsonFile(disputeFilePath, disputeData.BountyIdQueue)
+	err = fileUtils.SaveDataToDisputeJsonFile(disputeFilePath, state.disputeData.BountyIdQueue)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"razor/cmd/mocks"
 	"razor/core"
 	"razor/core/types"
+	"razor/utils"
 	utilsPkgMocks "razor/utils/mocks"
 	"testing"
 
@@ -319,6 +320,7 @@ func TestHandleClaimBounty(t *testing.T) {
 		claimBountyTxn     common.Hash
 		claimBountyTxnErr  error
 		saveDataErr        error
+		dryRun             bool
 	}
 	tests := []struct {
 		name    string
@@ -384,22 +386,42 @@ func TestHandleClaimBounty(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "When the bounty is claimed in dry run mode",
+			args: args{
+				disputeFilePath: "",
+				statErr:         nil,
+				disputeData:     types.DisputeFileData{BountyIdQueue: []uint32{1, 2}},
+				claimBountyTxn:  common.BigToHash(big.NewInt(1)),
+				dryRun:          true,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			SetUpMockInterfaces()
+			utils.DryRun = tt.args.dryRun
+			t.Cleanup(func() { utils.DryRun = false })
+			var waitErr error
+			if tt.args.dryRun {
+				waitErr = utils.ErrDryRunTransaction
+			}
 
 			pathMock.On("GetDisputeDataFileName", mock.AnythingOfType("string")).Return(tt.args.disputeFilePath, tt.args.disputeFilePathErr)
 			osPathMock.On("Stat", mock.Anything).Return(fileInfo, tt.args.statErr)
 			fileUtilsMock.On("ReadFromDisputeJsonFile", mock.Anything).Return(tt.args.disputeData, tt.args.disputeDataErr)
 			cmdUtilsMock.On("ClaimBounty", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.claimBountyTxn, tt.args.claimBountyTxnErr)
-			utilsMock.On("WaitForBlockCompletion", mock.Anything, mock.Anything).Return(nil)
+			utilsMock.On("WaitForBlockCompletion", mock.Anything, mock.Anything).Return(waitErr)
 			fileUtilsMock.On("SaveDataToDisputeJsonFile", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.saveDataErr)
 
 			ut := &UtilsStruct{}
 			if err := ut.HandleClaimBounty(rpcParameters, config, account); (err != nil) != tt.wantErr {
 				t.Errorf("AutoClaimBounty() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.args.dryRun {
+				fileUtilsMock.AssertNotCalled(t, "SaveDataToDisputeJsonFile", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
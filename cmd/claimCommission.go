@@ -6,8 +6,10 @@ import (
 	"razor/core"
 	"razor/core/types"
 	"razor/pkg/bindings"
+	"razor/rpc"
 	"razor/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/pflag"
 
 	"github.com/spf13/cobra"
@@ -38,28 +40,10 @@ func (*UtilsStruct) ClaimCommission(flagSet *pflag.FlagSet) {
 	log.Debugf("ClaimCommission: Staker Info: %+v", stakerInfo)
 
 	if stakerInfo.StakerReward.Cmp(big.NewInt(0)) > 0 {
-		txnOpts, err := razorUtils.GetTxnOpts(rpcParameters, types.TransactionOptions{
-			ChainId:         core.ChainId,
-			Config:          config,
-			ContractAddress: core.StakeManagerAddress,
-			MethodName:      "claimStakerReward",
-			Parameters:      []interface{}{},
-			ABI:             bindings.StakeManagerMetaData.ABI,
-			Account:         account,
-		})
-		utils.CheckError("Error in getting txn options: ", err)
-
 		log.Info("Claiming commission...")
-
-		client, err := rpcParameters.RPCManager.GetBestRPCClient()
-		utils.CheckError("Error in getting best RPC client: ", err)
-
-		log.Debug("Executing ClaimStakeReward transaction...")
-		txn, err := stakeManagerUtils.ClaimStakerReward(client, txnOpts)
+		txnHash, err := claimStakerReward(rpcParameters, config, account)
 		utils.CheckError("Error in claiming stake reward: ", err)
 
-		txnHash := transactionUtils.Hash(txn)
-		log.Info("Txn Hash: ", txnHash.Hex())
 		err = razorUtils.WaitForBlockCompletion(rpcParameters, txnHash.Hex())
 		utils.CheckError("Error in WaitForBlockCompletion for claimCommission: ", err)
 	} else {
@@ -68,6 +52,39 @@ func (*UtilsStruct) ClaimCommission(flagSet *pflag.FlagSet) {
 	}
 }
 
+//This function sends the transaction claiming the commission of the staker and returns its hash
+func claimStakerReward(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account) (common.Hash, error) {
+	txnOpts, err := razorUtils.GetTxnOpts(rpcParameters, types.TransactionOptions{
+		ChainId:         core.ChainId,
+		Config:          config,
+		ContractAddress: core.StakeManagerAddress,
+		MethodName:      "claimStakerReward",
+		Parameters:      []interface{}{},
+		ABI:             bindings.StakeManagerMetaData.ABI,
+		Account:         account,
+	})
+	if err != nil {
+		log.Error("Error in getting txn options: ", err)
+		return core.NilHash, err
+	}
+
+	client, err := rpcParameters.RPCManager.GetBestRPCClient()
+	if err != nil {
+		log.Error("Error in getting best RPC client: ", err)
+		return core.NilHash, err
+	}
+
+	log.Debug("Executing ClaimStakeReward transaction...")
+	txn, err := stakeManagerUtils.ClaimStakerReward(client, txnOpts)
+	if err != nil {
+		return core.NilHash, err
+	}
+
+	txnHash := transactionUtils.Hash(txn)
+	log.Info("Txn Hash: ", txnHash.Hex())
+	return txnHash, nil
+}
+
 func init() {
 	rootCmd.AddCommand(claimCommissionCmd)
 
@@ -153,19 +153,8 @@ func InitializeCommandDependencies(flagSet *pflag.FlagSet) (types.Configurations
 		}
 		log.Debugf("Address: %v", address)
 
-		log.Debug("Getting password...")
-		password := razorUtils.AssignPassword(flagSet)
-
-		accountManager, err := razorUtils.AccountManagerForKeystore()
-		if err != nil {
-			log.Error("Error in getting accounts manager for keystore: ", err)
-			return types.Configurations{}, rpc.RPCParameters{}, nil, types.Account{}, err
-		}
-
-		account = accounts.InitAccountStruct(address, password, accountManager)
-		err = razorUtils.CheckPassword(account)
+		account, err = initialiseAccount(flagSet, config, address)
 		if err != nil {
-			log.Error("Error in fetching private key from given password: ", err)
 			return types.Configurations{}, rpc.RPCParameters{}, nil, types.Account{}, err
 		}
 	}
@@ -198,3 +187,45 @@ func InitializeCommandDependencies(flagSet *pflag.FlagSet) (types.Configurations
 
 	return config, rpcParameters, blockMonitor, account, nil
 }
+
+//This function returns the account for the given address which signs with the remote signer if it is configured and with the keystore otherwise
+func initialiseAccount(flagSet *pflag.FlagSet, config types.Configurations, address string) (types.Account, error) {
+	return initialiseAccountWithPassword(config, address, func() string {
+		return razorUtils.AssignPassword(flagSet)
+	})
+}
+
+//This function returns the account for the given address, getPassword is only called if the account is unlocked with the keystore
+func initialiseAccountWithPassword(config types.Configurations, address string, getPassword func() string) (types.Account, error) {
+	var (
+		password       string
+		accountManager types.AccountManagerInterface
+		err            error
+	)
+	if config.SignerURL != "" {
+		// The remote signer unlocks its accounts itself, so no password is needed
+		log.Info("Using remote signer to sign transactions")
+		accountManager, err = razorUtils.AccountManagerForRemoteSigner(config.SignerURL)
+		if err != nil {
+			log.Error("Error in getting accounts manager for remote signer: ", err)
+			return types.Account{}, err
+		}
+	} else {
+		log.Debug("Getting password...")
+		password = getPassword()
+
+		accountManager, err = razorUtils.AccountManagerForKeystore()
+		if err != nil {
+			log.Error("Error in getting accounts manager for keystore: ", err)
+			return types.Account{}, err
+		}
+	}
+
+	account := accounts.InitAccountStruct(address, password, accountManager)
+	err = razorUtils.CheckPassword(account)
+	if err != nil {
+		log.Error("Error in verifying account: ", err)
+		return types.Account{}, err
+	}
+	return account, nil
+}
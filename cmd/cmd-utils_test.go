@@ -6,6 +6,9 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/mock"
 	"math/big"
+	accountsPkgMocks "razor/accounts/mocks"
+	"razor/core/types"
+	"reflect"
 	"testing"
 )
 
@@ -440,3 +443,93 @@ func TestGetFormattedStateNames(t *testing.T) {
 		})
 	}
 }
+
+func TestInitialiseAccount(t *testing.T) {
+	var flagSet *pflag.FlagSet
+	keystoreAccountManager := new(accountsPkgMocks.AccountManagerInterface)
+	remoteAccountManager := new(accountsPkgMocks.AccountManagerInterface)
+	address := "0x57Baf83BAD5bee0F7F44d84669A50C35c57E3576"
+
+	type args struct {
+		config           types.Configurations
+		password         string
+		keystoreErr      error
+		remoteSignerErr  error
+		checkPasswordErr error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    types.Account
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When remote signer is not configured and account is initialised with keystore",
+			args: args{
+				password: "test",
+			},
+			want: types.Account{
+				Address:        address,
+				Password:       "test",
+				AccountManager: keystoreAccountManager,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 2: When remote signer is configured and account is initialised without password",
+			args: args{
+				config:   types.Configurations{SignerURL: "http://localhost:8550"},
+				password: "test",
+			},
+			want: types.Account{
+				Address:        address,
+				AccountManager: remoteAccountManager,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 3: When there is an error in connecting to remote signer",
+			args: args{
+				config:          types.Configurations{SignerURL: "http://localhost:8550"},
+				remoteSignerErr: errors.New("remote signer error"),
+			},
+			want:    types.Account{},
+			wantErr: true,
+		},
+		{
+			name: "Test 4: When there is an error in getting account manager for keystore",
+			args: args{
+				keystoreErr: errors.New("keystore error"),
+			},
+			want:    types.Account{},
+			wantErr: true,
+		},
+		{
+			name: "Test 5: When account is not verified",
+			args: args{
+				config:           types.Configurations{SignerURL: "http://localhost:8550"},
+				checkPasswordErr: errors.New("account is not managed by remote signer"),
+			},
+			want:    types.Account{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("AssignPassword", flagSet).Return(tt.args.password)
+			utilsMock.On("AccountManagerForKeystore").Return(keystoreAccountManager, tt.args.keystoreErr)
+			utilsMock.On("AccountManagerForRemoteSigner", tt.args.config.SignerURL).Return(remoteAccountManager, tt.args.remoteSignerErr)
+			utilsMock.On("CheckPassword", mock.Anything).Return(tt.args.checkPasswordErr)
+
+			got, err := initialiseAccount(flagSet, tt.args.config, address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("initialiseAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("initialiseAccount() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,14 +2,11 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
 	"razor/rpc"
 	"razor/utils"
 	"strconv"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -20,7 +17,8 @@ var collectionListCmd = &cobra.Command{
 	Short: "list of all collections",
 	Long: `Provides the list of all collections with their name, power, ID etc.
 Example:
-	./razor collectionList --logFile collectionListLogs`,
+	./razor collectionList --logFile collectionListLogs
+	./razor collectionList --output csv`,
 	Run: initialiseCollectionList,
 }
 
@@ -34,13 +32,27 @@ func (*UtilsStruct) ExecuteCollectionList(flagSet *pflag.FlagSet) {
 	_, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
 	utils.CheckError("Error in initialising command dependencies: ", err)
 
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
 	log.Debug("Calling GetCollectionList()")
-	err = cmdUtils.GetCollectionList(rpcParameters)
+	err = cmdUtils.GetCollectionList(rpcParameters, output)
 	utils.CheckError("Error in getting collection list: ", err)
 }
 
-//This function provides the list of all collections with their name, power, ID etc.
-func (*UtilsStruct) GetCollectionList(rpcParameters rpc.RPCParameters) error {
+// collectionRecord is a collection in the output of collectionList
+type collectionRecord struct {
+	Active            bool     `json:"active" yaml:"active"`
+	Id                uint16   `json:"id" yaml:"id"`
+	Power             int8     `json:"power" yaml:"power"`
+	AggregationMethod uint32   `json:"aggregationMethod" yaml:"aggregationMethod"`
+	JobIds            []uint16 `json:"jobIds" yaml:"jobIds"`
+	Name              string   `json:"name" yaml:"name"`
+	Tolerance         uint32   `json:"tolerance" yaml:"tolerance"`
+}
+
+//This function provides the list of all collections with their name, power, ID etc. in the given output format
+func (*UtilsStruct) GetCollectionList(rpcParameters rpc.RPCParameters, output string) error {
 	collections, err := razorUtils.GetAllCollections(rpcParameters)
 	log.Debugf("GetCollectionList: Collections: %+v", collections)
 
@@ -48,29 +60,44 @@ func (*UtilsStruct) GetCollectionList(rpcParameters rpc.RPCParameters) error {
 		return err
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Active", "Collection Id", "Power", "Aggregation Method", "Job IDs", "Name", "Tolerance"})
+	records := make([]collectionRecord, 0, len(collections))
+	var rows [][]string
 	for i := 0; i < len(collections); i++ {
-		jobIDs, _ := json.Marshal(collections[i].JobIDs)
+		jobIds := make([]string, len(collections[i].JobIDs))
+		for j, jobId := range collections[i].JobIDs {
+			jobIds[j] = strconv.Itoa(int(jobId))
+		}
 
-		table.Append([]string{
+		records = append(records, collectionRecord{
+			Active:            collections[i].Active,
+			Id:                collections[i].Id,
+			Power:             collections[i].Power,
+			AggregationMethod: collections[i].AggregationMethod,
+			JobIds:            append([]uint16{}, collections[i].JobIDs...),
+			Name:              collections[i].Name,
+			Tolerance:         collections[i].Tolerance,
+		})
+		rows = append(rows, []string{
 			strconv.FormatBool(collections[i].Active),
 			strconv.Itoa(int(collections[i].Id)),
 			strconv.Itoa(int(collections[i].Power)),
 			strconv.Itoa(int(collections[i].AggregationMethod)),
-			strings.Trim(string(jobIDs), "[]"),
+			strings.Join(jobIds, ","),
 			collections[i].Name,
 			strconv.Itoa(int(collections[i].Tolerance)),
 		})
-
 	}
 
-	table.Render()
-	return nil
-
+	return printOutput(output, outputData{
+		Header:  []string{"Active", "Collection Id", "Power", "Aggregation Method", "Job IDs", "Name", "Tolerance"},
+		Fields:  []string{"active", "id", "power", "aggregationMethod", "jobIds", "name", "tolerance"},
+		Rows:    rows,
+		Records: records,
+	})
 }
 
 func init() {
 	rootCmd.AddCommand(collectionListCmd)
 
+	addOutputFlag(collectionListCmd)
 }
@@ -71,7 +71,7 @@ func TestGetCollectionList(t *testing.T) {
 			utilsMock.On("GetAllCollections", mock.Anything).Return(tt.args.collectionList, tt.args.collectionListErr)
 			utils := &UtilsStruct{}
 
-			err := utils.GetCollectionList(rpcParameters)
+			err := utils.GetCollectionList(rpcParameters, OutputTable)
 
 			if err == nil || tt.wantErr == nil {
 				if err != tt.wantErr {
@@ -142,7 +142,8 @@ func TestExecuteCollectionList(t *testing.T) {
 			fileUtilsMock.On("AssignLogFile", mock.AnythingOfType("*pflag.FlagSet"), mock.Anything)
 			cmdUtilsMock.On("GetConfigData").Return(tt.args.config, tt.args.configErr)
 			utilsMock.On("ConnectToClient", mock.AnythingOfType("string")).Return(client)
-			cmdUtilsMock.On("GetCollectionList", mock.Anything).Return(tt.args.collectionListErr)
+			flagSetMock.On("GetStringOutput", mock.Anything).Return(OutputTable, nil)
+			cmdUtilsMock.On("GetCollectionList", mock.Anything, mock.Anything).Return(tt.args.collectionListErr)
 
 			utils := &UtilsStruct{}
 			fatal = false
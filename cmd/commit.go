@@ -56,6 +56,10 @@ HandleCommitState fetches the collections assigned to the staker and creates the
 Values for only the collections assigned to the staker is fetched for others, 0 is added to the leaves of tree.
 */
 func (*UtilsStruct) HandleCommitState(rpcParameters rpc.RPCParameters, epoch uint32, seed []byte, commitParams *types.CommitParams, rogueData types.Rogue) (types.CommitData, error) {
+	if utils.ResponseRecorder != nil {
+		utils.ResponseRecorder.SetEpoch(epoch)
+	}
+
 	numActiveCollections, err := razorUtils.GetNumActiveCollections(rpcParameters)
 	if err != nil {
 		return types.CommitData{}, err
@@ -159,6 +163,7 @@ func (*UtilsStruct) Commit(rpcParameters rpc.RPCParameters, config types.Configu
 		MethodName:      "commit",
 		Parameters:      []interface{}{epoch, commitmentToSend},
 		Account:         account,
+		StateWindow:     &types.StateWindow{Epoch: epoch, State: 0},
 	})
 	if err != nil {
 		return core.NilHash, err
@@ -211,6 +216,9 @@ func CalculateCommitment(seed []byte, values []*big.Int) ([32]byte, error) {
 	}
 	commitment := solsha3.SoliditySHA3([]string{"bytes32", "bytes32"}, []interface{}{"0x" + hex.EncodeToString(merkleRoot[:]), "0x" + hex.EncodeToString(seed)})
 	log.Debug("CalculateCommitment: Commitment: ", hex.EncodeToString(commitment))
+	if utils.DryRun {
+		log.Infof("Dry run: Merkle root: 0x%s Commitment: 0x%s", hex.EncodeToString(merkleRoot[:]), hex.EncodeToString(commitment))
+	}
 	commitmentToSend := [32]byte{}
 	copy(commitmentToSend[:], commitment)
 	return commitmentToSend, nil
@@ -233,12 +241,20 @@ func VerifyCommitment(rpcParameters rpc.RPCParameters, account types.Account, co
 }
 
 func GetCommittedDataForEpoch(rpcParameters rpc.RPCParameters, account types.Account, epoch uint32, rogueData types.Rogue) (types.CommitFileData, error) {
+	state := getStakerState(rpcParameters.Ctx)
 	// Attempt to fetch global commit data from memory if epoch matches
-	if globalCommitDataStruct.Epoch == epoch {
+	if state.commitData.Epoch == epoch {
 		log.Debugf("Epoch in global commit data is equal to current epoch %v. Fetching commit data from memory!", epoch)
+	} else if commitDataFromJournal, found := state.getCommitDataFromJournal(epoch); found {
+		log.Infof("Getting the commit data of epoch %v from epoch journal...", epoch)
+		state.updateCommitData(types.CommitData{
+			Leaves:                 commitDataFromJournal.Leaves,
+			SeqAllottedCollections: commitDataFromJournal.SeqAllottedCollections,
+			AssignedCollections:    commitDataFromJournal.AssignedCollections,
+		}, commitDataFromJournal.Commitment, epoch)
 	} else {
 		// Fetch from file if memory data is outdated
-		log.Debugf("GetCommittedDataForEpoch: Global commit data epoch %v doesn't match current epoch %v. Fetching from file!", globalCommitDataStruct.Epoch, epoch)
+		log.Debugf("GetCommittedDataForEpoch: Global commit data epoch %v doesn't match current epoch %v. Fetching from file!", state.commitData.Epoch, epoch)
 		log.Info("Getting the commit data from file...")
 		fileName, err := pathUtils.GetCommitDataFileName(account.Address)
 		if err != nil {
@@ -258,7 +274,7 @@ func GetCommittedDataForEpoch(rpcParameters rpc.RPCParameters, account types.Acc
 		}
 
 		// Update global commit data struct since the file data is valid
-		updateGlobalCommitDataStruct(types.CommitData{
+		state.updateCommitData(types.CommitData{
 			Leaves:                 commitDataFromFile.Leaves,
 			SeqAllottedCollections: commitDataFromFile.SeqAllottedCollections,
 			AssignedCollections:    commitDataFromFile.AssignedCollections,
@@ -267,7 +283,7 @@ func GetCommittedDataForEpoch(rpcParameters rpc.RPCParameters, account types.Acc
 
 	// Verify the final selected commit data
 	log.Debugf("Verifying commit data for epoch %v...", epoch)
-	isValid, err := VerifyCommitment(rpcParameters, account, globalCommitDataStruct.Commitment)
+	isValid, err := VerifyCommitment(rpcParameters, account, state.commitData.Commitment)
 	if err != nil {
 		return types.CommitFileData{}, err
 	}
@@ -278,11 +294,11 @@ func GetCommittedDataForEpoch(rpcParameters rpc.RPCParameters, account types.Acc
 	// If rogue mode is enabled, alter the commitment data
 	if rogueData.IsRogue && utils.Contains(rogueData.RogueMode, "reveal") {
 		log.Warn("YOU ARE REVEALING VALUES IN ROGUE MODE, THIS CAN INCUR PENALTIES!")
-		globalCommitDataStruct.Leaves = generateRogueCommittedData(len(globalCommitDataStruct.Leaves))
-		log.Debugf("Global Commit data struct in rogue mode: %+v", globalCommitDataStruct)
+		state.commitData.Leaves = generateRogueCommittedData(len(state.commitData.Leaves))
+		log.Debugf("Global Commit data struct in rogue mode: %+v", state.commitData)
 	}
 
-	return globalCommitDataStruct, nil
+	return state.commitData, nil
 }
 
 func generateRogueCommittedData(length int) []*big.Int {
@@ -0,0 +1,164 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/metrics"
+	"razor/rpc"
+	"razor/utils"
+
+	"github.com/spf13/pflag"
+)
+
+// compoundingPolicy claims the commission of the staker and stakes the RZR balance above a reserve back in every epoch
+type compoundingPolicy struct {
+	reserve *big.Int
+	// maxAmount caps the RZR staked in an epoch, it is nil if the amount is not capped
+	maxAmount *big.Int
+}
+
+// autoCompoundingPolicy is nil unless the vote or supervise command is run with auto compounding
+var autoCompoundingPolicy *compoundingPolicy
+
+//This function creates the compounding policy from the flags, it returns nil if auto compounding is not enabled
+func getCompoundingPolicy(flagSet *pflag.FlagSet) (*compoundingPolicy, error) {
+	autoCompound, err := flagSetUtils.GetBoolAutoCompound(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	reserveFlag, err := flagSetUtils.GetStringCompoundReserve(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	maxAmountFlag, err := flagSetUtils.GetStringMaxCompoundAmount(flagSet)
+	if err != nil {
+		return nil, err
+	}
+	if !autoCompound {
+		if reserveFlag != "" || maxAmountFlag != "" {
+			return nil, errors.New("autoCompound is required to set the compounding reserve or the maximum compounded amount")
+		}
+		return nil, nil
+	}
+
+	policy := &compoundingPolicy{reserve: big.NewInt(0)}
+	if reserveFlag != "" {
+		policy.reserve, err = parseAmountInWei(reserveFlag, "RZR")
+		if err != nil {
+			return nil, fmt.Errorf("invalid compoundReserve: %w", err)
+		}
+	}
+	if maxAmountFlag != "" {
+		policy.maxAmount, err = parseAmountInWei(maxAmountFlag, "RZR")
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxCompoundAmount: %w", err)
+		}
+		if policy.maxAmount.Sign() == 0 {
+			return nil, errors.New("maxCompoundAmount must be positive")
+		}
+	}
+	return policy, nil
+}
+
+//This function compounds the rewards of the staker once per epoch.
+//It claims the commission of the staker and stakes the RZR balance above the reserve, up to the maximum amount of the policy.
+func compoundRewards(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, stakerId uint32, epoch uint32) {
+	policy := autoCompoundingPolicy
+	if policy == nil {
+		return
+	}
+
+	state := getStakerState(rpcParameters.Ctx)
+	if state.lastCompoundEpoch >= epoch {
+		return
+	}
+	// Compounding at most once per epoch, so that a failing transaction isn't retried in every block of the confirm state.
+	// A simulated compounding in dry run mode doesn't mark the epoch as done.
+	if !utils.DryRun {
+		state.lastCompoundEpoch = epoch
+	}
+
+	stakerInfo, err := razorUtils.StakerInfo(rpcParameters, stakerId)
+	if err != nil {
+		log.Error("Error in getting staker info for compounding: ", err)
+		return
+	}
+	if stakerInfo.StakerReward != nil && stakerInfo.StakerReward.Sign() > 0 {
+		log.Infof("Compounding: Claiming commission of %g RZR", utils.GetAmountInDecimal(stakerInfo.StakerReward))
+		txnHash, err := claimStakerReward(rpcParameters, config, account)
+		if err == nil {
+			err = razorUtils.WaitForBlockCompletion(rpcParameters, txnHash.Hex())
+		}
+		observeCompounding("claimCommission", epoch, stakerInfo.StakerReward, err)
+		if err != nil && !errors.Is(err, utils.ErrDryRunTransaction) {
+			log.Error("Error in claiming commission for compounding: ", err)
+			return
+		}
+	}
+
+	balance, err := razorUtils.FetchBalance(rpcParameters, account.Address)
+	if err != nil {
+		log.Error("Error in fetching RZR balance for compounding: ", err)
+		return
+	}
+	amount := new(big.Int).Sub(balance, policy.reserve)
+	if amount.Sign() <= 0 {
+		log.Debugf("Compounding: RZR balance %g is not above the reserve %g, nothing to stake", utils.GetAmountInDecimal(balance), utils.GetAmountInDecimal(policy.reserve))
+		return
+	}
+	if policy.maxAmount != nil && amount.Cmp(policy.maxAmount) > 0 {
+		log.Debugf("Compounding: Capping the amount to stake %g to the maximum amount %g", utils.GetAmountInDecimal(amount), utils.GetAmountInDecimal(policy.maxAmount))
+		amount.Set(policy.maxAmount)
+	}
+
+	log.Infof("Compounding: Staking %g RZR, keeping a reserve of %g RZR", utils.GetAmountInDecimal(amount), utils.GetAmountInDecimal(policy.reserve))
+	err = stakeCompoundedAmount(rpcParameters, config, account, amount)
+	observeCompounding("stake", epoch, amount, err)
+	if err != nil && !errors.Is(err, utils.ErrDryRunTransaction) {
+		log.Error("Error in staking RZR for compounding: ", err)
+	}
+}
+
+//This function approves and stakes the given amount of RZR and waits for the transactions to be mined
+func stakeCompoundedAmount(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, amount *big.Int) error {
+	txnArgs := types.TransactionOptions{
+		Amount:  amount,
+		ChainId: core.ChainId,
+		Config:  config,
+		Account: account,
+	}
+
+	approveTxnHash, err := cmdUtils.Approve(rpcParameters, txnArgs)
+	if err != nil {
+		return fmt.Errorf("approve error: %w", err)
+	}
+	if approveTxnHash != core.NilHash {
+		err = razorUtils.WaitForBlockCompletion(rpcParameters, approveTxnHash.Hex())
+		if err != nil && !errors.Is(err, utils.ErrDryRunTransaction) {
+			return fmt.Errorf("error in WaitForBlockCompletion for approve: %w", err)
+		}
+	}
+
+	stakeTxnHash, err := cmdUtils.StakeCoins(rpcParameters, txnArgs)
+	if err != nil {
+		return fmt.Errorf("stake error: %w", err)
+	}
+	return razorUtils.WaitForBlockCompletion(rpcParameters, stakeTxnHash.Hex())
+}
+
+//This function records a compounding action in the logs and metrics, a simulated action in dry run mode is only logged
+func observeCompounding(action string, epoch uint32, amount *big.Int, err error) {
+	amountInRZR, _ := utils.GetAmountInDecimal(amount).Float64()
+	if errors.Is(err, utils.ErrDryRunTransaction) {
+		log.Infof("Dry run: Compounding: %s of %g RZR was simulated in epoch %d", action, amountInRZR, epoch)
+		return
+	}
+	metrics.ObserveCompounding(action, amountInRZR, err)
+	if err == nil {
+		log.Infof("Compounding: %s of %g RZR succeeded in epoch %d", action, amountInRZR, epoch)
+	}
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/utils"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCompoundingPolicy(t *testing.T) {
+	type args struct {
+		autoCompound bool
+		reserve      string
+		maxAmount    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *compoundingPolicy
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When auto compounding is not enabled",
+			args: args{},
+			want: nil,
+		},
+		{
+			name: "Test 2: When auto compounding is enabled without a reserve and a maximum amount",
+			args: args{autoCompound: true},
+			want: &compoundingPolicy{reserve: big.NewInt(0)},
+		},
+		{
+			name: "Test 3: When auto compounding is enabled with a reserve and a maximum amount",
+			args: args{autoCompound: true, reserve: "100", maxAmount: "1000.5"},
+			want: &compoundingPolicy{reserve: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), maxAmount: new(big.Int).Mul(big.NewInt(10005), big.NewInt(1e17))},
+		},
+		{
+			name:    "Test 4: When a reserve is passed without enabling auto compounding",
+			args:    args{reserve: "100"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 5: When the reserve is negative",
+			args:    args{autoCompound: true, reserve: "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 6: When the maximum amount is zero",
+			args:    args{autoCompound: true, maxAmount: "0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			flagSetMock.On("GetBoolAutoCompound", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.autoCompound, nil)
+			flagSetMock.On("GetStringCompoundReserve", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.reserve, nil)
+			flagSetMock.On("GetStringMaxCompoundAmount", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.maxAmount, nil)
+
+			got, err := getCompoundingPolicy(&pflag.FlagSet{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getCompoundingPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("getCompoundingPolicy() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if got.reserve.Cmp(tt.want.reserve) != 0 {
+				t.Errorf("getCompoundingPolicy() reserve = %v, want %v", got.reserve, tt.want.reserve)
+			}
+			if (got.maxAmount == nil) != (tt.want.maxAmount == nil) || (got.maxAmount != nil && got.maxAmount.Cmp(tt.want.maxAmount) != 0) {
+				t.Errorf("getCompoundingPolicy() maxAmount = %v, want %v", got.maxAmount, tt.want.maxAmount)
+			}
+		})
+	}
+}
+
+func TestCompoundRewards(t *testing.T) {
+	var config types.Configurations
+	account := types.Account{Address: "0x000000000000000000000000000000000000dead"}
+	const epoch uint32 = 5
+
+	type args struct {
+		policy            *compoundingPolicy
+		lastCompoundEpoch uint32
+		stakerReward      *big.Int
+		claimErr          error
+		balance           *big.Int
+		approveErr        error
+		dryRun            bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantClaim   bool
+		wantStaked  *big.Int
+		wantApprove bool
+	}{
+		{
+			name: "Test 1: When auto compounding is not enabled",
+			args: args{
+				stakerReward: big.NewInt(1e18),
+				balance:      big.NewInt(1e18),
+			},
+		},
+		{
+			name: "Test 2: When the commission is claimed and the balance above the reserve is staked",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(1e18)},
+				stakerReward: big.NewInt(2e18),
+				balance:      big.NewInt(5e18),
+			},
+			wantClaim:   true,
+			wantApprove: true,
+			wantStaked:  big.NewInt(4e18),
+		},
+		{
+			name: "Test 3: When the amount to stake is capped by the maximum amount",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(1e18), maxAmount: big.NewInt(2e18)},
+				stakerReward: big.NewInt(0),
+				balance:      big.NewInt(5e18),
+			},
+			wantApprove: true,
+			wantStaked:  big.NewInt(2e18),
+		},
+		{
+			name: "Test 4: When the balance is not above the reserve",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(5e18)},
+				stakerReward: big.NewInt(0),
+				balance:      big.NewInt(5e18),
+			},
+		},
+		{
+			name: "Test 5: When the rewards were already compounded in the epoch",
+			args: args{
+				policy:            &compoundingPolicy{reserve: big.NewInt(0)},
+				lastCompoundEpoch: epoch,
+				stakerReward:      big.NewInt(1e18),
+				balance:           big.NewInt(1e18),
+			},
+		},
+		{
+			name: "Test 6: When claiming the commission fails",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(0)},
+				stakerReward: big.NewInt(1e18),
+				claimErr:     errors.New("claim error"),
+				balance:      big.NewInt(1e18),
+			},
+			wantClaim: true,
+		},
+		{
+			name: "Test 7: When approving the amount to stake fails",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(0)},
+				stakerReward: big.NewInt(0),
+				balance:      big.NewInt(1e18),
+				approveErr:   errors.New("approve error"),
+			},
+			wantApprove: true,
+		},
+		{
+			name: "Test 8: When the compounding transactions are simulated in dry run mode",
+			args: args{
+				policy:       &compoundingPolicy{reserve: big.NewInt(0)},
+				stakerReward: big.NewInt(1e18),
+				balance:      big.NewInt(1e18),
+				dryRun:       true,
+			},
+			wantClaim:   true,
+			wantApprove: true,
+			wantStaked:  big.NewInt(1e18),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+			autoCompoundingPolicy = tt.args.policy
+			defer func() { autoCompoundingPolicy = nil }()
+			utils.DryRun = tt.args.dryRun
+			t.Cleanup(func() { utils.DryRun = false })
+			var waitErr error
+			if tt.args.dryRun {
+				waitErr = utils.ErrDryRunTransaction
+			}
+
+			state := &stakerState{lastCompoundEpoch: tt.args.lastCompoundEpoch}
+			compoundRPCParameters := rpcParameters
+			compoundRPCParameters.Ctx = withStakerState(context.Background(), state)
+
+			utilsMock.On("StakerInfo", mock.Anything, mock.AnythingOfType("uint32")).Return(types.Staker{StakerReward: tt.args.stakerReward}, nil)
+			utilsMock.On("GetTxnOpts", mock.Anything, mock.Anything).Return(TxnOpts, nil)
+			stakeManagerMock.On("ClaimStakerReward", mock.AnythingOfType("*ethclient.Client"), mock.Anything).Return(&Types.Transaction{}, tt.args.claimErr)
+			transactionMock.On("Hash", mock.Anything).Return(common.BigToHash(big.NewInt(1)))
+			utilsMock.On("WaitForBlockCompletion", mock.Anything, mock.Anything).Return(waitErr)
+			utilsMock.On("FetchBalance", mock.Anything, account.Address).Return(tt.args.balance, nil)
+			cmdUtilsMock.On("Approve", mock.Anything, mock.Anything).Return(core.NilHash, tt.args.approveErr)
+			cmdUtilsMock.On("StakeCoins", mock.Anything, mock.Anything).Return(common.BigToHash(big.NewInt(2)), nil)
+
+			compoundRewards(compoundRPCParameters, config, account, 1, epoch)
+
+			if tt.wantClaim {
+				stakeManagerMock.AssertCalled(t, "ClaimStakerReward", mock.AnythingOfType("*ethclient.Client"), mock.Anything)
+			} else {
+				stakeManagerMock.AssertNotCalled(t, "ClaimStakerReward", mock.Anything, mock.Anything)
+			}
+			if tt.wantApprove {
+				cmdUtilsMock.AssertCalled(t, "Approve", mock.Anything, mock.Anything)
+			} else {
+				cmdUtilsMock.AssertNotCalled(t, "Approve", mock.Anything, mock.Anything)
+			}
+			if tt.wantStaked != nil {
+				cmdUtilsMock.AssertCalled(t, "StakeCoins", mock.Anything, types.TransactionOptions{
+					Amount:  tt.wantStaked,
+					ChainId: core.ChainId,
+					Config:  config,
+					Account: account,
+				})
+			} else {
+				cmdUtilsMock.AssertNotCalled(t, "StakeCoins", mock.Anything, mock.Anything)
+			}
+			if tt.args.policy != nil && !tt.args.dryRun && state.lastCompoundEpoch != epoch {
+				t.Errorf("Expected last compound epoch to be %d, got %d", epoch, state.lastCompoundEpoch)
+			}
+			if tt.args.dryRun && state.lastCompoundEpoch == epoch {
+				t.Errorf("Expected a simulated compounding not to mark epoch %d as done", epoch)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"razor/core"
 	"razor/core/types"
+	"razor/logger"
 	"razor/rpc"
 	"razor/utils"
 	"strings"
@@ -14,8 +15,24 @@ import (
 	"github.com/spf13/viper"
 )
 
-//This function returns the config data
+//This function returns the config data and applies the RPC timeout and logging config from it
 func (*UtilsStruct) GetConfigData() (types.Configurations, error) {
+	config, err := readConfigData()
+	if err != nil {
+		return config, err
+	}
+	utils.RPCTimeout = config.RPCTimeout
+
+	err = setLogLevel(config)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+//This function reads the config data without applying it
+func readConfigData() (types.Configurations, error) {
 	config := types.Configurations{
 		Provider:           "",
 		GasMultiplier:      0,
@@ -28,6 +45,7 @@ func (*UtilsStruct) GetConfigData() (types.Configurations, error) {
 		LogFileMaxSize:     0,
 		LogFileMaxBackups:  0,
 		LogFileMaxAge:      0,
+		SignerURL:          "",
 	}
 
 	provider, err := cmdUtils.GetProvider()
@@ -82,6 +100,10 @@ func (*UtilsStruct) GetConfigData() (types.Configurations, error) {
 	if err != nil {
 		return config, err
 	}
+	signerURL, err := cmdUtils.GetSignerURL()
+	if err != nil {
+		return config, err
+	}
 	config.Provider = provider
 	config.GasMultiplier = gasMultiplier
 	config.BufferPercent = bufferPercent
@@ -91,13 +113,11 @@ func (*UtilsStruct) GetConfigData() (types.Configurations, error) {
 	config.GasLimitMultiplier = gasLimit
 	config.GasLimitOverride = gasLimitOverride
 	config.RPCTimeout = rpcTimeout
-	utils.RPCTimeout = rpcTimeout
 	config.HTTPTimeout = httpTimeout
 	config.LogFileMaxSize = logFileMaxSize
 	config.LogFileMaxBackups = logFileMaxBackups
 	config.LogFileMaxAge = logFileMaxAge
-
-	setLogLevel(config)
+	config.SignerURL = signerURL
 
 	return config, nil
 }
@@ -369,10 +389,33 @@ func (*UtilsStruct) GetLogFileMaxAge() (int, error) {
 	return logFileMaxAge.(int), nil
 }
 
-//This function sets the log level
-func setLogLevel(config types.Configurations) {
+//This function returns the URL of the remote signer, transactions are signed with the local keystore if it is not set
+func (*UtilsStruct) GetSignerURL() (string, error) {
+	signerURL, err := getConfigValue("signerUrl", "string", core.DefaultSignerURL, "signerUrl")
+	if err != nil {
+		return core.DefaultSignerURL, err
+	}
+	return signerURL.(string), nil
+}
+
+//This function configures the log format, module levels and sinks from the logging section of razor.yaml and sets the log level.
+//Nothing is applied if the logging section is invalid.
+func setLogLevel(config types.Configurations) error {
+	var loggingConfig logger.Config
+	err := viper.UnmarshalKey("logging", &loggingConfig)
+	if err != nil {
+		return errors.New("error in reading logging config: " + err.Error())
+	}
+	err = logger.Configure(loggingConfig)
+	if err != nil {
+		return errors.New("error in configuring logging: " + err.Error())
+	}
+
 	if config.LogLevel == "debug" {
 		log.SetLogLevel(logrus.DebugLevel)
+	} else {
+		// The config is read again when razor.yaml is reloaded while voting
+		log.SetLogLevel(logrus.InfoLevel)
 	}
 
 	if razorUtils.IsFlagPassed("logFile") {
@@ -380,6 +423,7 @@ func setLogLevel(config types.Configurations) {
 		log.Debugf("Log File Max Backups (max number of old log files to retain): %d", config.LogFileMaxBackups)
 		log.Debugf("Log File Max Age (max number of days to retain old log files): %d", config.LogFileMaxAge)
 	}
+	return nil
 }
 
 func ValidateBufferPercentLimit(rpcParameters rpc.RPCParameters, bufferPercent int32) error {
@@ -91,6 +91,8 @@ func TestGetConfigData(t *testing.T) {
 		logFileMaxBackupsErr error
 		logFileMaxAge        int
 		logFileMaxAgeErr     error
+		signerURL            string
+		signerURLErr         error
 	}
 	tests := []struct {
 		name    string
@@ -189,6 +191,14 @@ func TestGetConfigData(t *testing.T) {
 			want:    nilConfig,
 			wantErr: errors.New("httpTimeout error"),
 		},
+		{
+			name: "Test 11: When there is an error in getting signerURL",
+			args: args{
+				signerURLErr: errors.New("signerURL error"),
+			},
+			want:    nilConfig,
+			wantErr: errors.New("signerURL error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -207,6 +217,7 @@ func TestGetConfigData(t *testing.T) {
 			cmdUtilsMock.On("GetLogFileMaxSize").Return(tt.args.logFileMaxSize, tt.args.logFileMaxSizeErr)
 			cmdUtilsMock.On("GetLogFileMaxBackups").Return(tt.args.logFileMaxBackups, tt.args.logFileMaxBackupsErr)
 			cmdUtilsMock.On("GetLogFileMaxAge").Return(tt.args.logFileMaxAge, tt.args.logFileMaxAgeErr)
+			cmdUtilsMock.On("GetSignerURL").Return(tt.args.signerURL, tt.args.signerURLErr)
 			utilsMock.On("IsFlagPassed", mock.AnythingOfType("string")).Return(true)
 			utils := &UtilsStruct{}
 
@@ -1010,6 +1021,85 @@ func TestGetHTTPTimeout(t *testing.T) {
 	}
 }
 
+func TestGetSignerURL(t *testing.T) {
+	type args struct {
+		isFlagSet             bool
+		signerURL             string
+		signerURLErr          error
+		signerURLInTestConfig string
+	}
+	tests := []struct {
+		name               string
+		useDummyConfigFile bool
+		args               args
+		want               string
+		wantErr            error
+	}{
+		{
+			name: "Test 1: When signerUrl is fetched from root flag",
+			args: args{
+				isFlagSet: true,
+				signerURL: "http://localhost:8550",
+			},
+			want:    "http://localhost:8550",
+			wantErr: nil,
+		},
+		{
+			name: "Test 2: When there is an error in fetching signerUrl from root flag",
+			args: args{
+				isFlagSet:    true,
+				signerURLErr: errors.New("signerUrl error"),
+			},
+			want:    core.DefaultSignerURL,
+			wantErr: errors.New("signerUrl error"),
+		},
+		{
+			name:               "Test 3: When signerUrl value is fetched from config",
+			useDummyConfigFile: true,
+			args: args{
+				signerURLInTestConfig: "http://127.0.0.1:8550",
+			},
+			want:    "http://127.0.0.1:8550",
+			wantErr: nil,
+		},
+		{
+			name:    "Test 4: When signerUrl is not passed in root nor set in config",
+			want:    core.DefaultSignerURL,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset() // Reset viper state
+
+			if tt.useDummyConfigFile {
+				createTestConfig(t, "signerUrl", tt.args.signerURLInTestConfig)
+				defer removeTestConfig(tempConfigPath)
+			}
+
+			SetUpMockInterfaces()
+
+			flagSetMock.On("FetchRootFlagInput", mock.Anything, mock.Anything).Return(tt.args.signerURL, tt.args.signerURLErr)
+			flagSetMock.On("Changed", mock.Anything, mock.Anything).Return(tt.args.isFlagSet)
+
+			utils := &UtilsStruct{}
+			got, err := utils.GetSignerURL()
+			if got != tt.want {
+				t.Errorf("getSignerURL() got = %v, want %v", got, tt.want)
+			}
+			if err == nil || tt.wantErr == nil {
+				if err != tt.wantErr {
+					t.Errorf("Error for getSignerURL function, got = %v, want = %v", err, tt.wantErr)
+				}
+			} else {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("Error for getSignerURL function, got = %v, want = %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
 func TestGetWaitTime(t *testing.T) {
 	type args struct {
 		isFlagSet        bool
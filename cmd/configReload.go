@@ -0,0 +1,320 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"razor/core"
+	"razor/core/types"
+	"razor/rpc"
+	"razor/utils"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configReloader watches razor.yaml, assets.json and endpoints.json in the .razor directory while voting.
+// The changed files are reloaded by the vote loop before it handles the next block, so that a state is never handled with a partially applied config.
+type configReloader struct {
+	mu sync.Mutex
+	// changedAt holds the time of the last change of each file which changed since it was last reloaded
+	changedAt map[string]time.Time
+	config    atomic.Pointer[types.Configurations]
+}
+
+var reloader *configReloader
+
+// restartOnlyConfigs are the configs which are only read when vote starts, a change to them is applied after a restart
+var restartOnlyConfigs = map[string]bool{
+	"LogFileMaxSize":    true,
+	"LogFileMaxBackups": true,
+	"LogFileMaxAge":     true,
+	"SignerURL":         true,
+}
+
+// startConfigReloader starts watching the config files in the .razor directory and takes a snapshot of assets.json to aggregate with
+func startConfigReloader(config types.Configurations) error {
+	defaultPath, err := pathUtils.GetDefaultPath()
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(defaultPath); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	assetsFile, err := utils.ReadAssetsFile()
+	if err != nil {
+		watcher.Close()
+		return err
+	}
+	utils.AssetsSnapshot.Store(&assetsFile)
+
+	r := &configReloader{changedAt: make(map[string]time.Time)}
+	r.config.Store(&config)
+	reloader = r
+	go r.watch(watcher)
+	log.Infof("Watching %s, %s and %s in %s for changes", core.ConfigFile, core.AssetsDataFile, core.EndpointsFile, defaultPath)
+	return nil
+}
+
+// currentConfig returns the config reloaded from razor.yaml while voting, or the given config if the config files aren't watched
+func currentConfig(config types.Configurations) types.Configurations {
+	if reloader == nil {
+		return config
+	}
+	return *reloader.config.Load()
+}
+
+//This function records the changes of the config files until the watcher is closed
+func (r *configReloader) watch(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			fileName := filepath.Base(event.Name)
+			switch fileName {
+			case core.ConfigFile, core.AssetsDataFile, core.EndpointsFile:
+			default:
+				continue
+			}
+			// Editors save a file by writing it or by renaming a new file over it
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+				continue
+			}
+			log.Debugf("%s changed: %s", fileName, event.Op)
+			r.mu.Lock()
+			r.changedAt[fileName] = time.Now()
+			r.mu.Unlock()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Error("Error in watching config files: ", err)
+		}
+	}
+}
+
+//This function returns the files which changed and weren't written to for ConfigReloadSettleTime seconds, they are no longer pending
+func (r *configReloader) settledChanges() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changedFiles := make(map[string]bool)
+	for fileName, changedAt := range r.changedAt {
+		if time.Since(changedAt) >= core.ConfigReloadSettleTime*time.Second {
+			changedFiles[fileName] = true
+			delete(r.changedAt, fileName)
+		}
+	}
+	return changedFiles
+}
+
+//This function reloads the config files which changed since the last block and returns the config to handle the next block with.
+//A file which is invalid is not applied and the config in use is kept.
+func (r *configReloader) reload(rpcParameters rpc.RPCParameters, commitParams *types.CommitParams) types.Configurations {
+	config := *r.config.Load()
+	changedFiles := r.settledChanges()
+	if len(changedFiles) == 0 {
+		return config
+	}
+
+	if changedFiles[core.ConfigFile] {
+		reloadedConfig, err := reloadConfig(rpcParameters, config)
+		if err != nil {
+			log.Errorf("Error in reloading %s, keeping the current config: %v", core.ConfigFile, err)
+		} else {
+			changes := diffConfigurations(config, reloadedConfig)
+			if len(changes) == 0 {
+				log.Infof("Reloaded %s, no config changed", core.ConfigFile)
+			}
+			for _, change := range changes {
+				log.Infof("Reloaded %s, %s", core.ConfigFile, change)
+			}
+			if reloadedConfig.HTTPTimeout != config.HTTPTimeout && commitParams.HttpClient != nil {
+				// The client is copied rather than changed, as its timeout shouldn't change while a request is in flight
+				httpClient := *commitParams.HttpClient
+				httpClient.Timeout = time.Duration(reloadedConfig.HTTPTimeout) * time.Second
+				commitParams.HttpClient = &httpClient
+			}
+			if reloadedConfig.Provider != config.Provider {
+				// The provider is one of the endpoints
+				changedFiles[core.EndpointsFile] = true
+			}
+			config = reloadedConfig
+			r.config.Store(&config)
+		}
+	}
+
+	if changedFiles[core.AssetsDataFile] {
+		assetsFile, err := utils.ReadAssetsFile()
+		if err == nil {
+			var changes []string
+			changes, err = swapAssetsFile(assetsFile)
+			for _, change := range changes {
+				log.Infof("Reloaded %s, %s", core.AssetsDataFile, change)
+			}
+		}
+		if err != nil {
+			log.Errorf("Error in reloading %s, keeping the current assets: %v", core.AssetsDataFile, err)
+		}
+	}
+
+	if changedFiles[core.EndpointsFile] {
+		err := reloadEndpoints(rpcParameters, config.Provider)
+		if err != nil {
+			log.Errorf("Error in reloading %s, keeping the current endpoints: %v", core.EndpointsFile, err)
+		}
+	}
+	return config
+}
+
+//This function reads razor.yaml again and returns the config if it is valid. The new config, including its logging section,
+//is only applied once it is validated, and the config in use is read back into viper if it isn't valid.
+func reloadConfig(rpcParameters rpc.RPCParameters, currentConfig types.Configurations) (types.Configurations, error) {
+	currentSettings := viper.AllSettings()
+	err := viper.ReadInConfig()
+	if err != nil {
+		return currentConfig, err
+	}
+	config, err := readConfigData()
+	if err == nil {
+		err = ValidateBufferPercentLimit(rpcParameters, config.BufferPercent)
+	}
+	if err == nil {
+		// The logging section is applied last as it is validated while it is applied
+		err = setLogLevel(config)
+	}
+	if err != nil {
+		if restoreErr := restoreViperSettings(currentSettings); restoreErr != nil {
+			log.Error("Error in restoring the config in use: ", restoreErr)
+		}
+		return currentConfig, err
+	}
+	utils.RPCTimeout = config.RPCTimeout
+	return config, nil
+}
+
+//This function replaces the config read by viper with the given settings
+func restoreViperSettings(settings map[string]interface{}) error {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return viper.ReadConfig(bytes.NewReader(data))
+}
+
+//This function returns the configs which differ between the old and new config
+func diffConfigurations(oldConfig types.Configurations, newConfig types.Configurations) []string {
+	var changes []string
+	oldValue, newValue := reflect.ValueOf(oldConfig), reflect.ValueOf(newConfig)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		fieldName := oldValue.Type().Field(i).Name
+		change := fmt.Sprintf("%s changed from %v to %v", fieldName, oldValue.Field(i).Interface(), newValue.Field(i).Interface())
+		if restartOnlyConfigs[fieldName] {
+			change += ", it is applied after a restart"
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+//This function swaps the assets.json file the collections are aggregated with if it can be parsed, and returns the collections which changed.
+//Problems which don't prevent parsing the file are logged as warnings, as they are while voting.
+func swapAssetsFile(assetsFile utils.AssetsFile) ([]string, error) {
+	newCollections := make(map[string]assetsFileCollection)
+	if assetsFile.IsPresent {
+		parsedAssetsFile, issues := parseAssetsFile([]byte(assetsFile.Data))
+		if parsedAssetsFile == nil {
+			return nil, errors.New(strings.Join(issues, ", "))
+		}
+		for _, issue := range issues {
+			log.Warnf("%s: %s", core.AssetsDataFile, issue)
+		}
+		newCollections = parsedAssetsFile.Assets.Collection
+	}
+
+	oldCollections := make(map[string]assetsFileCollection)
+	oldAssetsFile := utils.AssetsSnapshot.Load()
+	if oldAssetsFile != nil && oldAssetsFile.IsPresent {
+		if parsedAssetsFile, _ := parseAssetsFile([]byte(oldAssetsFile.Data)); parsedAssetsFile != nil {
+			oldCollections = parsedAssetsFile.Assets.Collection
+		}
+	}
+	utils.AssetsSnapshot.Store(&assetsFile)
+
+	var changes []string
+	for collectionName, collection := range newCollections {
+		oldCollection, ok := oldCollections[collectionName]
+		if !ok {
+			changes = append(changes, "collection "+collectionName+" added")
+		} else if !reflect.DeepEqual(oldCollection, collection) {
+			changes = append(changes, "collection "+collectionName+" changed")
+		}
+	}
+	for collectionName := range oldCollections {
+		if _, ok := newCollections[collectionName]; !ok {
+			changes = append(changes, "collection "+collectionName+" removed")
+		}
+	}
+	sort.Strings(changes)
+	if len(changes) == 0 {
+		changes = append(changes, "no collection changed")
+	}
+	return changes, nil
+}
+
+//This function reads endpoints.json again and switches to its endpoints, scoring and quorum if they are valid and any endpoint is reachable
+func reloadEndpoints(rpcParameters rpc.RPCParameters, provider string) error {
+	endpointsConfig, err := rpc.ReadEndpointsFile(provider)
+	if err != nil {
+		return err
+	}
+	update, err := rpcParameters.RPCManager.UpdateEndpoints(endpointsConfig)
+	if err != nil {
+		return err
+	}
+	if !update.IsChanged() {
+		log.Infof("Reloaded %s, no endpoint changed", core.EndpointsFile)
+		return nil
+	}
+	for _, endpoint := range update.Added {
+		log.Infof("Reloaded %s, endpoint %s added", core.EndpointsFile, endpoint)
+	}
+	for _, endpoint := range update.Removed {
+		log.Infof("Reloaded %s, endpoint %s removed", core.EndpointsFile, endpoint)
+	}
+	for _, endpoint := range update.Reconfigured {
+		log.Infof("Reloaded %s, weight or priority of endpoint %s changed", core.EndpointsFile, endpoint)
+	}
+	if update.IsScoringChanged {
+		log.Infof("Reloaded %s, scoring changed", core.EndpointsFile)
+	}
+	if update.IsQuorumChanged {
+		log.Infof("Reloaded %s, quorum changed", core.EndpointsFile)
+	}
+	bestEndpointURL, err := rpcParameters.RPCManager.GetBestEndpointURL()
+	if err != nil {
+		return err
+	}
+	log.Info("Current best RPC endpoint URL: ", bestEndpointURL)
+	return nil
+}
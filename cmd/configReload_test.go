@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"razor/core/types"
+	"razor/utils"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestDiffConfigurations(t *testing.T) {
+	config := types.Configurations{
+		Provider:       "https://rpc.example.com",
+		GasMultiplier:  1,
+		BufferPercent:  20,
+		WaitTime:       1,
+		LogLevel:       "debug",
+		LogFileMaxSize: 200,
+	}
+
+	reloadedConfig := config
+	reloadedConfig.GasMultiplier = 1.5
+	reloadedConfig.LogLevel = ""
+	reloadedConfig.LogFileMaxSize = 100
+
+	want := []string{
+		"GasMultiplier changed from 1 to 1.5",
+		"LogLevel changed from debug to ",
+		"LogFileMaxSize changed from 200 to 100, it is applied after a restart",
+	}
+	if got := diffConfigurations(config, reloadedConfig); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffConfigurations() = %q, want %q", got, want)
+	}
+	if got := diffConfigurations(config, config); len(got) != 0 {
+		t.Errorf("diffConfigurations() of the same config = %q, want no changes", got)
+	}
+}
+
+func TestSwapAssetsFile(t *testing.T) {
+	defer utils.AssetsSnapshot.Store(nil)
+
+	ethCollection := `"ethCollection": {"power": 2, "official jobs": {"1": {"URL": "https://api.gemini.com/v1/pubticker/ethusd", "selector": "last"}}}`
+	btcCollection := `"btcCollection": {"power": 2, "custom jobs": [{"name": "btc_kraken", "URL": "https://api.kraken.com/0/public/Ticker?pair=XBTUSD", "selector": "result.XXBTZUSD.c[0]", "weight": 100}]}`
+	changedEthCollection := `"ethCollection": {"power": 3, "official jobs": {"1": {"URL": "https://api.gemini.com/v1/pubticker/ethusd", "selector": "last"}}}`
+
+	tests := []struct {
+		name       string
+		assetsFile utils.AssetsFile
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "Test 1: When an assets file is added",
+			assetsFile: utils.AssetsFile{IsPresent: true, Data: `{"assets": {"collection": {` + ethCollection + `}}}`},
+			want:       []string{"collection ethCollection added"},
+		},
+		{
+			name:       "Test 2: When a collection is changed and another is added",
+			assetsFile: utils.AssetsFile{IsPresent: true, Data: `{"assets": {"collection": {` + changedEthCollection + `, ` + btcCollection + `}}}`},
+			want:       []string{"collection btcCollection added", "collection ethCollection changed"},
+		},
+		{
+			name:       "Test 3: When the assets file is being written and can't be parsed",
+			assetsFile: utils.AssetsFile{IsPresent: true, Data: `{"assets": {"collection": {` + ethCollection},
+			wantErr:    true,
+		},
+		{
+			name:       "Test 4: When only the formatting of the assets file changed",
+			assetsFile: utils.AssetsFile{IsPresent: true, Data: `{"assets": {"collection": {` + btcCollection + `,` + changedEthCollection + `}}}`},
+			want:       []string{"no collection changed"},
+		},
+		{
+			name:       "Test 5: When the assets file is removed",
+			assetsFile: utils.AssetsFile{},
+			want:       []string{"collection btcCollection removed", "collection ethCollection removed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousAssetsFile := utils.AssetsSnapshot.Load()
+			got, err := swapAssetsFile(tt.assetsFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("swapAssetsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("swapAssetsFile() = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr {
+				if utils.AssetsSnapshot.Load() != previousAssetsFile {
+					t.Error("swapAssetsFile() swapped an assets file which can't be parsed")
+				}
+			} else if !reflect.DeepEqual(*utils.AssetsSnapshot.Load(), tt.assetsFile) {
+				t.Errorf("swapAssetsFile() snapshot = %+v, want %+v", *utils.AssetsSnapshot.Load(), tt.assetsFile)
+			}
+		})
+	}
+}
+
+func TestSettledChanges(t *testing.T) {
+	r := &configReloader{changedAt: map[string]time.Time{
+		"razor.yaml":     time.Now().Add(-time.Minute),
+		"endpoints.json": time.Now(),
+	}}
+
+	if got := r.settledChanges(); !reflect.DeepEqual(got, map[string]bool{"razor.yaml": true}) {
+		t.Errorf("settledChanges() = %v, want only the file which is no longer being written", got)
+	}
+	if got := r.settledChanges(); len(got) != 0 {
+		t.Errorf("settledChanges() = %v, want the reloaded file to no longer be pending", got)
+	}
+	if _, ok := r.changedAt["endpoints.json"]; !ok {
+		t.Error("settledChanges() dropped a file which is still being written")
+	}
+}
+
+func TestRestoreViperSettings(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+
+	if err := viper.ReadConfig(bytes.NewReader([]byte("buffer: 20\nlogging:\n  format: json\n"))); err != nil {
+		t.Fatal(err)
+	}
+	currentSettings := viper.AllSettings()
+	if err := viper.ReadConfig(bytes.NewReader([]byte("buffer: 50\n"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := restoreViperSettings(currentSettings); err != nil {
+		t.Fatalf("restoreViperSettings() error = %v", err)
+	}
+	if got := viper.GetInt32("buffer"); got != 20 {
+		t.Errorf("Expected buffer of the config in use to be restored, got %d", got)
+	}
+	if got := viper.GetString("logging.format"); got != "json" {
+		t.Errorf("Expected logging section of the config in use to be restored, got %q", got)
+	}
+}
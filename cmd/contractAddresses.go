@@ -2,7 +2,6 @@
 package cmd
 
 import (
-	"fmt"
 	"razor/core"
 	"razor/utils"
 
@@ -14,8 +13,12 @@ import (
 var contractAddressesCmd = &cobra.Command{
 	Use:   "contractAddresses",
 	Short: "contractAddresses command can be used to list all contract addresses",
-	Long:  `Provides the list of all contract addresses`,
-	Run:   initialiseContractAddresses,
+	Long: `Provides the list of all contract addresses
+
+Example:
+  ./razor contractAddresses
+  ./razor contractAddresses --output json`,
+	Run: initialiseContractAddresses,
 }
 
 //This function initialises the ExecuteContractAddresses function
@@ -23,25 +26,47 @@ func initialiseContractAddresses(cmd *cobra.Command, args []string) {
 	cmdUtils.ExecuteContractAddresses(cmd.Flags())
 }
 
+// contractAddressRecord is a contract in the output of contractAddresses
+type contractAddressRecord struct {
+	Contract string `json:"contract" yaml:"contract"`
+	Address  string `json:"address" yaml:"address"`
+}
+
 //This function sets the flag appropriatley and executes the ContractAddresses function
 func (*UtilsStruct) ExecuteContractAddresses(flagSet *pflag.FlagSet) {
 	_, _, _, _, err := InitializeCommandDependencies(flagSet)
 	utils.CheckError("Error in initialising command dependencies: ", err)
 
-	fmt.Println("The contract addresses are: ")
-	cmdUtils.ContractAddresses()
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
 
+	err = cmdUtils.ContractAddresses(output)
+	utils.CheckError("Error in printing contract addresses: ", err)
 }
 
-//This function provides the list of all contract addresses
-func (*UtilsStruct) ContractAddresses() {
-	log.Info("StakeManagerAddress :", core.StakeManagerAddress)
-	log.Info("RAZORAddress :", core.RAZORAddress)
-	log.Info("CollectionManagerAddress :", core.CollectionManagerAddress)
-	log.Info("VoteManagerAddress :", core.VoteManagerAddress)
-	log.Info("BlockManagerAddress :", core.BlockManagerAddress)
+//This function provides the list of all contract addresses in the given output format
+func (*UtilsStruct) ContractAddresses(output string) error {
+	records := []contractAddressRecord{
+		{Contract: "StakeManager", Address: core.StakeManagerAddress},
+		{Contract: "RAZOR", Address: core.RAZORAddress},
+		{Contract: "CollectionManager", Address: core.CollectionManagerAddress},
+		{Contract: "VoteManager", Address: core.VoteManagerAddress},
+		{Contract: "BlockManager", Address: core.BlockManagerAddress},
+	}
+	var rows [][]string
+	for _, record := range records {
+		rows = append(rows, []string{record.Contract, record.Address})
+	}
+	return printOutput(output, outputData{
+		Header:  []string{"Contract", "Address"},
+		Fields:  []string{"contract", "address"},
+		Rows:    rows,
+		Records: records,
+	})
 }
 
 func init() {
 	rootCmd.AddCommand(contractAddressesCmd)
+
+	addOutputFlag(contractAddressesCmd)
 }
@@ -18,7 +18,9 @@ func TestContractAddresses(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ut := &UtilsStruct{}
-			ut.ContractAddresses()
+			if err := ut.ContractAddresses(OutputTable); err != nil {
+				t.Errorf("Error for ContractAddresses function, got = %v", err)
+			}
 		})
 	}
 }
@@ -46,7 +48,8 @@ func TestExecuteContractAddresses(t *testing.T) {
 			utilsMock.On("IsFlagPassed", mock.Anything).Return(false)
 			fileUtilsMock.On("AssignLogFile", mock.AnythingOfType("*pflag.FlagSet"), mock.Anything)
 			cmdUtilsMock.On("GetConfigData").Return(types.Configurations{}, nil)
-			cmdUtilsMock.On("ContractAddresses")
+			flagSetMock.On("GetStringOutput", flagSet).Return(OutputTable, nil)
+			cmdUtilsMock.On("ContractAddresses", mock.Anything).Return(nil)
 
 			utils := &UtilsStruct{}
 			fatal = false
@@ -3,10 +3,13 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"os"
 	"razor/core"
 	"razor/core/types"
+	"razor/journal"
+	"razor/notifier"
 	"razor/path"
 	"razor/pkg/bindings"
 	"razor/rpc"
@@ -20,10 +23,6 @@ import (
 	solsha3 "github.com/miguelmota/go-solidity-sha3"
 )
 
-var (
-	giveSortedLeafIds []int
-)
-
 //blockId is id of the block
 
 //This function handles the dispute and if there is any error it returns the error
@@ -120,6 +119,10 @@ func (*UtilsStruct) HandleDispute(rpcParameters rpc.RPCParameters, config types.
 				}
 				continue
 			}
+			if errors.Is(WaitForBlockCompletionErr, utils.ErrDryRunTransaction) {
+				log.Info("Dry run: Not storing the bounty id of the simulated dispute")
+				continue
+			}
 		}
 
 		// Ids Dispute
@@ -146,6 +149,10 @@ func (*UtilsStruct) HandleDispute(rpcParameters rpc.RPCParameters, config types.
 				}
 				continue
 			}
+			if errors.Is(WaitForBlockCompletionErr, utils.ErrDryRunTransaction) {
+				log.Info("Dry run: Not storing the bounty id of the simulated dispute")
+				continue
+			}
 		}
 
 		// Median Value dispute
@@ -195,10 +202,42 @@ func (*UtilsStruct) HandleDispute(rpcParameters rpc.RPCParameters, config types.
 		}
 	}
 
-	giveSortedLeafIds = []int{}
+	getStakerState(rpcParameters.Ctx).giveSortedLeafIds = []int{}
 	return nil
 }
 
+//This function notifies if the block proposed by the staker in the epoch has been disputed and returns whether it was disputed.
+//It is called in the confirm state, when no more disputes can be raised in the epoch, so that it doesn't depend on the disputes run by the staker itself.
+func checkProposedBlockDisputed(rpcParameters rpc.RPCParameters, account types.Account, stakerId uint32, epoch uint32) (bool, error) {
+	lastProposedEpoch, err := razorUtils.GetEpochLastProposed(rpcParameters, stakerId)
+	if err != nil {
+		log.Error("Error in fetching last proposed epoch: ", err)
+		return false, err
+	}
+	if lastProposedEpoch != epoch {
+		return false, nil
+	}
+
+	sortedProposedBlockIds, err := razorUtils.GetSortedProposedBlockIds(rpcParameters, epoch)
+	if err != nil {
+		log.Error("Error in fetching sorted proposed block id: ", err)
+		return false, err
+	}
+	for _, blockId := range sortedProposedBlockIds {
+		proposedBlock, err := razorUtils.GetProposedBlock(rpcParameters, epoch, blockId)
+		if err != nil {
+			log.Error("Error in getting proposed block: ", err)
+			return false, err
+		}
+		if proposedBlock.ProposerId == stakerId && !proposedBlock.Valid {
+			log.Errorf("Block %d proposed by the staker in epoch %d has been disputed successfully", blockId, epoch)
+			eventNotifier.Notify(notifier.EventBlockDisputed, account.Address, epoch, fmt.Sprintf("Block %d proposed by the staker has been disputed successfully", blockId))
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 //This function returns the local median data
 func (*UtilsStruct) GetLocalMediansData(rpcParameters rpc.RPCParameters, account types.Account, epoch uint32, blockNumber *big.Int, rogueData types.Rogue) (types.ProposeFileData, error) {
 	if rogueData.IsRogue {
@@ -209,8 +248,13 @@ func (*UtilsStruct) GetLocalMediansData(rpcParameters rpc.RPCParameters, account
 
 	// Fetching the data from file only if the node is not in rogue mode and
 	// the proposed data in memory is nil or epoch in propose data from memory doesn't match with current epoch
-	nilProposedData := globalProposedDataStruct.MediansData == nil || globalProposedDataStruct.RevealedDataMaps == nil || globalProposedDataStruct.RevealedCollectionIds == nil
-	if nilProposedData || epoch != globalProposedDataStruct.Epoch {
+	state := getStakerState(rpcParameters.Ctx)
+	nilProposedData := state.proposedData.MediansData == nil || state.proposedData.RevealedDataMaps == nil || state.proposedData.RevealedCollectionIds == nil
+	if nilProposedData || epoch != state.proposedData.Epoch {
+		if proposedData, found := state.getProposeDataFromJournal(epoch); found {
+			log.Debug("Global propose data struct is not updated, got the proposed data from epoch journal")
+			return proposedData, nil
+		}
 		log.Debug("Global propose data struct is not updated, getting the proposed data from file...")
 		fileName, err := pathUtils.GetProposeDataFileName(account.Address)
 		if err != nil {
@@ -231,7 +275,7 @@ func (*UtilsStruct) GetLocalMediansData(rpcParameters rpc.RPCParameters, account
 		return proposedData, err
 	}
 
-	return globalProposedDataStruct, nil
+	return state.proposedData, nil
 }
 
 func calculateMedian(rpcParameters rpc.RPCParameters, account types.Account, epoch uint32, blockNumber *big.Int) (types.ProposeFileData, error) {
@@ -361,7 +405,8 @@ func (*UtilsStruct) Dispute(rpcParameters rpc.RPCParameters, config types.Config
 		Account: account,
 	}
 
-	if !utils.Contains(giveSortedLeafIds, leafId) {
+	state := getStakerState(rpcParameters.Ctx)
+	if !utils.Contains(state.giveSortedLeafIds, leafId) {
 		var (
 			start int
 			end   int
@@ -401,7 +446,7 @@ func (*UtilsStruct) Dispute(rpcParameters rpc.RPCParameters, config types.Config
 			}
 		}
 		// Adding leafId to giveSortedLeafIds as giveSorted is done for that leafId
-		giveSortedLeafIds = append(giveSortedLeafIds, int(leafId))
+		state.giveSortedLeafIds = append(state.giveSortedLeafIds, int(leafId))
 	}
 	log.Debugf("Dispute: Calling GetCollectionIdPositionInBlock with arguments leafId = %d, proposed block = %+v", leafId, proposedBlock)
 	positionOfCollectionInBlock := cmdUtils.GetCollectionIdPositionInBlock(rpcParameters, leafId, proposedBlock)
@@ -443,6 +488,8 @@ func (*UtilsStruct) Dispute(rpcParameters rpc.RPCParameters, config types.Config
 			if err != nil {
 				return err
 			}
+		} else if errors.Is(WaitForBlockCompletionErr, utils.ErrDryRunTransaction) {
+			log.Info("Dry run: Not storing the bounty id of the simulated dispute")
 		} else {
 			log.Error("Error in WaitForBlockCompletion for FinalizeDispute: ", WaitForBlockCompletionErr)
 		}
@@ -509,8 +556,12 @@ func GiveSorted(rpcParameters rpc.RPCParameters, txnArgs types.TransactionOption
 
 	txnHash := transactionUtils.Hash(txn)
 	log.Info("Txn Hash: ", txnHash.Hex())
-	giveSortedLeafIds = append(giveSortedLeafIds, int(leafId))
+	state := getStakerState(rpcParameters.Ctx)
+	state.giveSortedLeafIds = append(state.giveSortedLeafIds, int(leafId))
 	err = razorUtils.WaitForBlockCompletion(rpcParameters, txnHash.Hex())
+	if errors.Is(err, utils.ErrDryRunTransaction) {
+		return nil
+	}
 	if err != nil {
 		log.Error("Error in WaitForBlockCompletion for giveSorted: ", err)
 		return err
@@ -559,26 +610,33 @@ func (*UtilsStruct) StoreBountyId(rpcParameters rpc.RPCParameters, account types
 		return err
 	}
 
+	state := getStakerState(rpcParameters.Ctx)
 	if _, err := path.OSUtilsInterface.Stat(disputeFilePath); !errors.Is(err, os.ErrNotExist) {
 		log.Debug("Fetching dispute data from dispute data file...")
-		disputeData, err = fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
+		state.disputeData, err = fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
 		if err != nil {
 			return err
 		}
-		log.Debugf("StoreBountyId: Dispute data: %+v", disputeData)
+		log.Debugf("StoreBountyId: Dispute data: %+v", state.disputeData)
 	}
 
 	if latestBountyId != 0 {
 		//prepending the latestBountyId to the queue
-		disputeData.BountyIdQueue = append([]uint32{latestBountyId}, disputeData.BountyIdQueue...)
+		state.disputeData.BountyIdQueue = append([]uint32{latestBountyId}, state.disputeData.BountyIdQueue...)
 	}
 
 	//saving the updated bountyIds to disputeData file
 	log.Debug("Saving the updated bountyIds to dispute data file...")
-	err = fileUtils.SaveDataToDisputeJsonFile(disputeFilePath, disputeData.BountyIdQueue)
+	err = fileUtils.SaveDataToDisputeJsonFile(disputeFilePath, state.disputeData.BountyIdQueue)
 	if err != nil {
 		return err
 	}
+
+	epoch := uint32(latestHeader.Time / core.EpochLength)
+	state.recordInEpochJournal(epoch, journal.KindDispute, types.DisputeJournalData{
+		BountyId:      latestBountyId,
+		BountyIdQueue: state.disputeData.BountyIdQueue,
+	})
 	return nil
 }
 
@@ -597,6 +655,9 @@ func (*UtilsStruct) ResetDispute(rpcParameters rpc.RPCParameters, txnOpts *bind.
 	txnHash := transactionUtils.Hash(txn)
 	log.Info("Txn Hash: ", txnHash.Hex())
 	err = razorUtils.WaitForBlockCompletion(rpcParameters, txnHash.Hex())
+	if errors.Is(err, utils.ErrDryRunTransaction) {
+		return
+	}
 	if err != nil {
 		log.Error("Error in WaitForBlockCompletion for resetDispute: ", err)
 		return
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"razor/core/types"
 	"razor/pkg/bindings"
+	"razor/utils"
 	"reflect"
 	"testing"
 
@@ -152,6 +153,7 @@ func TestHandleDispute(t *testing.T) {
 		leafIdErr                 error
 		disputeErr                error
 		storeBountyIdErr          error
+		dryRun                    bool
 	}
 	tests := []struct {
 		name string
@@ -516,11 +518,57 @@ func TestHandleDispute(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "Test 20: When there is a biggest influence dispute case in dry run mode",
+			args: args{
+				sortedProposedBlockIds: []uint32{45, 65, 23, 64, 12},
+				biggestStake:           big.NewInt(1).Mul(big.NewInt(5356), big.NewInt(1e18)),
+				biggestStakeId:         2,
+				proposedBlock: bindings.StructsBlock{
+					Medians:      []*big.Int{big.NewInt(6701548), big.NewInt(478307)},
+					Valid:        true,
+					BiggestStake: big.NewInt(1).Mul(big.NewInt(4356), big.NewInt(1e18)),
+				},
+				disputeBiggestStakeTxn: &Types.Transaction{},
+				Hash:                   common.BigToHash(big.NewInt(1)),
+				dryRun:                 true,
+			},
+			want: nil,
+		},
+		{
+			name: "Test 21: When idDisputeTxn is not nil in dry run mode",
+			args: args{
+				sortedProposedBlockIds: []uint32{45, 65, 23, 64, 12},
+				biggestStake:           big.NewInt(1).Mul(big.NewInt(5356), big.NewInt(1e18)),
+				biggestStakeId:         2,
+				medians:                []*big.Int{big.NewInt(6901548), big.NewInt(498307)},
+				revealedCollectionIds:  []uint16{1},
+				revealedDataMaps: &types.RevealedDataMaps{
+					SortedRevealedValues: nil,
+					VoteWeights:          nil,
+					InfluenceSum:         nil,
+				},
+				proposedBlock: bindings.StructsBlock{
+					Medians:      []*big.Int{big.NewInt(6901548), big.NewInt(498307)},
+					Valid:        true,
+					BiggestStake: big.NewInt(1).Mul(big.NewInt(5356), big.NewInt(1e18)),
+				},
+				idDisputeTxn: &Types.Transaction{},
+				dryRun:       true,
+			},
+			want: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			SetUpMockInterfaces()
+			utils.DryRun = tt.args.dryRun
+			t.Cleanup(func() { utils.DryRun = false })
+			var waitErr error
+			if tt.args.dryRun {
+				waitErr = utils.ErrDryRunTransaction
+			}
 
 			utilsMock.On("GetSortedProposedBlockIds", mock.Anything, mock.Anything).Return(tt.args.sortedProposedBlockIds, tt.args.sortedProposedBlockIdsErr)
 			cmdUtilsMock.On("GetBiggestStakeAndId", mock.Anything, mock.Anything).Return(tt.args.biggestStake, tt.args.biggestStakeId, tt.args.biggestStakeErr)
@@ -533,7 +581,7 @@ func TestHandleDispute(t *testing.T) {
 			utilsMock.On("GetTxnOpts", mock.Anything, mock.Anything).Return(txnOpts, nil)
 			blockManagerMock.On("DisputeBiggestStakeProposed", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.disputeBiggestStakeTxn, tt.args.disputeBiggestStakeErr)
 			transactionMock.On("Hash", mock.Anything).Return(tt.args.Hash)
-			utilsMock.On("WaitForBlockCompletion", mock.Anything, mock.Anything).Return(nil)
+			utilsMock.On("WaitForBlockCompletion", mock.Anything, mock.Anything).Return(waitErr)
 			cmdUtilsMock.On("CheckDisputeForIds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.idDisputeTxn, tt.args.idDisputeTxnErr)
 			utilsMock.On("GetLeafIdOfACollection", mock.AnythingOfType("*ethclient.Client"), mock.Anything).Return(tt.args.leafId, tt.args.leafIdErr)
 			cmdUtilsMock.On("Dispute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.disputeErr)
@@ -541,8 +589,8 @@ func TestHandleDispute(t *testing.T) {
 			cmdUtilsMock.On("StoreBountyId", mock.Anything, mock.Anything).Return(tt.args.storeBountyIdErr)
 			cmdUtilsMock.On("ResetDispute", mock.Anything, mock.Anything, mock.Anything)
 
-			utils := &UtilsStruct{}
-			err := utils.HandleDispute(rpcParameters, config, account, epoch, blockNumber, rogueData, backupNodeActionsToIgnore)
+			ut := &UtilsStruct{}
+			err := ut.HandleDispute(rpcParameters, config, account, epoch, blockNumber, rogueData, backupNodeActionsToIgnore)
 			if err == nil || tt.want == nil {
 				if err != tt.want {
 					t.Errorf("Error for HandleDispute function, got = %v, want = %v", err, tt.want)
@@ -552,6 +600,9 @@ func TestHandleDispute(t *testing.T) {
 					t.Errorf("Error for HandleDispute function, got = %v, want = %v", err, tt.want)
 				}
 			}
+			if tt.args.dryRun {
+				cmdUtilsMock.AssertNotCalled(t, "StoreBountyId", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -1149,6 +1200,79 @@ func BenchmarkGetCollectionIdPositionInBlock(b *testing.B) {
 	}
 }
 
+func TestCheckProposedBlockDisputed(t *testing.T) {
+	var (
+		account  types.Account
+		stakerId uint32 = 2
+		epoch    uint32 = 5
+	)
+
+	tests := []struct {
+		name                 string
+		lastProposedEpoch    uint32
+		lastProposedEpochErr error
+		proposedBlocks       map[uint32]bindings.StructsBlock
+		proposedBlockErr     error
+		want                 bool
+		wantErr              bool
+	}{
+		{
+			name:              "Test 1: When the block proposed by the staker was disputed",
+			lastProposedEpoch: epoch,
+			proposedBlocks: map[uint32]bindings.StructsBlock{
+				1: {ProposerId: 3, Valid: true},
+				2: {ProposerId: stakerId, Valid: false},
+			},
+			want: true,
+		},
+		{
+			name:              "Test 2: When the block proposed by the staker is valid",
+			lastProposedEpoch: epoch,
+			proposedBlocks: map[uint32]bindings.StructsBlock{
+				1: {ProposerId: 3, Valid: false},
+				2: {ProposerId: stakerId, Valid: true},
+			},
+			want: false,
+		},
+		{
+			name:              "Test 3: When the staker didn't propose in the epoch",
+			lastProposedEpoch: epoch - 1,
+			want:              false,
+		},
+		{
+			name:                 "Test 4: When there is an error in getting the last proposed epoch",
+			lastProposedEpochErr: errors.New("last proposed epoch error"),
+			wantErr:              true,
+		},
+		{
+			name:              "Test 5: When there is an error in getting a proposed block",
+			lastProposedEpoch: epoch,
+			proposedBlocks:    map[uint32]bindings.StructsBlock{1: {}, 2: {}},
+			proposedBlockErr:  errors.New("proposed block error"),
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetEpochLastProposed", mock.Anything, stakerId).Return(tt.lastProposedEpoch, tt.lastProposedEpochErr)
+			utilsMock.On("GetSortedProposedBlockIds", mock.Anything, epoch).Return([]uint32{1, 2}, nil)
+			for blockId, proposedBlock := range tt.proposedBlocks {
+				utilsMock.On("GetProposedBlock", mock.Anything, epoch, blockId).Return(proposedBlock, tt.proposedBlockErr)
+			}
+
+			got, err := checkProposedBlockDisputed(rpcParameters, account, stakerId, epoch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkProposedBlockDisputed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkProposedBlockDisputed() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func BenchmarkHandleDispute(b *testing.B) {
 	privateKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
 	txnOpts, _ := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(31337))
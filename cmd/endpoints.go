@@ -0,0 +1,217 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"razor/metrics"
+	"razor/rpc"
+	"razor/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// endpointsCmd represents the endpoints command
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints",
+	Short: "endpoints command can be used to inspect the RPC endpoints in endpoints.json",
+	Long: `Provides the subcommands to inspect the RPC endpoints in endpoints.json
+
+Example:
+  ./razor endpoints status`,
+}
+
+// endpointsStatusCmd represents the endpoints status command
+var endpointsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "status command can be used to list the RPC endpoints by their current ranking",
+	Long: `Measures the RPC endpoints in endpoints.json and lists them by their ranking, with their priority, weight, score, block lag, latency and error history.
+The endpoints are measured once when the command runs. Pass the URL of the status endpoint of a vote process running with --exposeMetrics to list the endpoints as ranked by that node instead, their URLs are redacted by the node.
+
+Example:
+  ./razor endpoints status
+  ./razor endpoints status --output json
+  ./razor endpoints status --statusURL http://localhost:2112/status`,
+	Run: initialiseEndpointsStatus,
+}
+
+//This function initialises the ExecuteEndpointsStatus function
+func initialiseEndpointsStatus(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteEndpointsStatus(cmd.Flags())
+}
+
+// endpointStatusRecord is an endpoint in the output of endpoints status
+type endpointStatusRecord struct {
+	Rank              int     `json:"rank" yaml:"rank"`
+	URL               string  `json:"url" yaml:"url"`
+	Priority          int     `json:"priority" yaml:"priority"`
+	Weight            float64 `json:"weight" yaml:"weight"`
+	Score             float64 `json:"score" yaml:"score"`
+	BlockNumber       uint64  `json:"blockNumber" yaml:"blockNumber"`
+	BlockLag          uint64  `json:"blockLag" yaml:"blockLag"`
+	AverageLatency    float64 `json:"averageLatency" yaml:"averageLatency"`
+	ErrorRate         float64 `json:"errorRate" yaml:"errorRate"`
+	ConsecutiveErrors int     `json:"consecutiveErrors" yaml:"consecutiveErrors"`
+	QuarantinedUntil  string  `json:"quarantinedUntil,omitempty" yaml:"quarantinedUntil,omitempty"`
+	IsBest            bool    `json:"isBest" yaml:"isBest"`
+	IsAvailable       bool    `json:"isAvailable" yaml:"isAvailable"`
+	LastError         string  `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+}
+
+//This function sets the flag appropriatley and executes the EndpointsStatus function
+func (*UtilsStruct) ExecuteEndpointsStatus(flagSet *pflag.FlagSet) {
+	statusURL, err := flagSetUtils.GetStringStatusURL(flagSet)
+	utils.CheckError("Error in getting status URL: ", err)
+
+	if statusURL != "" {
+		config, err := cmdUtils.GetConfigData()
+		utils.CheckError("Error in getting config: ", err)
+
+		output, err := flagSetUtils.GetStringOutput(flagSet)
+		utils.CheckError("Error in getting output format: ", err)
+
+		log.Debug("ExecuteEndpointsStatus: Reading endpoints status from ", statusURL)
+		endpoints, err := getNodeEndpointsStatus(&http.Client{Timeout: time.Duration(config.HTTPTimeout) * time.Second}, statusURL)
+		utils.CheckError("Error in getting endpoints status from node: ", err)
+
+		err = printEndpointsStatus(endpoints, output)
+		utils.CheckError("Error in printing endpoints status: ", err)
+		return
+	}
+
+	_, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
+	err = cmdUtils.EndpointsStatus(rpcParameters, output)
+	utils.CheckError("Error in printing endpoints status: ", err)
+}
+
+//This function lists the RPC endpoints by their ranking in the given output format
+func (*UtilsStruct) EndpointsStatus(rpcParameters rpc.RPCParameters, output string) error {
+	return printEndpointsStatus(getEndpointsStatus(rpcParameters.RPCManager, false), output)
+}
+
+//This function returns the current status of the RPC endpoints. If the URLs are to be redacted, the endpoints are identified by their metrics label.
+func getEndpointsStatus(rpcManager *rpc.RPCManager, redactURLs bool) []metrics.EndpointStatus {
+	endpoints := []metrics.EndpointStatus{}
+	for _, status := range rpcManager.GetEndpointsStatus() {
+		endpoint := metrics.EndpointStatus{
+			Rank:              status.Rank,
+			Endpoint:          status.URL,
+			Priority:          status.Priority,
+			Weight:            status.Weight,
+			Score:             status.Health.Score,
+			BlockNumber:       status.BlockNumber,
+			BlockLag:          status.BlockLag,
+			AverageLatency:    status.Health.AverageLatency,
+			ErrorRate:         status.Health.ErrorRate,
+			ConsecutiveErrors: status.Health.ConsecutiveErrors,
+			QuarantinedUntil:  status.Health.QuarantinedUntil,
+			IsBest:            status.IsBest,
+			IsAvailable:       status.IsAvailable,
+			LastError:         status.Health.LastError,
+		}
+		if redactURLs {
+			endpoint.Endpoint = metrics.EndpointLabel(status.URL)
+			// The error of a failed request quotes the URL it was sent to
+			endpoint.LastError = strings.ReplaceAll(endpoint.LastError, status.URL, endpoint.Endpoint)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+//This function reads the status of the RPC endpoints from the status endpoint of a running vote process
+func getNodeEndpointsStatus(httpClient *http.Client, statusURL string) ([]metrics.EndpointStatus, error) {
+	response, err := httpClient.Get(statusURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status endpoint returned %s", response.Status)
+	}
+	var nodeStatus metrics.NodeStatus
+	if err := json.NewDecoder(response.Body).Decode(&nodeStatus); err != nil {
+		return nil, err
+	}
+	if nodeStatus.Endpoints == nil {
+		return nil, errors.New("node doesn't serve the status of its endpoints")
+	}
+	return nodeStatus.Endpoints, nil
+}
+
+//This function prints the RPC endpoints by their ranking in the given output format
+func printEndpointsStatus(endpoints []metrics.EndpointStatus, output string) error {
+	var (
+		records []endpointStatusRecord
+		rows    [][]string
+	)
+	for _, status := range endpoints {
+		record := endpointStatusRecord{
+			Rank:              status.Rank,
+			URL:               status.Endpoint,
+			Priority:          status.Priority,
+			Weight:            status.Weight,
+			Score:             status.Score,
+			BlockNumber:       status.BlockNumber,
+			BlockLag:          status.BlockLag,
+			AverageLatency:    status.AverageLatency,
+			ErrorRate:         status.ErrorRate,
+			ConsecutiveErrors: status.ConsecutiveErrors,
+			IsBest:            status.IsBest,
+			IsAvailable:       status.IsAvailable,
+			LastError:         status.LastError,
+		}
+		if status.QuarantinedUntil.After(time.Now()) {
+			record.QuarantinedUntil = status.QuarantinedUntil.Format(time.RFC3339)
+		}
+		records = append(records, record)
+
+		state := "available"
+		if record.QuarantinedUntil != "" {
+			state = "quarantined until " + record.QuarantinedUntil
+		} else if !status.IsAvailable {
+			state = "unreachable"
+		}
+		if status.IsBest {
+			state += ", best"
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(record.Rank),
+			record.URL,
+			strconv.Itoa(record.Priority),
+			strconv.FormatFloat(record.Weight, 'f', -1, 64),
+			strconv.FormatFloat(record.Score, 'f', 3, 64),
+			strconv.FormatUint(record.BlockLag, 10),
+			strconv.FormatFloat(record.AverageLatency, 'f', 3, 64),
+			strconv.FormatFloat(record.ErrorRate, 'f', 2, 64),
+			state,
+		})
+	}
+	return printOutput(output, outputData{
+		Header:  []string{"Rank", "URL", "Priority", "Weight", "Score", "Block Lag", "Latency (s)", "Error Rate", "State"},
+		Fields:  []string{"rank", "url", "priority", "weight", "score", "blockLag", "averageLatency", "errorRate", "state"},
+		Rows:    rows,
+		Records: records,
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(endpointsCmd)
+	endpointsCmd.AddCommand(endpointsStatusCmd)
+
+	var StatusURL string
+
+	endpointsStatusCmd.Flags().StringVarP(&StatusURL, "statusURL", "", "", "URL of the status endpoint of a running vote process to read the endpoints status from")
+	addOutputFlag(endpointsStatusCmd)
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"razor/core/types"
+	"razor/metrics"
+	"razor/rpc"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndpointsStatus(t *testing.T) {
+	best := &rpc.RPCEndpoint{URL: "https://best.example.com", BlockNumber: 100, Client: &ethclient.Client{}}
+	tests := []struct {
+		name       string
+		rpcManager *rpc.RPCManager
+		output     string
+		wantErr    bool
+	}{
+		{
+			name: "Test 1: When EndpointsStatus() executes successfully",
+			rpcManager: &rpc.RPCManager{
+				Endpoints:    []*rpc.RPCEndpoint{{URL: "https://unreachable.example.com"}, best},
+				BestEndpoint: best,
+			},
+			output: OutputJSON,
+		},
+		{
+			name:       "Test 2: When the output format is invalid",
+			rpcManager: &rpc.RPCManager{Endpoints: []*rpc.RPCEndpoint{best}, BestEndpoint: best},
+			output:     "xml",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ut := &UtilsStruct{}
+			err := ut.EndpointsStatus(rpc.RPCParameters{RPCManager: tt.rpcManager}, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error for EndpointsStatus function, got = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEndpointsStatus(t *testing.T) {
+	keyURL := "https://rpc.example.com/v1/secret-key"
+	endpoint := &rpc.RPCEndpoint{URL: keyURL, BlockNumber: 100, Client: &ethclient.Client{}}
+	endpoint.Health.LastError = `Post "` + keyURL + `": dial tcp: connection refused`
+	manager := &rpc.RPCManager{Endpoints: []*rpc.RPCEndpoint{endpoint}, BestEndpoint: endpoint}
+
+	if got := getEndpointsStatus(manager, false); len(got) != 1 || got[0].Endpoint != keyURL {
+		t.Errorf("getEndpointsStatus() = %+v, want the URL of the endpoint", got)
+	}
+	got := getEndpointsStatus(manager, true)
+	label := metrics.EndpointLabel(keyURL)
+	if len(got) != 1 || got[0].Endpoint != label || got[0].LastError != `Post "`+label+`": dial tcp: connection refused` {
+		t.Errorf("getEndpointsStatus() with redacted URLs = %+v, want the endpoint identified by %s", got, label)
+	}
+}
+
+func TestGetNodeEndpointsStatus(t *testing.T) {
+	endpoints := []metrics.EndpointStatus{{Rank: 1, Endpoint: "rpc.example.com", BlockNumber: 100, IsBest: true, IsAvailable: true}}
+	tests := []struct {
+		name    string
+		status  interface{}
+		code    int
+		want    []metrics.EndpointStatus
+		wantErr bool
+	}{
+		{
+			name:   "Test 1: When the node serves the status of its endpoints",
+			status: metrics.NodeStatus{StakerId: 1, Endpoints: endpoints},
+			code:   http.StatusOK,
+			want:   endpoints,
+		},
+		{
+			name:    "Test 2: When the node doesn't serve the status of its endpoints",
+			status:  map[string]interface{}{"stakerId": 1},
+			code:    http.StatusOK,
+			wantErr: true,
+		},
+		{
+			name:    "Test 3: When the status endpoint returns an error",
+			code:    http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+				_ = json.NewEncoder(w).Encode(tt.status)
+			}))
+			defer server.Close()
+
+			got, err := getNodeEndpointsStatus(&http.Client{Timeout: time.Second}, server.URL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getNodeEndpointsStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].Endpoint != tt.want[0].Endpoint || got[0].BlockNumber != tt.want[0].BlockNumber) {
+				t.Errorf("getNodeEndpointsStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteEndpointsStatus(t *testing.T) {
+	var flagSet *pflag.FlagSet
+	tests := []struct {
+		name          string
+		statusURL     string
+		expectedFatal bool
+	}{
+		{
+			name:          "Test 1: When ExecuteEndpointsStatus() executes successfully",
+			expectedFatal: false,
+		},
+		{
+			name:          "Test 2: When the status endpoint of the node can't be reached",
+			statusURL:     "http://127.0.0.1:0/status",
+			expectedFatal: true,
+		},
+	}
+
+	defer func() { log.LogrusInstance.ExitFunc = nil }()
+	var fatal bool
+	log.LogrusInstance.ExitFunc = func(int) { fatal = true }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+			setupTestEndpointsEnvironment()
+
+			utilsMock.On("IsFlagPassed", mock.Anything).Return(false)
+			fileUtilsMock.On("AssignLogFile", mock.AnythingOfType("*pflag.FlagSet"), mock.Anything)
+			cmdUtilsMock.On("GetConfigData").Return(types.Configurations{}, nil)
+			flagSetMock.On("GetStringOutput", flagSet).Return(OutputTable, nil)
+			flagSetMock.On("GetStringStatusURL", flagSet).Return(tt.statusURL, nil)
+			cmdUtilsMock.On("EndpointsStatus", mock.Anything, mock.Anything).Return(nil)
+
+			utils := &UtilsStruct{}
+			fatal = false
+
+			utils.ExecuteEndpointsStatus(flagSet)
+			if fatal != tt.expectedFatal {
+				t.Error("The ExecuteEndpointsStatus function didn't execute as expected")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"errors"
+	"os"
+	"razor/core"
+	"razor/core/types"
+	"razor/journal"
+	"razor/path"
+)
+
+// openEpochJournal opens the epoch journal of the given address and imports the existing data files into it if it is empty.
+func openEpochJournal(address string) (*journal.Journal, error) {
+	journalFilePath, err := pathUtils.GetEpochJournalFileName(address)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Epoch journal file path: ", journalFilePath)
+
+	openedJournal, err := journal.Open(journalFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if openedJournal.IsEmpty() {
+		if err := migrateDataFilesToJournal(openedJournal, address); err != nil {
+			log.Error("Error in migrating data files to epoch journal: ", err)
+		}
+	}
+	return openedJournal, nil
+}
+
+// migrateDataFilesToJournal imports the latest commit, propose and dispute data saved in the data files into the journal
+func migrateDataFilesToJournal(openedJournal *journal.Journal, address string) error {
+	commitFilePath, err := pathUtils.GetCommitDataFileName(address)
+	if err != nil {
+		return err
+	}
+	if dataFileExists(commitFilePath) {
+		commitData, err := fileUtils.ReadFromCommitJsonFile(commitFilePath)
+		if err != nil {
+			return err
+		}
+		log.Infof("Migrating commit data of epoch %d from %s to epoch journal", commitData.Epoch, commitFilePath)
+		if err := openedJournal.AppendMigrated(commitData.Epoch, journal.KindCommit, commitData); err != nil {
+			return err
+		}
+	}
+
+	proposeFilePath, err := pathUtils.GetProposeDataFileName(address)
+	if err != nil {
+		return err
+	}
+	if dataFileExists(proposeFilePath) {
+		proposeData, err := fileUtils.ReadFromProposeJsonFile(proposeFilePath)
+		if err != nil {
+			return err
+		}
+		log.Infof("Migrating propose data of epoch %d from %s to epoch journal", proposeData.Epoch, proposeFilePath)
+		if err := openedJournal.AppendMigrated(proposeData.Epoch, journal.KindPropose, proposeData); err != nil {
+			return err
+		}
+	}
+
+	disputeFilePath, err := pathUtils.GetDisputeDataFileName(address)
+	if err != nil {
+		return err
+	}
+	if dataFileExists(disputeFilePath) {
+		disputeData, err := fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
+		if err != nil {
+			return err
+		}
+		if len(disputeData.BountyIdQueue) > 0 {
+			// Dispute data file doesn't store the epoch in which the bounties were earned
+			log.Infof("Migrating dispute data from %s to epoch journal", disputeFilePath)
+			if err := openedJournal.AppendMigrated(0, journal.KindDispute, types.DisputeJournalData{BountyIdQueue: disputeData.BountyIdQueue}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func dataFileExists(filePath string) bool {
+	_, err := path.OSUtilsInterface.Stat(filePath)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// recordInEpochJournal appends data to the epoch journal of the staker. Failing to record is logged and doesn't stop voting.
+func (state *stakerState) recordInEpochJournal(epoch uint32, kind journal.Kind, data interface{}) {
+	if state.epochJournal == nil {
+		return
+	}
+	if err := state.epochJournal.Append(epoch, kind, data); err != nil {
+		log.Errorf("Error in recording %s data of epoch %d in epoch journal: %v", kind, epoch, err)
+	}
+}
+
+// trimEpochJournal drops the epoch journal entries of the epochs older than the retained epochs from memory
+func (state *stakerState) trimEpochJournal(epoch uint32) {
+	if state.epochJournal == nil || epoch <= core.EpochJournalRetainedEpochs {
+		return
+	}
+	state.epochJournal.Trim(epoch - core.EpochJournalRetainedEpochs)
+}
+
+// getCommitDataFromJournal returns the commit data recorded in the epoch journal of the staker for the given epoch
+func (state *stakerState) getCommitDataFromJournal(epoch uint32) (types.CommitFileData, bool) {
+	if state.epochJournal == nil {
+		return types.CommitFileData{}, false
+	}
+	entry, found := state.epochJournal.LatestForEpoch(journal.KindCommit, epoch)
+	if !found {
+		return types.CommitFileData{}, false
+	}
+	var commitData types.CommitFileData
+	if err := entry.Decode(&commitData); err != nil {
+		log.Errorf("Error in decoding commit data of epoch %d from epoch journal: %v", epoch, err)
+		return types.CommitFileData{}, false
+	}
+	return commitData, true
+}
+
+// getProposeDataFromJournal returns the propose data recorded in the epoch journal of the staker for the given epoch
+func (state *stakerState) getProposeDataFromJournal(epoch uint32) (types.ProposeFileData, bool) {
+	if state.epochJournal == nil {
+		return types.ProposeFileData{}, false
+	}
+	entry, found := state.epochJournal.LatestForEpoch(journal.KindPropose, epoch)
+	if !found {
+		return types.ProposeFileData{}, false
+	}
+	var proposeData types.ProposeFileData
+	if err := entry.Decode(&proposeData); err != nil {
+		log.Errorf("Error in decoding propose data of epoch %d from epoch journal: %v", epoch, err)
+		return types.ProposeFileData{}, false
+	}
+	return proposeData, true
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"razor/core/types"
+	"razor/journal"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMigrateDataFilesToJournal(t *testing.T) {
+	var fileInfo fs.FileInfo
+	commitFileData := types.CommitFileData{
+		Epoch:                  5,
+		AssignedCollections:    map[int]bool{1: true},
+		SeqAllottedCollections: []*big.Int{big.NewInt(1)},
+		Leaves:                 []*big.Int{big.NewInt(100), big.NewInt(200)},
+		Commitment:             [32]byte{1, 2, 3},
+	}
+
+	type args struct {
+		commitFileErr    error
+		commitFileExists bool
+		readCommitErr    error
+		disputeData      types.DisputeFileData
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantCommitEntry bool
+		wantEntries     int
+		wantErr         bool
+	}{
+		{
+			name: "Test 1: When commit and dispute data files are migrated successfully",
+			args: args{
+				commitFileExists: true,
+				disputeData:      types.DisputeFileData{BountyIdQueue: []uint32{2, 1}},
+			},
+			wantCommitEntry: true,
+			wantEntries:     2,
+			wantErr:         false,
+		},
+		{
+			name: "Test 2: When there are no data files to migrate",
+			args: args{
+				commitFileExists: false,
+			},
+			wantEntries: 0,
+			wantErr:     false,
+		},
+		{
+			name: "Test 3: When there is an error in getting commit data file name",
+			args: args{
+				commitFileErr: errors.New("path error"),
+			},
+			wantEntries: 0,
+			wantErr:     true,
+		},
+		{
+			name: "Test 4: When there is an error in reading commit data file",
+			args: args{
+				commitFileExists: true,
+				readCommitErr:    errors.New("read error"),
+			},
+			wantEntries: 0,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			testJournal, err := journal.Open(filepath.Join(t.TempDir(), "journal.jsonl"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer testJournal.Close()
+
+			commitStatErr := os.ErrNotExist
+			if tt.args.commitFileExists {
+				commitStatErr = nil
+			}
+			pathMock.On("GetCommitDataFileName", mock.Anything).Return("commit", tt.args.commitFileErr)
+			pathMock.On("GetProposeDataFileName", mock.Anything).Return("propose", nil)
+			pathMock.On("GetDisputeDataFileName", mock.Anything).Return("dispute", nil)
+			osPathMock.On("Stat", "commit").Return(fileInfo, commitStatErr)
+			osPathMock.On("Stat", "propose").Return(fileInfo, os.ErrNotExist)
+			osPathMock.On("Stat", "dispute").Return(fileInfo, commitStatErr)
+			fileUtilsMock.On("ReadFromCommitJsonFile", mock.Anything).Return(commitFileData, tt.args.readCommitErr)
+			fileUtilsMock.On("ReadFromDisputeJsonFile", mock.Anything).Return(tt.args.disputeData, nil)
+
+			err = migrateDataFilesToJournal(testJournal, "0x000000000000000000000000000000000000dead")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("migrateDataFilesToJournal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(testJournal.Entries()); got != tt.wantEntries {
+				t.Errorf("Expected %d entries in journal, got %d", tt.wantEntries, got)
+			}
+
+			state := &stakerState{epochJournal: testJournal}
+			commitData, found := state.getCommitDataFromJournal(commitFileData.Epoch)
+			if found != tt.wantCommitEntry {
+				t.Errorf("Expected commit entry found to be %v, got %v", tt.wantCommitEntry, found)
+			}
+			if found && !reflect.DeepEqual(commitData, commitFileData) {
+				t.Errorf("Commit data from journal = %+v, want %+v", commitData, commitFileData)
+			}
+		})
+	}
+}
+
+func TestGetProposeDataFromJournal(t *testing.T) {
+	proposeData := types.ProposeFileData{
+		Epoch:                 7,
+		MediansData:           []*big.Int{big.NewInt(1000)},
+		RevealedCollectionIds: []uint16{1},
+		RevealedDataMaps: &types.RevealedDataMaps{
+			SortedRevealedValues: map[uint16][]*big.Int{0: {big.NewInt(1000)}},
+			VoteWeights:          map[string]*big.Int{"1000": big.NewInt(10)},
+			InfluenceSum:         map[uint16]*big.Int{0: big.NewInt(10)},
+		},
+	}
+
+	state := &stakerState{}
+	if _, found := state.getProposeDataFromJournal(proposeData.Epoch); found {
+		t.Error("Expected no propose data when epoch journal is not opened")
+	}
+
+	testJournal, err := journal.Open(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testJournal.Close()
+	state.epochJournal = testJournal
+
+	state.recordInEpochJournal(proposeData.Epoch, journal.KindPropose, proposeData)
+
+	got, found := state.getProposeDataFromJournal(proposeData.Epoch)
+	if !found {
+		t.Fatal("Expected propose data to be found in epoch journal")
+	}
+	if !reflect.DeepEqual(got, proposeData) {
+		t.Errorf("Propose data from journal = %+v, want %+v", got, proposeData)
+	}
+	if _, found := state.getProposeDataFromJournal(proposeData.Epoch + 1); found {
+		t.Error("Expected no propose data for next epoch")
+	}
+}
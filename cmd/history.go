@@ -0,0 +1,409 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/rpc"
+	"razor/utils"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "per epoch participation of a staker",
+	Long: `Scans the chain for a staker over a range of epochs and shows whether the staker committed, revealed and proposed in every epoch,
+whether its proposed block was confirmed or disputed, whether it was penalized and the change in its stake.
+If no epoch range is given, the last 10 epochs are shown.
+
+Example:
+  ./razor history --stakerId 2
+  ./razor history --stakerId 2 --fromEpoch 1000 --toEpoch 1020 --output csv`,
+	Run: initialiseHistory,
+}
+
+//This function initialises the ExecuteHistory function
+func initialiseHistory(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteHistory(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the GetStakerHistory function
+func (*UtilsStruct) ExecuteHistory(flagSet *pflag.FlagSet) {
+	_, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	stakerId, err := flagSetUtils.GetUint32StakerId(flagSet)
+	utils.CheckError("Error in getting stakerId: ", err)
+	log.Debug("ExecuteHistory: StakerId: ", stakerId)
+
+	fromEpoch, err := flagSetUtils.GetUint32FromEpoch(flagSet)
+	utils.CheckError("Error in getting fromEpoch: ", err)
+
+	toEpoch, err := flagSetUtils.GetUint32ToEpoch(flagSet)
+	utils.CheckError("Error in getting toEpoch: ", err)
+
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
+	currentEpoch, err := razorUtils.GetEpoch(rpcParameters)
+	utils.CheckError("Error in getting epoch: ", err)
+
+	fromEpoch, toEpoch, err = getHistoryEpochRange(fromEpoch, toEpoch, currentEpoch)
+	utils.CheckError("Invalid epoch range: ", err)
+	log.Debugf("ExecuteHistory: From epoch: %d, To epoch: %d", fromEpoch, toEpoch)
+
+	log.Debugf("ExecuteHistory: Calling GetStakerHistory() with arguments stakerId = %d, fromEpoch = %d, toEpoch = %d", stakerId, fromEpoch, toEpoch)
+	history, err := cmdUtils.GetStakerHistory(rpcParameters, stakerId, fromEpoch, toEpoch)
+	utils.CheckError("Error in getting staker history: ", err)
+
+	err = printHistory(history, output)
+	utils.CheckError("Error in printing staker history: ", err)
+}
+
+//This function returns the epoch range to be scanned, by default the last HistoryDefaultEpochs epochs are scanned
+func getHistoryEpochRange(fromEpoch uint32, toEpoch uint32, currentEpoch uint32) (uint32, uint32, error) {
+	if toEpoch == 0 || toEpoch > currentEpoch {
+		toEpoch = currentEpoch
+	}
+	if fromEpoch == 0 {
+		if toEpoch >= core.HistoryDefaultEpochs {
+			fromEpoch = toEpoch - core.HistoryDefaultEpochs + 1
+		} else {
+			fromEpoch = 1
+		}
+	}
+	if fromEpoch > toEpoch {
+		return 0, 0, fmt.Errorf("fromEpoch %d is greater than toEpoch %d", fromEpoch, toEpoch)
+	}
+	return fromEpoch, toEpoch, nil
+}
+
+//This function scans the chain and returns the participation of the staker in every epoch of the given range
+func (*UtilsStruct) GetStakerHistory(rpcParameters rpc.RPCParameters, stakerId uint32, fromEpoch uint32, toEpoch uint32) ([]types.EpochHistory, error) {
+	latestHeader, err := clientUtils.GetLatestBlockWithRetry(rpcParameters)
+	if err != nil {
+		log.Error("Error in fetching block: ", err)
+		return nil, err
+	}
+
+	fromBlock, err := getFirstBlockOfEpoch(rpcParameters, fromEpoch, latestHeader)
+	if err != nil {
+		return nil, err
+	}
+	toBlock := latestHeader.Number
+	if uint64(toEpoch+1)*core.EpochLength <= latestHeader.Time {
+		firstBlockOfNextEpoch, err := getFirstBlockOfEpoch(rpcParameters, toEpoch+1, latestHeader)
+		if err != nil {
+			return nil, err
+		}
+		toBlock = new(big.Int).Sub(firstBlockOfNextEpoch, big.NewInt(1))
+	}
+	log.Debugf("GetStakerHistory: Scanning events from block %s to block %s", fromBlock, toBlock)
+
+	history := make(map[uint32]*types.EpochHistory)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		history[epoch] = &types.EpochHistory{
+			Epoch:      epoch,
+			StakeDelta: big.NewInt(0),
+		}
+	}
+	stakerIdTopic := common.BigToHash(big.NewInt(int64(stakerId)))
+
+	voteManagerABI, err := utils.ABIInterface.Parse(strings.NewReader(bindings.VoteManagerMetaData.ABI))
+	if err != nil {
+		return nil, err
+	}
+	voteManagerLogs, err := getEventLogsInRange(rpcParameters, core.VoteManagerAddress, [][]common.Hash{
+		{voteManagerABI.Events[core.CommittedEvent].ID, voteManagerABI.Events[core.RevealedEvent].ID},
+		{stakerIdTopic},
+	}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	for _, vLog := range voteManagerLogs {
+		var eventName string
+		switch vLog.Topics[0] {
+		case voteManagerABI.Events[core.CommittedEvent].ID:
+			eventName = core.CommittedEvent
+		case voteManagerABI.Events[core.RevealedEvent].ID:
+			eventName = core.RevealedEvent
+		default:
+			continue
+		}
+		epoch, err := unpackEventEpoch(voteManagerABI, voteManagerABI.Events[eventName], vLog.Data)
+		if err != nil {
+			log.Debugf("Error in unpacking %s event: %v", eventName, err)
+			continue
+		}
+		epochHistory, ok := history[epoch]
+		if !ok {
+			continue
+		}
+		if eventName == core.CommittedEvent {
+			epochHistory.Committed = true
+		} else {
+			epochHistory.Revealed = true
+		}
+	}
+
+	stakeManagerABI, err := utils.ABIInterface.Parse(strings.NewReader(bindings.StakeManagerMetaData.ABI))
+	if err != nil {
+		return nil, err
+	}
+	stakeChangeEvent := stakeManagerABI.Events[core.StakeChangeEvent]
+	stakeManagerLogs, err := getEventLogsInRange(rpcParameters, core.StakeManagerAddress, [][]common.Hash{
+		{stakeChangeEvent.ID},
+		{stakerIdTopic},
+	}, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	for _, vLog := range stakeManagerLogs {
+		epoch, reason, prevStake, newStake, err := unpackStakeChange(stakeManagerABI, stakeChangeEvent, vLog.Data)
+		if err != nil {
+			log.Debug("Error in unpacking StakeChange event: ", err)
+			continue
+		}
+		epochHistory, ok := history[epoch]
+		if !ok {
+			continue
+		}
+		delta := new(big.Int).Sub(newStake, prevStake)
+		epochHistory.StakeDelta.Add(epochHistory.StakeDelta, delta)
+		if reason == core.StakeChangeInactivityPenalty || reason == core.StakeChangeSlashed {
+			epochHistory.Penalized = true
+		}
+	}
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		err = updateBlockHistory(rpcParameters, stakerId, history[epoch])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var stakerHistory []types.EpochHistory
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		stakerHistory = append(stakerHistory, *history[epoch])
+	}
+	return stakerHistory, nil
+}
+
+//This function checks whether the staker proposed in the epoch and whether that block was confirmed or disputed
+func updateBlockHistory(rpcParameters rpc.RPCParameters, stakerId uint32, epochHistory *types.EpochHistory) error {
+	sortedProposedBlockIds, err := razorUtils.GetSortedProposedBlockIds(rpcParameters, epochHistory.Epoch)
+	if err != nil {
+		log.Error("Error in getting sorted proposed block ids: ", err)
+		return err
+	}
+	for _, blockId := range sortedProposedBlockIds {
+		proposedBlock, err := razorUtils.GetProposedBlock(rpcParameters, epochHistory.Epoch, blockId)
+		if err != nil {
+			log.Error("Error in getting proposed block: ", err)
+			return err
+		}
+		if proposedBlock.ProposerId == stakerId {
+			epochHistory.Proposed = true
+			epochHistory.Disputed = !proposedBlock.Valid
+		}
+	}
+	if !epochHistory.Proposed {
+		return nil
+	}
+
+	confirmedBlock, err := razorUtils.GetConfirmedBlocks(rpcParameters, epochHistory.Epoch)
+	if err != nil {
+		log.Error("Error in getting confirmed block: ", err)
+		return err
+	}
+	epochHistory.Confirmed = confirmedBlock.ProposerId == stakerId
+	return nil
+}
+
+//This function returns the epoch from the data of an event, which is the epoch in which the contract emitted the event
+func unpackEventEpoch(contractABI abi.ABI, event abi.Event, data []byte) (uint32, error) {
+	values, err := abiUtils.Unpack(contractABI, event.Name, data)
+	if err != nil {
+		return 0, err
+	}
+	for i, input := range event.Inputs.NonIndexed() {
+		if i >= len(values) {
+			break
+		}
+		if input.Name == "epoch" {
+			if epoch, ok := values[i].(uint32); ok {
+				return epoch, nil
+			}
+		}
+	}
+	return 0, errors.New("epoch not present in event")
+}
+
+//This function returns the epoch, reason, previous and new stake from the data of a StakeChange event
+func unpackStakeChange(contractABI abi.ABI, event abi.Event, data []byte) (uint32, uint8, *big.Int, *big.Int, error) {
+	values, err := abiUtils.Unpack(contractABI, event.Name, data)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+	var (
+		epoch               uint32
+		isEpochPresent      bool
+		reason              uint8
+		isReasonPresent     bool
+		prevStake, newStake *big.Int
+	)
+	for i, input := range event.Inputs.NonIndexed() {
+		if i >= len(values) {
+			break
+		}
+		switch input.Name {
+		case "epoch":
+			epoch, isEpochPresent = values[i].(uint32)
+		case "reason":
+			reason, isReasonPresent = values[i].(uint8)
+		case "prevStake":
+			prevStake, _ = values[i].(*big.Int)
+		case "newStake":
+			newStake, _ = values[i].(*big.Int)
+		}
+	}
+	if !isEpochPresent || !isReasonPresent || prevStake == nil || newStake == nil {
+		return 0, 0, nil, nil, errors.New("epoch, reason or stake values not present in event")
+	}
+	return epoch, reason, prevStake, newStake, nil
+}
+
+//This function returns the first block whose timestamp lies in the given epoch using binary search
+func getFirstBlockOfEpoch(rpcParameters rpc.RPCParameters, epoch uint32, latestHeader *Types.Header) (*big.Int, error) {
+	epochStartTime := uint64(epoch) * core.EpochLength
+	if latestHeader.Time < epochStartTime {
+		return nil, fmt.Errorf("epoch %d has not started yet", epoch)
+	}
+	low, high := uint64(0), latestHeader.Number.Uint64()
+	for low < high {
+		mid := low + (high-low)/2
+		header, err := clientUtils.GetBlockByNumberWithRetry(rpcParameters, new(big.Int).SetUint64(mid))
+		if err != nil {
+			log.Error("Error in fetching block: ", err)
+			return nil, err
+		}
+		if header.Time < epochStartTime {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return new(big.Int).SetUint64(low), nil
+}
+
+//This function fetches the logs of a contract in chunks of HistoryEventsBlockRange blocks
+func getEventLogsInRange(rpcParameters rpc.RPCParameters, contractAddress string, topics [][]common.Hash, fromBlock *big.Int, toBlock *big.Int) ([]Types.Log, error) {
+	var logs []Types.Log
+	chunkSize := big.NewInt(core.HistoryEventsBlockRange)
+	for start := new(big.Int).Set(fromBlock); start.Cmp(toBlock) <= 0; start = new(big.Int).Add(start, chunkSize) {
+		end := new(big.Int).Add(start, chunkSize)
+		end.Sub(end, big.NewInt(1))
+		if end.Cmp(toBlock) > 0 {
+			end.Set(toBlock)
+		}
+		query := ethereum.FilterQuery{
+			FromBlock: start,
+			ToBlock:   end,
+			Addresses: []common.Address{common.HexToAddress(contractAddress)},
+			Topics:    topics,
+		}
+		log.Debugf("getEventLogsInRange: Query to send in filter logs: %+v", query)
+		chunkLogs, err := clientUtils.FilterLogsWithRetry(rpcParameters, query)
+		if err != nil {
+			log.Error("Error in filter logs: ", err)
+			return nil, err
+		}
+		logs = append(logs, chunkLogs...)
+	}
+	return logs, nil
+}
+
+// epochHistoryRecord is an epoch in the output of history
+type epochHistoryRecord struct {
+	Epoch      uint32 `json:"epoch" yaml:"epoch"`
+	Committed  bool   `json:"committed" yaml:"committed"`
+	Revealed   bool   `json:"revealed" yaml:"revealed"`
+	Proposed   bool   `json:"proposed" yaml:"proposed"`
+	Confirmed  bool   `json:"confirmed" yaml:"confirmed"`
+	Disputed   bool   `json:"disputed" yaml:"disputed"`
+	Penalized  bool   `json:"penalized" yaml:"penalized"`
+	StakeDelta string `json:"stakeDelta" yaml:"stakeDelta"`
+}
+
+//This function prints the staker history in the given output format
+func printHistory(history []types.EpochHistory, output string) error {
+	return printOutput(output, historyOutputData(history))
+}
+
+//This function returns the staker history as output data, the stake change is a decimal string so that it isn't rounded by JSON parsers
+func historyOutputData(history []types.EpochHistory) outputData {
+	records := make([]epochHistoryRecord, 0, len(history))
+	var rows [][]string
+	for _, epochHistory := range history {
+		records = append(records, epochHistoryRecord{
+			Epoch:      epochHistory.Epoch,
+			Committed:  epochHistory.Committed,
+			Revealed:   epochHistory.Revealed,
+			Proposed:   epochHistory.Proposed,
+			Confirmed:  epochHistory.Confirmed,
+			Disputed:   epochHistory.Disputed,
+			Penalized:  epochHistory.Penalized,
+			StakeDelta: epochHistory.StakeDelta.String(),
+		})
+		rows = append(rows, historyRow(epochHistory))
+	}
+	return outputData{
+		Header:  []string{"Epoch", "Committed", "Revealed", "Proposed", "Confirmed", "Disputed", "Penalized", "Stake Change"},
+		Fields:  []string{"epoch", "committed", "revealed", "proposed", "confirmed", "disputed", "penalized", "stakeDelta"},
+		Rows:    rows,
+		Records: records,
+	}
+}
+
+func historyRow(epochHistory types.EpochHistory) []string {
+	return []string{
+		strconv.Itoa(int(epochHistory.Epoch)),
+		strconv.FormatBool(epochHistory.Committed),
+		strconv.FormatBool(epochHistory.Revealed),
+		strconv.FormatBool(epochHistory.Proposed),
+		strconv.FormatBool(epochHistory.Confirmed),
+		strconv.FormatBool(epochHistory.Disputed),
+		strconv.FormatBool(epochHistory.Penalized),
+		epochHistory.StakeDelta.String(),
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	var (
+		StakerId  uint32
+		FromEpoch uint32
+		ToEpoch   uint32
+	)
+
+	historyCmd.Flags().Uint32VarP(&StakerId, "stakerId", "", 0, "staker id")
+	historyCmd.Flags().Uint32VarP(&FromEpoch, "fromEpoch", "", 0, "first epoch to scan")
+	historyCmd.Flags().Uint32VarP(&ToEpoch, "toEpoch", "", 0, "last epoch to scan (defaults to current epoch)")
+	addOutputFlag(historyCmd)
+
+	stakerIdErr := historyCmd.MarkFlagRequired("stakerId")
+	utils.CheckError("StakerId error: ", stakerIdErr)
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/rpc"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+)
+
+const historyTestABI = `[
+	{"type":"event","name":"Committed","inputs":[{"name":"epoch","type":"uint32"},{"name":"stakerId","type":"uint32","indexed":true},{"name":"commitment","type":"bytes32"},{"name":"timestamp","type":"uint256"}]},
+	{"type":"event","name":"Revealed","inputs":[{"name":"epoch","type":"uint32"},{"name":"stakerId","type":"uint32","indexed":true},{"name":"influence","type":"uint256"},{"name":"timestamp","type":"uint256"}]},
+	{"type":"event","name":"StakeChange","inputs":[{"name":"epoch","type":"uint32"},{"name":"stakerId","type":"uint32","indexed":true},{"name":"reason","type":"uint8"},{"name":"prevStake","type":"uint256"},{"name":"newStake","type":"uint256"},{"name":"timestamp","type":"uint256"}]}
+]`
+
+func TestGetHistoryEpochRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		fromEpoch     uint32
+		toEpoch       uint32
+		currentEpoch  uint32
+		wantFromEpoch uint32
+		wantToEpoch   uint32
+		wantErr       bool
+	}{
+		{
+			name:          "Test 1: When no epoch range is given",
+			currentEpoch:  100,
+			wantFromEpoch: 91,
+			wantToEpoch:   100,
+		},
+		{
+			name:          "Test 2: When epoch range is given",
+			fromEpoch:     50,
+			toEpoch:       60,
+			currentEpoch:  100,
+			wantFromEpoch: 50,
+			wantToEpoch:   60,
+		},
+		{
+			name:          "Test 3: When toEpoch is greater than current epoch",
+			fromEpoch:     95,
+			toEpoch:       200,
+			currentEpoch:  100,
+			wantFromEpoch: 95,
+			wantToEpoch:   100,
+		},
+		{
+			name:          "Test 4: When current epoch is less than default number of epochs",
+			currentEpoch:  5,
+			wantFromEpoch: 1,
+			wantToEpoch:   5,
+		},
+		{
+			name:         "Test 5: When fromEpoch is greater than toEpoch",
+			fromEpoch:    80,
+			toEpoch:      70,
+			currentEpoch: 100,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFromEpoch, gotToEpoch, err := getHistoryEpochRange(tt.fromEpoch, tt.toEpoch, tt.currentEpoch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getHistoryEpochRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotFromEpoch != tt.wantFromEpoch || gotToEpoch != tt.wantToEpoch {
+				t.Errorf("getHistoryEpochRange() = (%d, %d), want (%d, %d)", gotFromEpoch, gotToEpoch, tt.wantFromEpoch, tt.wantToEpoch)
+			}
+		})
+	}
+}
+
+func TestGetStakerHistory(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(historyTestABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stakerId uint32 = 2
+	stakerIdTopic := common.BigToHash(big.NewInt(int64(stakerId)))
+
+	// Every block is 45 seconds apart, so there are 10 blocks in an epoch
+	blockTime := core.EpochLength / 10
+	headerByNumber := func(_ rpc.RPCParameters, blockNumber *big.Int) *Types.Header {
+		return &Types.Header{Number: blockNumber, Time: blockNumber.Uint64() * blockTime}
+	}
+	latestHeader := headerByNumber(rpcParameters, big.NewInt(1005))
+
+	packEvent := func(eventName string, values ...interface{}) []byte {
+		data, err := contractABI.Events[eventName].Inputs.NonIndexed().Pack(values...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	// The epoch of an event is taken from its data, the reveal at block 990 is in epoch 98 even though the block is the first one of epoch 99
+	voteManagerLogs := []Types.Log{
+		{BlockNumber: 981, Topics: []common.Hash{contractABI.Events[core.CommittedEvent].ID, stakerIdTopic}, Data: packEvent(core.CommittedEvent, uint32(98), [32]byte{1}, big.NewInt(0))},
+		{BlockNumber: 990, Topics: []common.Hash{contractABI.Events[core.RevealedEvent].ID, stakerIdTopic}, Data: packEvent(core.RevealedEvent, uint32(98), big.NewInt(10), big.NewInt(0))},
+		{BlockNumber: 991, Topics: []common.Hash{contractABI.Events[core.CommittedEvent].ID, stakerIdTopic}, Data: packEvent(core.CommittedEvent, uint32(99), [32]byte{2}, big.NewInt(0))},
+	}
+	stakeManagerLogs := []Types.Log{
+		{BlockNumber: 995, Topics: []common.Hash{contractABI.Events[core.StakeChangeEvent].ID, stakerIdTopic}},
+	}
+	filterLogs := func(_ rpc.RPCParameters, query ethereum.FilterQuery) []Types.Log {
+		var logs []Types.Log
+		source := stakeManagerLogs
+		if query.Addresses[0] == common.HexToAddress(core.VoteManagerAddress) {
+			source = voteManagerLogs
+		}
+		for _, vLog := range source {
+			if vLog.BlockNumber >= query.FromBlock.Uint64() && vLog.BlockNumber <= query.ToBlock.Uint64() {
+				logs = append(logs, vLog)
+			}
+		}
+		return logs
+	}
+
+	type args struct {
+		filterLogsErr      error
+		confirmedProposer  uint32
+		proposedBlockValid bool
+		stakeChangeReason  uint8
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.EpochHistory
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When staker history is fetched successfully",
+			args: args{
+				confirmedProposer:  stakerId,
+				proposedBlockValid: true,
+				stakeChangeReason:  core.StakeChangeInactivityPenalty,
+			},
+			want: []types.EpochHistory{
+				{Epoch: 98, Committed: true, Revealed: true, StakeDelta: big.NewInt(0)},
+				{Epoch: 99, Committed: true, Proposed: true, Confirmed: true, Penalized: true, StakeDelta: big.NewInt(-100)},
+				{Epoch: 100, StakeDelta: big.NewInt(0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 2: When proposed block of staker was disputed",
+			args: args{
+				confirmedProposer:  3,
+				proposedBlockValid: false,
+				stakeChangeReason:  core.StakeChangeSlashed,
+			},
+			want: []types.EpochHistory{
+				{Epoch: 98, Committed: true, Revealed: true, StakeDelta: big.NewInt(0)},
+				{Epoch: 99, Committed: true, Proposed: true, Disputed: true, Penalized: true, StakeDelta: big.NewInt(-100)},
+				{Epoch: 100, StakeDelta: big.NewInt(0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 3: When stake of staker decreased for a reason other than a penalty",
+			args: args{
+				confirmedProposer:  stakerId,
+				proposedBlockValid: true,
+				stakeChangeReason:  core.StakeChangeBlockReward,
+			},
+			want: []types.EpochHistory{
+				{Epoch: 98, Committed: true, Revealed: true, StakeDelta: big.NewInt(0)},
+				{Epoch: 99, Committed: true, Proposed: true, Confirmed: true, StakeDelta: big.NewInt(-100)},
+				{Epoch: 100, StakeDelta: big.NewInt(0)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 4: When there is an error in fetching logs",
+			args: args{
+				filterLogsErr: errors.New("filter logs error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			clientUtilsMock.On("GetLatestBlockWithRetry", mock.Anything).Return(latestHeader, nil)
+			clientUtilsMock.On("GetBlockByNumberWithRetry", mock.Anything, mock.Anything).Return(headerByNumber, nil)
+			if tt.args.filterLogsErr != nil {
+				clientUtilsMock.On("FilterLogsWithRetry", mock.Anything, mock.Anything).Return(nil, tt.args.filterLogsErr)
+			} else {
+				clientUtilsMock.On("FilterLogsWithRetry", mock.Anything, mock.Anything).Return(filterLogs, nil)
+			}
+			abiUtilsMock.On("Parse", mock.Anything).Return(contractABI, nil)
+			abiMock.On("Unpack", mock.Anything, core.StakeChangeEvent, mock.Anything).Return([]interface{}{uint32(99), tt.args.stakeChangeReason, big.NewInt(1000), big.NewInt(900), big.NewInt(0)}, nil)
+			abiMock.On("Unpack", mock.Anything, mock.Anything, mock.Anything).Return(func(contractABI abi.ABI, name string, data []byte) ([]interface{}, error) {
+				return contractABI.Unpack(name, data)
+			}, nil)
+			utilsMock.On("GetSortedProposedBlockIds", mock.Anything, uint32(99)).Return([]uint32{1}, nil)
+			utilsMock.On("GetSortedProposedBlockIds", mock.Anything, mock.Anything).Return([]uint32{}, nil)
+			utilsMock.On("GetProposedBlock", mock.Anything, uint32(99), uint32(1)).Return(bindings.StructsBlock{ProposerId: stakerId, Valid: tt.args.proposedBlockValid}, nil)
+			utilsMock.On("GetConfirmedBlocks", mock.Anything, uint32(99)).Return(types.ConfirmedBlock{ProposerId: tt.args.confirmedProposer}, nil)
+
+			utils := &UtilsStruct{}
+			got, err := utils.GetStakerHistory(rpcParameters, stakerId, 98, 100)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStakerHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetStakerHistory() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryOutputData(t *testing.T) {
+	stakeDelta, _ := new(big.Int).SetString("-123456789012345678901", 10)
+	history := []types.EpochHistory{{Epoch: 99, Committed: true, Penalized: true, StakeDelta: stakeDelta}}
+
+	var buffer bytes.Buffer
+	if err := writeOutput(&buffer, OutputJSON, historyOutputData(history)); err != nil {
+		t.Fatalf("writeOutput() error = %v", err)
+	}
+	var records []map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &records); err != nil {
+		t.Fatalf("Error in unmarshalling history output: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if got := records[0]["stakeDelta"]; got != "-123456789012345678901" {
+		t.Errorf("Expected stake change as a decimal string, got %v", got)
+	}
+	if got := records[0]["epoch"]; got != float64(99) {
+		t.Errorf("Expected epoch 99, got %v", got)
+	}
+}
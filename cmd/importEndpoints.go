@@ -29,7 +29,7 @@ func (*UtilsStruct) ExecuteImportEndpoints() {
 	utils.CheckError("Error in getting default path: ", err)
 
 	// Define the target path for endpoints.json
-	destFilePath := filepath.Join(defaultPath, "endpoints.json")
+	destFilePath := filepath.Join(defaultPath, core.EndpointsFile)
 
 	// Serialize the default endpoints to JSON
 	endpointsData, err := json.MarshalIndent(core.DefaultEndpoints, "", "  ")
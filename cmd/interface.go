@@ -9,6 +9,7 @@ import (
 	"razor/core/types"
 	"razor/path"
 	"razor/pkg/bindings"
+	"razor/recording"
 	"razor/rpc"
 	"time"
 
@@ -128,11 +129,30 @@ type FlagSetInterface interface {
 	GetBoolWeiRazor(flagSet *pflag.FlagSet) (bool, error)
 	GetUint32Tolerance(flagSet *pflag.FlagSet) (uint32, error)
 	GetBoolRogue(flagSet *pflag.FlagSet) (bool, error)
+	GetBoolDryRun(flagSet *pflag.FlagSet) (bool, error)
 	GetStringSliceRogueMode(flagSet *pflag.FlagSet) ([]string, error)
 	GetStringSliceBackupNode(flagSet *pflag.FlagSet) ([]string, error)
 	GetStringExposeMetrics(flagSet *pflag.FlagSet) (string, error)
 	GetStringCertFile(flagSet *pflag.FlagSet) (string, error)
 	GetStringCertKey(flagSet *pflag.FlagSet) (string, error)
+	GetUint32FromEpoch(flagSet *pflag.FlagSet) (uint32, error)
+	GetUint32ToEpoch(flagSet *pflag.FlagSet) (uint32, error)
+	GetStringOutput(flagSet *pflag.FlagSet) (string, error)
+	GetStringStakersFile(flagSet *pflag.FlagSet) (string, error)
+	GetStringLeaseFile(flagSet *pflag.FlagSet) (string, error)
+	GetStringStatusURL(flagSet *pflag.FlagSet) (string, error)
+	GetStringFundingAccount(flagSet *pflag.FlagSet) (string, error)
+	GetStringFundingPassword(flagSet *pflag.FlagSet) (string, error)
+	GetStringMinSFuelBalance(flagSet *pflag.FlagSet) (string, error)
+	GetStringTopUpAmount(flagSet *pflag.FlagSet) (string, error)
+	GetBoolAutoCompound(flagSet *pflag.FlagSet) (bool, error)
+	GetStringCompoundReserve(flagSet *pflag.FlagSet) (string, error)
+	GetStringMaxCompoundAmount(flagSet *pflag.FlagSet) (string, error)
+	GetBoolRecordResponses(flagSet *pflag.FlagSet) (bool, error)
+	GetUint32Epoch(flagSet *pflag.FlagSet) (uint32, error)
+	GetStringStakerAddress(flagSet *pflag.FlagSet) (string, error)
+	GetStringSliceAdditionalStake(flagSet *pflag.FlagSet) ([]string, error)
+	GetUint32Simulations(flagSet *pflag.FlagSet) (uint32, error)
 	GetIntLogFileMaxSize(flagSet *pflag.FlagSet) (int, error)
 	GetIntLogFileMaxBackups(flagSet *pflag.FlagSet) (int, error)
 	GetIntLogFileMaxAge(flagSet *pflag.FlagSet) (int, error)
@@ -153,6 +173,7 @@ type UtilsCmdInterface interface {
 	GetLogFileMaxSize() (int, error)
 	GetLogFileMaxBackups() (int, error)
 	GetLogFileMaxAge() (int, error)
+	GetSignerURL() (string, error)
 	GetConfigData() (types.Configurations, error)
 	ExecuteClaimBounty(flagSet *pflag.FlagSet)
 	ClaimBounty(rpcParameters rpc.RPCParameters, config types.Configurations, redeemBountyInput types.RedeemBountyInput) (common.Hash, error)
@@ -164,6 +185,7 @@ type UtilsCmdInterface interface {
 	AssignAmountInWei(flagSet *pflag.FlagSet) (*big.Int, error)
 	ExecuteTransfer(flagSet *pflag.FlagSet)
 	Transfer(rpcParameters rpc.RPCParameters, config types.Configurations, transferInput types.TransferInput) (common.Hash, error)
+	TransferSFuel(rpcParameters rpc.RPCParameters, config types.Configurations, transferInput types.TransferInput) (common.Hash, error)
 	CheckForLastCommitted(rpcParameters rpc.RPCParameters, staker bindings.StructsStaker, epoch uint32) error
 	Reveal(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, epoch uint32, latestHeader *Types.Header, stateBuffer uint64, commitData types.CommitData, signature []byte) (common.Hash, error)
 	GenerateTreeRevealData(merkleTree [][][]byte, commitData types.CommitData) bindings.StructsMerkleTree
@@ -175,7 +197,7 @@ type UtilsCmdInterface interface {
 	GetEpochAndState(rpcParameter rpc.RPCParameters) (uint32, int64, error)
 	WaitForAppropriateState(rpcParameter rpc.RPCParameters, action string, states ...int) (uint32, error)
 	ExecuteJobList(flagSet *pflag.FlagSet)
-	GetJobList(rpcParameters rpc.RPCParameters) error
+	GetJobList(rpcParameters rpc.RPCParameters, output string) error
 	ExecuteUnstake(flagSet *pflag.FlagSet)
 	Unstake(rpcParameters rpc.RPCParameters, config types.Configurations, input types.UnstakeInput) (common.Hash, error)
 	ApproveUnstake(rpcParameters rpc.RPCParameters, stakerTokenAddress common.Address, txnArgs types.TransactionOptions) (common.Hash, error)
@@ -189,11 +211,25 @@ type UtilsCmdInterface interface {
 	UpdateJob(rpcParameters rpc.RPCParameters, config types.Configurations, jobInput types.CreateJobInput, jobId uint16) (common.Hash, error)
 	WaitIfCommitState(rpcParameter rpc.RPCParameters, action string) (uint32, error)
 	ExecuteCollectionList(flagSet *pflag.FlagSet)
-	GetCollectionList(rpcParameters rpc.RPCParameters) error
+	GetCollectionList(rpcParameters rpc.RPCParameters, output string) error
 	ExecuteStakerinfo(flagSet *pflag.FlagSet)
 	ExecuteSetDelegation(flagSet *pflag.FlagSet)
 	SetDelegation(rpcParameters rpc.RPCParameters, config types.Configurations, delegationInput types.SetDelegationInput) (common.Hash, error)
-	GetStakerInfo(rpcParameters rpc.RPCParameters, stakerId uint32) error
+	GetStakerInfo(rpcParameters rpc.RPCParameters, stakerId uint32, output string) error
+	ExecuteHistory(flagSet *pflag.FlagSet)
+	GetStakerHistory(rpcParameters rpc.RPCParameters, stakerId uint32, fromEpoch uint32, toEpoch uint32) ([]types.EpochHistory, error)
+	ExecuteValidateAssets(flagSet *pflag.FlagSet)
+	ValidateAssets(rpcParameters rpc.RPCParameters, assetsFileData []byte, commitParams *types.CommitParams) ([]types.JobValidationResult, []string, error)
+	ExecuteWatch(flagSet *pflag.FlagSet)
+	Watch(rpcParameters rpc.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, account types.Account) error
+	AuditProposedBlocks(rpcParameters rpc.RPCParameters, epoch uint32, blockNumber *big.Int) ([]types.DisputableBlock, types.ProposeFileData, error)
+	ExecuteSupervise(flagSet *pflag.FlagSet)
+	GetSupervisedStakers(rpcParameters rpc.RPCParameters, config types.Configurations, stakersFilePath string) ([]types.SupervisedStaker, error)
+	Supervise(rpcParameters rpc.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, stakers []types.SupervisedStaker, commitParams *types.CommitParams, backupNodeActionsToIgnore []string) error
+	ExecuteReplay(flagSet *pflag.FlagSet)
+	ReplayEpoch(rpcParameters rpc.RPCParameters, epoch uint32, responses []recording.Response, commitData *types.CommitFileData, commitParams *types.CommitParams) ([]types.ReplayedCollection, error)
+	ExecuteProposerOdds(flagSet *pflag.FlagSet)
+	GetProposerOdds(rpcParameters rpc.RPCParameters, stakerId uint32, epoch uint32, additionalStakes []*big.Int, simulations uint32) (types.ProposerOdds, error)
 	ExecuteUpdateCollection(flagSet *pflag.FlagSet)
 	UpdateCollection(rpcParameters rpc.RPCParameters, config types.Configurations, collectionInput types.CreateCollectionInput, collectionId uint16) (common.Hash, error)
 	MakeBlock(rpcParameters rpc.RPCParameters, blockNumber *big.Int, epoch uint32, rogueData types.Rogue) ([]*big.Int, []uint16, *types.RevealedDataMaps, error)
@@ -237,7 +273,9 @@ type UtilsCmdInterface interface {
 	GetBountyIdFromEvents(rpcParameters rpc.RPCParameters, blockNumber *big.Int, bountyHunter string) (uint32, error)
 	HandleClaimBounty(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account) error
 	ExecuteContractAddresses(flagSet *pflag.FlagSet)
-	ContractAddresses()
+	ContractAddresses(output string) error
+	ExecuteEndpointsStatus(flagSet *pflag.FlagSet)
+	EndpointsStatus(rpcParameters rpc.RPCParameters, output string) error
 	ResetDispute(rpcParameters rpc.RPCParameters, txnOpts *bind.TransactOpts, epoch uint32)
 	StoreBountyId(rpcParameters rpc.RPCParameters, account types.Account) error
 	CheckToDoResetDispute(rpcParameters rpc.RPCParameters, txnOpts *bind.TransactOpts, epoch uint32, sortedValues []*big.Int)
@@ -248,6 +286,7 @@ type UtilsCmdInterface interface {
 
 type TransactionInterface interface {
 	Hash(txn *Types.Transaction) common.Hash
+	Transfer(client *ethclient.Client, opts *bind.TransactOpts, recipient common.Address) (*Types.Transaction, error)
 }
 
 type CryptoInterface interface {
@@ -2,10 +2,8 @@
 package cmd
 
 import (
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"os"
 	"razor/rpc"
 	"razor/utils"
 	"strconv"
@@ -18,7 +16,8 @@ var jobListCmd = &cobra.Command{
 	Long: `Provides the list of all jobs with their name, weight, power etc.
 
 Example:
-	./razor jobList`,
+	./razor jobList
+	./razor jobList --output json`,
 	Run: initialiseJobList,
 }
 
@@ -32,23 +31,46 @@ func (*UtilsStruct) ExecuteJobList(flagSet *pflag.FlagSet) {
 	_, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
 	utils.CheckError("Error in initialising command dependencies: ", err)
 
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
 	log.Debug("ExecuteJobList: Calling JobList()...")
-	err = cmdUtils.GetJobList(rpcParameters)
+	err = cmdUtils.GetJobList(rpcParameters, output)
 	utils.CheckError("Error in getting job list: ", err)
 }
 
-//This function provides the list of all jobs
-func (*UtilsStruct) GetJobList(rpcParameters rpc.RPCParameters) error {
+// jobRecord is a job in the output of jobList
+type jobRecord struct {
+	Id           uint16 `json:"id" yaml:"id"`
+	SelectorType uint8  `json:"selectorType" yaml:"selectorType"`
+	Weight       uint8  `json:"weight" yaml:"weight"`
+	Power        int8   `json:"power" yaml:"power"`
+	Name         string `json:"name" yaml:"name"`
+	Selector     string `json:"selector" yaml:"selector"`
+	Url          string `json:"url" yaml:"url"`
+}
+
+//This function provides the list of all jobs in the given output format
+func (*UtilsStruct) GetJobList(rpcParameters rpc.RPCParameters, output string) error {
 	jobs, err := razorUtils.GetJobs(rpcParameters)
 	log.Debugf("JobList: Jobs: %+v", jobs)
 	if err != nil {
 		return err
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Job Id", "Selector Type", "Weight", "Power", "Name", "Selector", "Url"})
+	records := make([]jobRecord, 0, len(jobs))
+	var rows [][]string
 	for i := 0; i < len(jobs); i++ {
-		table.Append([]string{
+		records = append(records, jobRecord{
+			Id:           jobs[i].Id,
+			SelectorType: jobs[i].SelectorType,
+			Weight:       jobs[i].Weight,
+			Power:        jobs[i].Power,
+			Name:         jobs[i].Name,
+			Selector:     jobs[i].Selector,
+			Url:          jobs[i].Url,
+		})
+		rows = append(rows, []string{
 			strconv.Itoa(int(jobs[i].Id)),
 			strconv.Itoa(int(jobs[i].SelectorType)),
 			strconv.Itoa(int(jobs[i].Weight)),
@@ -57,15 +79,18 @@ func (*UtilsStruct) GetJobList(rpcParameters rpc.RPCParameters) error {
 			jobs[i].Selector,
 			jobs[i].Url,
 		})
-
 	}
 
-	table.Render()
-	return nil
-
+	return printOutput(output, outputData{
+		Header:  []string{"Job Id", "Selector Type", "Weight", "Power", "Name", "Selector", "Url"},
+		Fields:  []string{"id", "selectorType", "weight", "power", "name", "selector", "url"},
+		Rows:    rows,
+		Records: records,
+	})
 }
 
 func init() {
 	rootCmd.AddCommand(jobListCmd)
 
+	addOutputFlag(jobListCmd)
 }
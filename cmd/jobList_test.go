@@ -65,7 +65,7 @@ func TestGetJobList(t *testing.T) {
 			utilsMock.On("GetJobs", mock.Anything, mock.Anything).Return(tt.args.jobList, tt.args.jobListErr)
 			utils := &UtilsStruct{}
 
-			err := utils.GetJobList(rpcParameters)
+			err := utils.GetJobList(rpcParameters, OutputTable)
 
 			if err == nil || tt.wantErr == nil {
 				if err != tt.wantErr {
@@ -136,7 +136,8 @@ func TestExecuteJobList(t *testing.T) {
 			fileUtilsMock.On("AssignLogFile", mock.AnythingOfType("*pflag.FlagSet"), mock.Anything)
 			cmdUtilsMock.On("GetConfigData").Return(tt.args.config, tt.args.configErr)
 			utilsMock.On("ConnectToClient", mock.AnythingOfType("string")).Return(client)
-			cmdUtilsMock.On("GetJobList", mock.Anything).Return(tt.args.jobListErr)
+			flagSetMock.On("GetStringOutput", mock.Anything).Return(OutputTable, nil)
+			cmdUtilsMock.On("GetJobList", mock.Anything, mock.Anything).Return(tt.args.jobListErr)
 
 			utils := &UtilsStruct{}
 			fatal = false
@@ -0,0 +1,88 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"razor/core/types"
+	"razor/lease"
+	"razor/path"
+	"razor/utils"
+	"time"
+)
+
+// startLeaseElector starts competing for the lease in the lease file, so that only one of the redundant vote nodes of a staker sends transactions.
+func startLeaseElector(leaseFilePath string) {
+	elector := lease.NewElector(lease.NewFileBackend(leaseFilePath), getLeaseHolderName(), lease.DefaultConfig())
+	utils.Lease = elector
+	log.Infof("Competing for lease in %s as %s", leaseFilePath, elector.Holder())
+	go elector.Run(context.Background(), func(isLeader bool) {
+		if isLeader {
+			log.Infof("Acquired the lease, %s is the leader and sends the transactions of the staker", elector.Holder())
+			return
+		}
+		log.Warnf("Lost the lease, %s is standby and won't send transactions", elector.Holder())
+	})
+}
+
+// isStandby returns whether another vote node of the staker holds the lease
+func isStandby() bool {
+	return utils.Lease != nil && !utils.Lease.IsLeader()
+}
+
+// logStandby logs which node holds the lease while this node is standby
+func logStandby() {
+	current := utils.Lease.Current()
+	if current.Holder == "" {
+		log.Info("Standby: waiting to acquire the lease")
+		return
+	}
+	log.Infof("Standby: lease is held by %s until %s", current.Holder, current.ExpiresAt.Format(time.RFC3339))
+}
+
+// reloadAfterTakeover reloads the epoch journal and the dispute data of the staker from disk once this node takes over the lease.
+// While standby, the previous leader recorded its commits, proposals and bounties on disk, which the state kept in memory doesn't include.
+func (state *stakerState) reloadAfterTakeover(address string) {
+	log.Info("Took over the lease, reloading the epoch journal and data files recorded by the previous leader")
+	if state.epochJournal != nil {
+		// The journal is reopened instead of appending to the open file, whose offset is behind the entries appended by the previous leader
+		if err := state.epochJournal.Close(); err != nil {
+			log.Error("Error in closing epoch journal: ", err)
+		}
+		epochJournal, err := openEpochJournal(address)
+		if err != nil {
+			log.Error("Error in reopening epoch journal, recovery will only use data files: ", err)
+		}
+		state.epochJournal = epochJournal
+	}
+
+	// The commit and propose data of an epoch are read again from the journal or the data files when they are needed
+	state.commitData = types.CommitFileData{}
+	state.proposedData = types.ProposeFileData{}
+
+	state.disputeData = types.DisputeFileData{}
+	disputeFilePath, err := pathUtils.GetDisputeDataFileName(address)
+	if err != nil {
+		log.Error("Error in getting dispute data file path: ", err)
+		return
+	}
+	if _, err := path.OSUtilsInterface.Stat(disputeFilePath); !errors.Is(err, os.ErrNotExist) {
+		state.disputeData, err = fileUtils.ReadFromDisputeJsonFile(disputeFilePath)
+		if err != nil {
+			log.Error("Error in reading dispute data file: ", err)
+			return
+		}
+		log.Debugf("reloadAfterTakeover: Dispute data: %+v", state.disputeData)
+	}
+}
+
+// getLeaseHolderName returns the name of this node in the lease, which is unique across hosts and processes
+func getLeaseHolderName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"razor/core/types"
+	"razor/journal"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReloadAfterTakeover(t *testing.T) {
+	var fileInfo fs.FileInfo
+	leaderCommitData := types.CommitFileData{
+		Epoch:                  8,
+		AssignedCollections:    map[int]bool{1: true},
+		SeqAllottedCollections: []*big.Int{big.NewInt(1)},
+		Leaves:                 []*big.Int{big.NewInt(100), big.NewInt(200)},
+		Commitment:             [32]byte{1, 2, 3},
+	}
+
+	tests := []struct {
+		name              string
+		disputeStatErr    error
+		disputeData       types.DisputeFileData
+		wantBountyIdQueue []uint32
+	}{
+		{
+			name:              "Test 1: When the previous leader has recorded a commit and stored a bounty id",
+			disputeData:       types.DisputeFileData{BountyIdQueue: []uint32{4, 3}},
+			wantBountyIdQueue: []uint32{4, 3},
+		},
+		{
+			name:              "Test 2: When there is no dispute data file",
+			disputeStatErr:    os.ErrNotExist,
+			wantBountyIdQueue: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			journalFilePath := filepath.Join(t.TempDir(), "journal.jsonl")
+			standbyJournal, err := journal.Open(journalFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := standbyJournal.Append(7, journal.KindCommit, types.CommitFileData{Epoch: 7}); err != nil {
+				t.Fatal(err)
+			}
+			leaderJournal, err := journal.Open(journalFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := leaderJournal.Append(leaderCommitData.Epoch, journal.KindCommit, leaderCommitData); err != nil {
+				t.Fatal(err)
+			}
+			leaderJournal.Close()
+
+			state := &stakerState{
+				epochJournal: standbyJournal,
+				commitData:   types.CommitFileData{Epoch: 7},
+				disputeData:  types.DisputeFileData{BountyIdQueue: []uint32{3}},
+			}
+
+			pathMock.On("GetEpochJournalFileName", mock.Anything).Return(journalFilePath, nil)
+			pathMock.On("GetDisputeDataFileName", mock.Anything).Return("dispute", nil)
+			osPathMock.On("Stat", "dispute").Return(fileInfo, tt.disputeStatErr)
+			fileUtilsMock.On("ReadFromDisputeJsonFile", mock.Anything).Return(tt.disputeData, nil)
+
+			state.reloadAfterTakeover("0x000000000000000000000000000000000000dead")
+			defer state.epochJournal.Close()
+
+			commitData, found := state.getCommitDataFromJournal(leaderCommitData.Epoch)
+			if !found || !reflect.DeepEqual(commitData, leaderCommitData) {
+				t.Errorf("Expected commit data recorded by the previous leader %+v, got %+v (found %v)", leaderCommitData, commitData, found)
+			}
+			if state.commitData.Epoch != 0 {
+				t.Errorf("Expected commit data kept in memory to be reset, got epoch %d", state.commitData.Epoch)
+			}
+			if !reflect.DeepEqual(state.disputeData.BountyIdQueue, tt.wantBountyIdQueue) {
+				t.Errorf("Bounty id queue = %v, want %v", state.disputeData.BountyIdQueue, tt.wantBountyIdQueue)
+			}
+			// Appending after the reload must not overwrite the entries of the previous leader
+			state.recordInEpochJournal(9, journal.KindCommit, types.CommitFileData{Epoch: 9})
+			reopened, err := journal.Open(journalFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+			if got := len(reopened.Entries()); got != 3 {
+				t.Errorf("Expected 3 entries in the journal file, got %d", got)
+			}
+		})
+	}
+}
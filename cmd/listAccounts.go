@@ -15,7 +15,8 @@ var listAccountsCmd = &cobra.Command{
 	Short: "listAccounts command can be used to list all accessible accounts",
 	Long: `If the user wants to see what all accounts are existing in the razor-go environment, they can use this command to list down all the accounts.
 Example:
-  ./razor listAccounts`,
+  ./razor listAccounts
+  ./razor listAccounts --output json`,
 	Run: initialiseListAccounts,
 }
 
@@ -24,15 +25,37 @@ func initialiseListAccounts(cmd *cobra.Command, args []string) {
 	cmdUtils.ExecuteListAccounts(cmd.Flags())
 }
 
+// accountRecord is an account in the output of listAccounts
+type accountRecord struct {
+	Address      string `json:"address" yaml:"address"`
+	KeystoreFile string `json:"keystoreFile" yaml:"keystoreFile"`
+}
+
 //This function sets the flag appropriately and executes the ListAccounts function
 func (*UtilsStruct) ExecuteListAccounts(flagSet *pflag.FlagSet) {
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
 	log.Debug("ExecuteListAccounts: Calling ListAccounts()...")
 	allAccounts, err := cmdUtils.ListAccounts()
 	utils.CheckError("ListAccounts error: ", err)
-	log.Info("ExecuteListAccounts: The available accounts are: ")
+
+	records := make([]accountRecord, 0, len(allAccounts))
+	var rows [][]string
 	for _, account := range allAccounts {
-		log.Infof("%s", account.Address.String())
+		records = append(records, accountRecord{
+			Address:      account.Address.String(),
+			KeystoreFile: account.URL.Path,
+		})
+		rows = append(rows, []string{account.Address.String(), account.URL.Path})
 	}
+	err = printOutput(output, outputData{
+		Header:  []string{"Address", "Keystore File"},
+		Fields:  []string{"address", "keystoreFile"},
+		Rows:    rows,
+		Records: records,
+	})
+	utils.CheckError("Error in printing accounts: ", err)
 }
 
 //This function is used to list all accessible accounts
@@ -51,4 +74,6 @@ func (*UtilsStruct) ListAccounts() ([]accounts.Account, error) {
 
 func init() {
 	rootCmd.AddCommand(listAccountsCmd)
+
+	addOutputFlag(listAccountsCmd)
 }
@@ -127,6 +127,7 @@ func TestExecuteListAccounts(t *testing.T) {
 			SetUpMockInterfaces()
 
 			fileUtilsMock.On("AssignLogFile", mock.AnythingOfType("*pflag.FlagSet"), mock.Anything)
+			flagSetMock.On("GetStringOutput", flagSet).Return(OutputTable, nil)
 			cmdUtilsMock.On("ListAccounts").Return(tt.args.allAccounts, tt.args.allAccountsErr)
 			cmdUtilsMock.On("GetConfigData").Return(types.Configurations{}, nil)
 
@@ -78,6 +78,78 @@ func (_m *FlagSetInterface) FetchRootFlagInput(flagName string, dataType string)
 	return r0, r1
 }
 
+// GetBoolAutoCompound provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetBoolAutoCompound(flagSet *pflag.FlagSet) (bool, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (bool, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) bool); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoolDryRun provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetBoolDryRun(flagSet *pflag.FlagSet) (bool, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (bool, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) bool); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoolRecordResponses provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetBoolRecordResponses(flagSet *pflag.FlagSet) (bool, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (bool, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) bool); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBoolRogue provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetBoolRogue(flagSet *pflag.FlagSet) (bool, error) {
 	ret := _m.Called(flagSet)
@@ -294,6 +366,30 @@ func (_m *FlagSetInterface) GetStringCertKey(flagSet *pflag.FlagSet) (string, er
 	return r0, r1
 }
 
+// GetStringCompoundReserve provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringCompoundReserve(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringExposeMetrics provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringExposeMetrics(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -342,6 +438,126 @@ func (_m *FlagSetInterface) GetStringFrom(flagSet *pflag.FlagSet) (string, error
 	return r0, r1
 }
 
+// GetStringFundingAccount provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringFundingAccount(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStringFundingPassword provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringFundingPassword(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStringLeaseFile provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringLeaseFile(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStringMaxCompoundAmount provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringMaxCompoundAmount(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStringMinSFuelBalance provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringMinSFuelBalance(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringName provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringName(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -366,6 +582,30 @@ func (_m *FlagSetInterface) GetStringName(flagSet *pflag.FlagSet) (string, error
 	return r0, r1
 }
 
+// GetStringOutput provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringOutput(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringSelector provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringSelector(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -390,6 +630,32 @@ func (_m *FlagSetInterface) GetStringSelector(flagSet *pflag.FlagSet) (string, e
 	return r0, r1
 }
 
+// GetStringSliceAdditionalStake provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringSliceAdditionalStake(flagSet *pflag.FlagSet) ([]string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) ([]string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) []string); ok {
+		r0 = rf(flagSet)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringSliceBackupNode provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringSliceBackupNode(flagSet *pflag.FlagSet) ([]string, error) {
 	ret := _m.Called(flagSet)
@@ -442,6 +708,54 @@ func (_m *FlagSetInterface) GetStringSliceRogueMode(flagSet *pflag.FlagSet) ([]s
 	return r0, r1
 }
 
+// GetStringStakerAddress provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringStakerAddress(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStringStakersFile provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringStakersFile(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringStatus provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringStatus(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -466,6 +780,30 @@ func (_m *FlagSetInterface) GetStringStatus(flagSet *pflag.FlagSet) (string, err
 	return r0, r1
 }
 
+// GetStringStatusURL provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringStatusURL(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringTo provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringTo(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -490,6 +828,30 @@ func (_m *FlagSetInterface) GetStringTo(flagSet *pflag.FlagSet) (string, error)
 	return r0, r1
 }
 
+// GetStringTopUpAmount provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetStringTopUpAmount(flagSet *pflag.FlagSet) (string, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (string, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) string); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStringUrl provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetStringUrl(flagSet *pflag.FlagSet) (string, error) {
 	ret := _m.Called(flagSet)
@@ -634,6 +996,78 @@ func (_m *FlagSetInterface) GetUint32BountyId(flagSet *pflag.FlagSet) (uint32, e
 	return r0, r1
 }
 
+// GetUint32Epoch provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetUint32Epoch(flagSet *pflag.FlagSet) (uint32, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (uint32, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) uint32); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUint32FromEpoch provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetUint32FromEpoch(flagSet *pflag.FlagSet) (uint32, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (uint32, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) uint32); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUint32Simulations provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetUint32Simulations(flagSet *pflag.FlagSet) (uint32, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (uint32, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) uint32); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUint32StakerId provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetUint32StakerId(flagSet *pflag.FlagSet) (uint32, error) {
 	ret := _m.Called(flagSet)
@@ -658,6 +1092,30 @@ func (_m *FlagSetInterface) GetUint32StakerId(flagSet *pflag.FlagSet) (uint32, e
 	return r0, r1
 }
 
+// GetUint32ToEpoch provides a mock function with given fields: flagSet
+func (_m *FlagSetInterface) GetUint32ToEpoch(flagSet *pflag.FlagSet) (uint32, error) {
+	ret := _m.Called(flagSet)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) (uint32, error)); ok {
+		return rf(flagSet)
+	}
+	if rf, ok := ret.Get(0).(func(*pflag.FlagSet) uint32); ok {
+		r0 = rf(flagSet)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pflag.FlagSet) error); ok {
+		r1 = rf(flagSet)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUint32Tolerance provides a mock function with given fields: flagSet
 func (_m *FlagSetInterface) GetUint32Tolerance(flagSet *pflag.FlagSet) (uint32, error) {
 	ret := _m.Called(flagSet)
@@ -3,7 +3,12 @@
 package mocks
 
 import (
+	bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
+
 	common "github.com/ethereum/go-ethereum/common"
+
+	ethclient "github.com/ethereum/go-ethereum/ethclient"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/ethereum/go-ethereum/core/types"
@@ -30,6 +35,32 @@ func (_m *TransactionInterface) Hash(txn *types.Transaction) common.Hash {
 	return r0
 }
 
+// Transfer provides a mock function with given fields: client, opts, recipient
+func (_m *TransactionInterface) Transfer(client *ethclient.Client, opts *bind.TransactOpts, recipient common.Address) (*types.Transaction, error) {
+	ret := _m.Called(client, opts, recipient)
+
+	var r0 *types.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*ethclient.Client, *bind.TransactOpts, common.Address) (*types.Transaction, error)); ok {
+		return rf(client, opts, recipient)
+	}
+	if rf, ok := ret.Get(0).(func(*ethclient.Client, *bind.TransactOpts, common.Address) *types.Transaction); ok {
+		r0 = rf(client, opts, recipient)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*ethclient.Client, *bind.TransactOpts, common.Address) error); ok {
+		r1 = rf(client, opts, recipient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTransactionInterface creates a new instance of TransactionInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTransactionInterface(t interface {
@@ -5,6 +5,7 @@ package mocks
 import (
 	big "math/big"
 	"razor/block"
+	recording "razor/recording"
 	RPC "razor/rpc"
 
 	accounts "github.com/ethereum/go-ethereum/accounts"
@@ -109,6 +110,39 @@ func (_m *UtilsCmdInterface) AssignAmountInWei(flagSet *pflag.FlagSet) (*big.Int
 	return r0, r1
 }
 
+// AuditProposedBlocks provides a mock function with given fields: rpcParameters, epoch, blockNumber
+func (_m *UtilsCmdInterface) AuditProposedBlocks(rpcParameters RPC.RPCParameters, epoch uint32, blockNumber *big.Int) ([]types.DisputableBlock, types.ProposeFileData, error) {
+	ret := _m.Called(rpcParameters, epoch, blockNumber)
+
+	var r0 []types.DisputableBlock
+	var r1 types.ProposeFileData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, *big.Int) ([]types.DisputableBlock, types.ProposeFileData, error)); ok {
+		return rf(rpcParameters, epoch, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, *big.Int) []types.DisputableBlock); ok {
+		r0 = rf(rpcParameters, epoch, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.DisputableBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, uint32, *big.Int) types.ProposeFileData); ok {
+		r1 = rf(rpcParameters, epoch, blockNumber)
+	} else {
+		r1 = ret.Get(1).(types.ProposeFileData)
+	}
+
+	if rf, ok := ret.Get(2).(func(RPC.RPCParameters, uint32, *big.Int) error); ok {
+		r2 = rf(rpcParameters, epoch, blockNumber)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // BatchGetStakeSnapshotCalls provides a mock function with given fields: rpcParameters, epoch, numberOfStakers
 func (_m *UtilsCmdInterface) BatchGetStakeSnapshotCalls(rpcParameters RPC.RPCParameters, epoch uint32, numberOfStakers uint32) ([]*big.Int, error) {
 	ret := _m.Called(rpcParameters, epoch, numberOfStakers)
@@ -298,9 +332,18 @@ func (_m *UtilsCmdInterface) Commit(rpcParameters RPC.RPCParameters, config type
 	return r0, r1
 }
 
-// ContractAddresses provides a mock function with given fields:
-func (_m *UtilsCmdInterface) ContractAddresses() {
-	_m.Called()
+// ContractAddresses provides a mock function with given fields: output
+func (_m *UtilsCmdInterface) ContractAddresses(output string) error {
+	ret := _m.Called(output)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(output)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // Create provides a mock function with given fields: password
@@ -419,6 +462,20 @@ func (_m *UtilsCmdInterface) Dispute(rpcParameters RPC.RPCParameters, config typ
 	return r0
 }
 
+// EndpointsStatus provides a mock function with given fields: rpcParameters, output
+func (_m *UtilsCmdInterface) EndpointsStatus(rpcParameters RPC.RPCParameters, output string) error {
+	ret := _m.Called(rpcParameters, output)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, string) error); ok {
+		r0 = rf(rpcParameters, output)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ExecuteClaimBounty provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteClaimBounty(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
@@ -454,11 +511,21 @@ func (_m *UtilsCmdInterface) ExecuteDelegate(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
 }
 
+// ExecuteEndpointsStatus provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteEndpointsStatus(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // ExecuteExtendLock provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteExtendLock(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
 }
 
+// ExecuteHistory provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteHistory(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // ExecuteImport provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteImport(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
@@ -489,6 +556,16 @@ func (_m *UtilsCmdInterface) ExecuteModifyCollectionStatus(flagSet *pflag.FlagSe
 	_m.Called(flagSet)
 }
 
+// ExecuteProposerOdds provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteProposerOdds(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
+// ExecuteReplay provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteReplay(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // ExecuteSetDelegation provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteSetDelegation(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
@@ -504,6 +581,11 @@ func (_m *UtilsCmdInterface) ExecuteStakerinfo(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
 }
 
+// ExecuteSupervise provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteSupervise(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // ExecuteTransfer provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteTransfer(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
@@ -534,11 +616,21 @@ func (_m *UtilsCmdInterface) ExecuteUpdateJob(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
 }
 
+// ExecuteValidateAssets provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteValidateAssets(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // ExecuteVote provides a mock function with given fields: flagSet
 func (_m *UtilsCmdInterface) ExecuteVote(flagSet *pflag.FlagSet) {
 	_m.Called(flagSet)
 }
 
+// ExecuteWatch provides a mock function with given fields: flagSet
+func (_m *UtilsCmdInterface) ExecuteWatch(flagSet *pflag.FlagSet) {
+	_m.Called(flagSet)
+}
+
 // GenerateTreeRevealData provides a mock function with given fields: merkleTree, commitData
 func (_m *UtilsCmdInterface) GenerateTreeRevealData(merkleTree [][][]byte, commitData types.CommitData) bindings.StructsMerkleTree {
 	ret := _m.Called(merkleTree, commitData)
@@ -650,13 +742,13 @@ func (_m *UtilsCmdInterface) GetCollectionIdPositionInBlock(rpcParameters RPC.RP
 	return r0
 }
 
-// GetCollectionList provides a mock function with given fields: rpcParameters
-func (_m *UtilsCmdInterface) GetCollectionList(rpcParameters RPC.RPCParameters) error {
-	ret := _m.Called(rpcParameters)
+// GetCollectionList provides a mock function with given fields: rpcParameters, output
+func (_m *UtilsCmdInterface) GetCollectionList(rpcParameters RPC.RPCParameters, output string) error {
+	ret := _m.Called(rpcParameters, output)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(RPC.RPCParameters) error); ok {
-		r0 = rf(rpcParameters)
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, string) error); ok {
+		r0 = rf(rpcParameters, output)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -829,13 +921,13 @@ func (_m *UtilsCmdInterface) GetIteration(rpcParameters RPC.RPCParameters, propo
 	return r0
 }
 
-// GetJobList provides a mock function with given fields: rpcParameters
-func (_m *UtilsCmdInterface) GetJobList(rpcParameters RPC.RPCParameters) error {
-	ret := _m.Called(rpcParameters)
+// GetJobList provides a mock function with given fields: rpcParameters, output
+func (_m *UtilsCmdInterface) GetJobList(rpcParameters RPC.RPCParameters, output string) error {
+	ret := _m.Called(rpcParameters, output)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(RPC.RPCParameters) error); ok {
-		r0 = rf(rpcParameters)
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, string) error); ok {
+		r0 = rf(rpcParameters, output)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -987,6 +1079,30 @@ func (_m *UtilsCmdInterface) GetMultiplier() (float32, error) {
 	return r0, r1
 }
 
+// GetProposerOdds provides a mock function with given fields: rpcParameters, stakerId, epoch, additionalStakes, simulations
+func (_m *UtilsCmdInterface) GetProposerOdds(rpcParameters RPC.RPCParameters, stakerId uint32, epoch uint32, additionalStakes []*big.Int, simulations uint32) (types.ProposerOdds, error) {
+	ret := _m.Called(rpcParameters, stakerId, epoch, additionalStakes, simulations)
+
+	var r0 types.ProposerOdds
+	var r1 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, uint32, []*big.Int, uint32) (types.ProposerOdds, error)); ok {
+		return rf(rpcParameters, stakerId, epoch, additionalStakes, simulations)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, uint32, []*big.Int, uint32) types.ProposerOdds); ok {
+		r0 = rf(rpcParameters, stakerId, epoch, additionalStakes, simulations)
+	} else {
+		r0 = ret.Get(0).(types.ProposerOdds)
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, uint32, uint32, []*big.Int, uint32) error); ok {
+		r1 = rf(rpcParameters, stakerId, epoch, additionalStakes, simulations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProvider provides a mock function with given fields:
 func (_m *UtilsCmdInterface) GetProvider() (string, error) {
 	ret := _m.Called()
@@ -1061,6 +1177,30 @@ func (_m *UtilsCmdInterface) GetSalt(rpcParameters RPC.RPCParameters, epoch uint
 	return r0, r1
 }
 
+// GetSignerURL provides a mock function with given fields: 
+func (_m *UtilsCmdInterface) GetSignerURL() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSmallestStakeAndId provides a mock function with given fields: rpcParameters, epoch
 func (_m *UtilsCmdInterface) GetSmallestStakeAndId(rpcParameters RPC.RPCParameters, epoch uint32) (*big.Int, uint32, error) {
 	ret := _m.Called(rpcParameters, epoch)
@@ -1120,13 +1260,39 @@ func (_m *UtilsCmdInterface) GetSortedRevealedValues(rpcParameters RPC.RPCParame
 	return r0, r1
 }
 
-// GetStakerInfo provides a mock function with given fields: rpcParameters, stakerId
-func (_m *UtilsCmdInterface) GetStakerInfo(rpcParameters RPC.RPCParameters, stakerId uint32) error {
-	ret := _m.Called(rpcParameters, stakerId)
+// GetStakerHistory provides a mock function with given fields: rpcParameters, stakerId, fromEpoch, toEpoch
+func (_m *UtilsCmdInterface) GetStakerHistory(rpcParameters RPC.RPCParameters, stakerId uint32, fromEpoch uint32, toEpoch uint32) ([]types.EpochHistory, error) {
+	ret := _m.Called(rpcParameters, stakerId, fromEpoch, toEpoch)
+
+	var r0 []types.EpochHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, uint32, uint32) ([]types.EpochHistory, error)); ok {
+		return rf(rpcParameters, stakerId, fromEpoch, toEpoch)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, uint32, uint32) []types.EpochHistory); ok {
+		r0 = rf(rpcParameters, stakerId, fromEpoch, toEpoch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.EpochHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, uint32, uint32, uint32) error); ok {
+		r1 = rf(rpcParameters, stakerId, fromEpoch, toEpoch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakerInfo provides a mock function with given fields: rpcParameters, stakerId, output
+func (_m *UtilsCmdInterface) GetStakerInfo(rpcParameters RPC.RPCParameters, stakerId uint32, output string) error {
+	ret := _m.Called(rpcParameters, stakerId, output)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32) error); ok {
-		r0 = rf(rpcParameters, stakerId)
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, string) error); ok {
+		r0 = rf(rpcParameters, stakerId, output)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1134,6 +1300,32 @@ func (_m *UtilsCmdInterface) GetStakerInfo(rpcParameters RPC.RPCParameters, stak
 	return r0
 }
 
+// GetSupervisedStakers provides a mock function with given fields: rpcParameters, config, stakersFilePath
+func (_m *UtilsCmdInterface) GetSupervisedStakers(rpcParameters RPC.RPCParameters, config types.Configurations, stakersFilePath string) ([]types.SupervisedStaker, error) {
+	ret := _m.Called(rpcParameters, config, stakersFilePath)
+
+	var r0 []types.SupervisedStaker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, types.Configurations, string) ([]types.SupervisedStaker, error)); ok {
+		return rf(rpcParameters, config, stakersFilePath)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, types.Configurations, string) []types.SupervisedStaker); ok {
+		r0 = rf(rpcParameters, config, stakersFilePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.SupervisedStaker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, types.Configurations, string) error); ok {
+		r1 = rf(rpcParameters, config, stakersFilePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWaitTime provides a mock function with given fields:
 func (_m *UtilsCmdInterface) GetWaitTime() (int32, error) {
 	ret := _m.Called()
@@ -1572,6 +1764,32 @@ func (_m *UtilsCmdInterface) Propose(rpcParameters RPC.RPCParameters, config typ
 	return r0
 }
 
+// ReplayEpoch provides a mock function with given fields: rpcParameters, epoch, responses, commitData, commitParams
+func (_m *UtilsCmdInterface) ReplayEpoch(rpcParameters RPC.RPCParameters, epoch uint32, responses []recording.Response, commitData *types.CommitFileData, commitParams *types.CommitParams) ([]types.ReplayedCollection, error) {
+	ret := _m.Called(rpcParameters, epoch, responses, commitData, commitParams)
+
+	var r0 []types.ReplayedCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, []recording.Response, *types.CommitFileData, *types.CommitParams) ([]types.ReplayedCollection, error)); ok {
+		return rf(rpcParameters, epoch, responses, commitData, commitParams)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, uint32, []recording.Response, *types.CommitFileData, *types.CommitParams) []types.ReplayedCollection); ok {
+		r0 = rf(rpcParameters, epoch, responses, commitData, commitParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ReplayedCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, uint32, []recording.Response, *types.CommitFileData, *types.CommitParams) error); ok {
+		r1 = rf(rpcParameters, epoch, responses, commitData, commitParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetDispute provides a mock function with given fields: rpcParameters, txnOpts, epoch
 func (_m *UtilsCmdInterface) ResetDispute(rpcParameters RPC.RPCParameters, txnOpts *bind.TransactOpts, epoch uint32) {
 	_m.Called(rpcParameters, txnOpts, epoch)
@@ -1709,6 +1927,20 @@ func (_m *UtilsCmdInterface) StoreBountyId(rpcParameters RPC.RPCParameters, acco
 	return r0
 }
 
+// Supervise provides a mock function with given fields: rpcParameters, blockMonitor, config, stakers, commitParams, backupNodeActionsToIgnore
+func (_m *UtilsCmdInterface) Supervise(rpcParameters RPC.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, stakers []types.SupervisedStaker, commitParams *types.CommitParams, backupNodeActionsToIgnore []string) error {
+	ret := _m.Called(rpcParameters, blockMonitor, config, stakers, commitParams, backupNodeActionsToIgnore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, *block.BlockMonitor, types.Configurations, []types.SupervisedStaker, *types.CommitParams, []string) error); ok {
+		r0 = rf(rpcParameters, blockMonitor, config, stakers, commitParams, backupNodeActionsToIgnore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Transfer provides a mock function with given fields: rpcParameters, config, transferInput
 func (_m *UtilsCmdInterface) Transfer(rpcParameters RPC.RPCParameters, config types.Configurations, transferInput types.TransferInput) (common.Hash, error) {
 	ret := _m.Called(rpcParameters, config, transferInput)
@@ -1735,6 +1967,30 @@ func (_m *UtilsCmdInterface) Transfer(rpcParameters RPC.RPCParameters, config ty
 	return r0, r1
 }
 
+// TransferSFuel provides a mock function with given fields: rpcParameters, config, transferInput
+func (_m *UtilsCmdInterface) TransferSFuel(rpcParameters RPC.RPCParameters, config types.Configurations, transferInput types.TransferInput) (common.Hash, error) {
+	ret := _m.Called(rpcParameters, config, transferInput)
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, types.Configurations, types.TransferInput) (common.Hash, error)); ok {
+		return rf(rpcParameters, config, transferInput)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, types.Configurations, types.TransferInput) common.Hash); ok {
+		r0 = rf(rpcParameters, config, transferInput)
+	} else {
+		r0 = ret.Get(0).(common.Hash)
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, types.Configurations, types.TransferInput) error); ok {
+		r1 = rf(rpcParameters, config, transferInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnlockWithdraw provides a mock function with given fields: rpcParameters, txnOpts, stakerId
 func (_m *UtilsCmdInterface) UnlockWithdraw(rpcParameters RPC.RPCParameters, txnOpts *bind.TransactOpts, stakerId uint32) (common.Hash, error) {
 	ret := _m.Called(rpcParameters, txnOpts, stakerId)
@@ -1853,6 +2109,41 @@ func (_m *UtilsCmdInterface) UpdateJob(rpcParameters RPC.RPCParameters, config t
 	return r0, r1
 }
 
+// ValidateAssets provides a mock function with given fields: rpcParameters, assetsFileData, commitParams
+func (_m *UtilsCmdInterface) ValidateAssets(rpcParameters RPC.RPCParameters, assetsFileData []byte, commitParams *types.CommitParams) ([]types.JobValidationResult, []string, error) {
+	ret := _m.Called(rpcParameters, assetsFileData, commitParams)
+
+	var r0 []types.JobValidationResult
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, []byte, *types.CommitParams) ([]types.JobValidationResult, []string, error)); ok {
+		return rf(rpcParameters, assetsFileData, commitParams)
+	}
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, []byte, *types.CommitParams) []types.JobValidationResult); ok {
+		r0 = rf(rpcParameters, assetsFileData, commitParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.JobValidationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(RPC.RPCParameters, []byte, *types.CommitParams) []string); ok {
+		r1 = rf(rpcParameters, assetsFileData, commitParams)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(RPC.RPCParameters, []byte, *types.CommitParams) error); ok {
+		r2 = rf(rpcParameters, assetsFileData, commitParams)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Vote provides a mock function with given fields: rpcParameters, config, account, stakerId, commitParams, rogueData, backupNodeActionsToIgnore
 func (_m *UtilsCmdInterface) Vote(rpcParameters RPC.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, account types.Account, stakerId uint32, commitParams *types.CommitParams, rogueData types.Rogue, backupNodeActionsToIgnore []string) error {
 	ret := _m.Called(rpcParameters, blockMonitor, config, account, stakerId, commitParams, rogueData, backupNodeActionsToIgnore)
@@ -1922,6 +2213,20 @@ func (_m *UtilsCmdInterface) WaitIfCommitState(rpcParameter RPC.RPCParameters, a
 	return r0, r1
 }
 
+// Watch provides a mock function with given fields: rpcParameters, blockMonitor, config, account
+func (_m *UtilsCmdInterface) Watch(rpcParameters RPC.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, account types.Account) error {
+	ret := _m.Called(rpcParameters, blockMonitor, config, account)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(RPC.RPCParameters, *block.BlockMonitor, types.Configurations, types.Account) error); ok {
+		r0 = rf(rpcParameters, blockMonitor, config, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUtilsCmdInterface creates a new instance of UtilsCmdInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUtilsCmdInterface(t interface {
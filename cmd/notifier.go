@@ -0,0 +1,26 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"net/http"
+	"razor/notifier"
+	"razor/utils"
+
+	"github.com/spf13/viper"
+)
+
+// eventNotifier sends notifications about critical events of the stakers, it is nil unless the vote or supervise command is running
+var eventNotifier *notifier.Notifier
+
+// startNotifier sets up the notification sinks configured in the notifications section of razor.yaml
+func startNotifier(httpClient *http.Client) {
+	var notifierConfig notifier.Config
+	err := viper.UnmarshalKey("notifications", &notifierConfig)
+	utils.CheckError("Error in reading notifications config: ", err)
+
+	eventNotifier, err = notifier.New(notifierConfig, httpClient)
+	utils.CheckError("Error in setting up notifications: ", err)
+	if len(notifierConfig.Sinks) > 0 {
+		log.Infof("Sending notifications of critical events to %d sink(s)", len(notifierConfig.Sinks))
+	}
+}
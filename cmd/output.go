@@ -0,0 +1,81 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Following are the output formats of the read only commands
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+	OutputYAML  = "yaml"
+)
+
+// outputData is the result of a read only command in every output format.
+// Records are marshalled as they are for json and yaml, so their fields need json and yaml tags and big integers should be decimal strings.
+// Rows are printed under Header in a table and under the field names in Fields in csv.
+type outputData struct {
+	Header  []string
+	Fields  []string
+	Rows    [][]string
+	Records interface{}
+}
+
+//This function prints the result of a read only command to stdout in the given output format
+func printOutput(output string, data outputData) error {
+	return writeOutput(os.Stdout, output, data)
+}
+
+//This function writes the result of a read only command in the given output format
+func writeOutput(writer io.Writer, output string, data outputData) error {
+	switch strings.ToLower(output) {
+	case "", OutputTable:
+		table := tablewriter.NewWriter(writer)
+		table.SetHeader(data.Header)
+		table.AppendBulk(data.Rows)
+		table.Render()
+	case OutputJSON:
+		dataInJson, err := json.MarshalIndent(data.Records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(writer, string(dataInJson))
+		return err
+	case OutputYAML:
+		dataInYaml, err := yaml.Marshal(data.Records)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(dataInYaml)
+		return err
+	case OutputCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(data.Fields); err != nil {
+			return err
+		}
+		if err := csvWriter.WriteAll(data.Rows); err != nil {
+			return err
+		}
+		return csvWriter.Error()
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats are %s, %s, %s and %s", output, OutputTable, OutputJSON, OutputCSV, OutputYAML)
+	}
+	return nil
+}
+
+//This function adds the output flag to a read only command
+func addOutputFlag(cmd *cobra.Command) {
+	var Output string
+	cmd.Flags().StringVarP(&Output, "output", "", OutputTable, "output format (table, json, csv or yaml)")
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteOutput(t *testing.T) {
+	type record struct {
+		Id    uint16 `json:"id" yaml:"id"`
+		Stake string `json:"stake" yaml:"stake"`
+	}
+	data := outputData{
+		Header:  []string{"Staker Id", "Stake"},
+		Fields:  []string{"id", "stake"},
+		Rows:    [][]string{{"1", "1000000000000000000000"}, {"2", "0"}},
+		Records: []record{{Id: 1, Stake: "1000000000000000000000"}, {Id: 2, Stake: "0"}},
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Test 1: When output format is json",
+			output: "json",
+			want: `[
+  {
+    "id": 1,
+    "stake": "1000000000000000000000"
+  },
+  {
+    "id": 2,
+    "stake": "0"
+  }
+]
+`,
+		},
+		{
+			name:   "Test 2: When output format is csv",
+			output: "csv",
+			want:   "id,stake\n1,1000000000000000000000\n2,0\n",
+		},
+		{
+			name:   "Test 3: When output format is yaml in upper case",
+			output: "YAML",
+			want:   "- id: 1\n  stake: \"1000000000000000000000\"\n- id: 2\n  stake: \"0\"\n",
+		},
+		{
+			name:    "Test 4: When output format is invalid",
+			output:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := writeOutput(&buffer, tt.output, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if buffer.String() != tt.want {
+				t.Errorf("writeOutput() got = %q, want %q", buffer.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("Test 5: When output format is table", func(t *testing.T) {
+		var buffer bytes.Buffer
+		if err := writeOutput(&buffer, OutputTable, data); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"STAKER ID", "1000000000000000000000"} {
+			if !strings.Contains(buffer.String(), want) {
+				t.Errorf("writeOutput() table %q doesn't contain %q", buffer.String(), want)
+			}
+		}
+	})
+}
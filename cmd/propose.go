@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"razor/core"
 	"razor/core/types"
+	"razor/journal"
 	"razor/pkg/bindings"
 	"razor/rpc"
 	"razor/utils"
@@ -20,8 +21,6 @@ import (
 	solsha3 "github.com/miguelmota/go-solidity-sha3"
 )
 
-var globalProposedDataStruct types.ProposeFileData
-
 // Index reveal events of staker's
 // Reveal Event would have two things, activeCollectionIndex/medianIndex and values
 // Loop
@@ -142,6 +141,9 @@ func (*UtilsStruct) Propose(rpcParameters rpc.RPCParameters, config types.Config
 		return err
 	}
 	log.Debugf("Propose: Medians: %d", medians)
+	if utils.DryRun {
+		log.Infof("Dry run: Epoch: %d Collection Ids: %v Medians: %v", epoch, ids, medians)
+	}
 	log.Debugf("Propose: Epoch: %d Medians: %d", epoch, medians)
 	log.Debugf("Propose: Iteration: %d Biggest Staker Id: %d", iteration, biggestStakerId)
 	log.Info("Proposing block...")
@@ -154,6 +156,7 @@ func (*UtilsStruct) Propose(rpcParameters rpc.RPCParameters, config types.Config
 		MethodName:      "propose",
 		Parameters:      []interface{}{epoch, ids, medians, big.NewInt(int64(iteration)), biggestStakerId},
 		Account:         account,
+		StateWindow:     &types.StateWindow{Epoch: epoch, State: 2},
 	})
 	if err != nil {
 		return err
@@ -176,14 +179,21 @@ func (*UtilsStruct) Propose(rpcParameters rpc.RPCParameters, config types.Config
 	if proposeTxn != core.NilHash {
 		// Saving proposed data after getting the transaction hash
 		log.Debug("Updating global propose data struct...")
-		updateGlobalProposedDataStruct(types.ProposeFileData{
+		state := getStakerState(rpcParameters.Ctx)
+		state.updateProposedData(types.ProposeFileData{
 			MediansData:           medians,
 			RevealedDataMaps:      revealedDataMaps,
 			RevealedCollectionIds: ids,
 			Epoch:                 epoch,
 		})
-		log.Debugf("Propose: Global propose data struct: %+v", globalProposedDataStruct)
+		log.Debugf("Propose: Global propose data struct: %+v", state.proposedData)
 
+		log.Debug("Recording proposed data in epoch journal...")
+		state.recordInEpochJournal(epoch, journal.KindPropose, state.proposedData)
+
+		if utils.DryRun {
+			return nil
+		}
 		log.Debug("Saving proposed data for recovery...")
 		fileName, err := pathUtils.GetProposeDataFileName(account.Address)
 		if err != nil {
@@ -191,7 +201,7 @@ func (*UtilsStruct) Propose(rpcParameters rpc.RPCParameters, config types.Config
 			return err
 		}
 		log.Debug("Propose: Propose data file path: ", fileName)
-		err = fileUtils.SaveDataToProposeJsonFile(fileName, globalProposedDataStruct)
+		err = fileUtils.SaveDataToProposeJsonFile(fileName, state.proposedData)
 		if err != nil {
 			log.Errorf("Error in saving data to file %s: %v", fileName, err)
 			return err
@@ -514,11 +524,3 @@ func (*UtilsStruct) BatchGetStakeSnapshotCalls(rpcParameters rpc.RPCParameters,
 
 	return stakeArray, nil
 }
-
-func updateGlobalProposedDataStruct(proposedData types.ProposeFileData) types.ProposeFileData {
-	globalProposedDataStruct.MediansData = proposedData.MediansData
-	globalProposedDataStruct.RevealedDataMaps = proposedData.RevealedDataMaps
-	globalProposedDataStruct.RevealedCollectionIds = proposedData.RevealedCollectionIds
-	globalProposedDataStruct.Epoch = proposedData.Epoch
-	return globalProposedDataStruct
-}
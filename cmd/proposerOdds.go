@@ -0,0 +1,472 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"razor/core"
+	"razor/core/types"
+	"razor/rpc"
+	"razor/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	solsha3 "github.com/miguelmota/go-solidity-sha3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var proposerOddsCmd = &cobra.Command{
+	Use:   "proposerOdds",
+	Short: "forecast the proposer election of a staker",
+	Long: `Computes the iteration at which a staker is elected as a proposer in the current epoch and its rank against the iterations of all the other stakers,
+the staker with the lowest iteration proposes the block that gets confirmed.
+The salt of a future epoch depends on the block confirmed in the epoch before it, so for a future epoch only the odds are estimated.
+
+The odds of proposing the block that gets confirmed and the odds of proposing one of the maxAltBlocks blocks with the lowest iterations, which the contract accepts,
+are estimated by simulating epochs with random salts, for the current stake of the staker and for the stake after adding each of the given amounts of RZR.
+The stake snapshots of the current epoch are used once the staker has committed in it. Otherwise the current stakes of the stakers which committed in the last epochs are used,
+which is an approximation as the stakes and the active stakers can change until the snapshots are taken.
+
+Example:
+  ./razor proposerOdds --stakerId 2
+  ./razor proposerOdds --stakerId 2 --additionalStake 10000,50000,100000 --simulations 5000 --output json`,
+	Run: initialiseProposerOdds,
+}
+
+//This function initialises the ExecuteProposerOdds function
+func initialiseProposerOdds(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteProposerOdds(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the GetProposerOdds function
+func (*UtilsStruct) ExecuteProposerOdds(flagSet *pflag.FlagSet) {
+	_, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	stakerId, err := flagSetUtils.GetUint32StakerId(flagSet)
+	utils.CheckError("Error in getting stakerId: ", err)
+	log.Debug("ExecuteProposerOdds: StakerId: ", stakerId)
+
+	epoch, err := flagSetUtils.GetUint32Epoch(flagSet)
+	utils.CheckError("Error in getting epoch: ", err)
+
+	additionalStakeFlag, err := flagSetUtils.GetStringSliceAdditionalStake(flagSet)
+	utils.CheckError("Error in getting additional stake: ", err)
+	additionalStakes, err := parseAdditionalStakes(additionalStakeFlag)
+	utils.CheckError("Invalid additional stake: ", err)
+
+	simulations, err := flagSetUtils.GetUint32Simulations(flagSet)
+	utils.CheckError("Error in getting number of simulations: ", err)
+	if simulations == 0 {
+		simulations = core.ProposerOddsDefaultSimulations
+	}
+
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
+	log.Debugf("ExecuteProposerOdds: Calling GetProposerOdds() with arguments stakerId = %d, epoch = %d, additionalStakes = %s, simulations = %d", stakerId, epoch, additionalStakes, simulations)
+	proposerOdds, err := cmdUtils.GetProposerOdds(rpcParameters, stakerId, epoch, additionalStakes, simulations)
+	utils.CheckError("Error in getting proposer odds: ", err)
+
+	err = printProposerOdds(proposerOdds, output)
+	utils.CheckError("Error in printing proposer odds: ", err)
+}
+
+//This function converts the additional amounts of RZR to wei, amounts of zero are skipped as the current stake is always simulated
+func parseAdditionalStakes(amounts []string) ([]*big.Int, error) {
+	var additionalStakes []*big.Int
+	for _, amount := range amounts {
+		amountInWei, err := parseAmountInWei(strings.TrimSpace(amount), "RZR")
+		if err != nil {
+			return nil, err
+		}
+		if amountInWei.Sign() > 0 {
+			additionalStakes = append(additionalStakes, amountInWei)
+		}
+	}
+	return additionalStakes, nil
+}
+
+/*
+GetProposerOdds forecasts the proposer election of the staker in the current or a future epoch.
+In the current epoch the iteration of the staker is computed with the salt of the epoch, along with the stakers elected at lower iterations.
+For the current stake of the staker and the stake after adding each of the additional stakes, the probability of having the lowest iteration,
+which proposes the confirmed block, and the probability of being among the maxAltBlocks elected stakers with the lowest iterations, whose blocks are accepted,
+are estimated over the given number of random salts. If no additional stakes are given, half, once and twice the current stake are added.
+*/
+func (*UtilsStruct) GetProposerOdds(rpcParameters rpc.RPCParameters, stakerId uint32, epoch uint32, additionalStakes []*big.Int, simulations uint32) (types.ProposerOdds, error) {
+	currentEpoch, err := razorUtils.GetEpoch(rpcParameters)
+	if err != nil {
+		return types.ProposerOdds{}, err
+	}
+	if epoch == 0 {
+		epoch = currentEpoch
+	}
+	if epoch < currentEpoch {
+		return types.ProposerOdds{}, fmt.Errorf("epoch %d has passed, the current epoch is %d", epoch, currentEpoch)
+	}
+
+	numberOfStakers, err := razorUtils.GetNumberOfStakers(rpcParameters)
+	if err != nil {
+		return types.ProposerOdds{}, err
+	}
+	log.Debug("GetProposerOdds: Number of stakers: ", numberOfStakers)
+	if stakerId == 0 || stakerId > numberOfStakers {
+		return types.ProposerOdds{}, fmt.Errorf("staker %d doesn't exist, number of stakers is %d", stakerId, numberOfStakers)
+	}
+
+	stakes, isSnapshot, err := getProposerOddsStakes(rpcParameters, stakerId, epoch, currentEpoch, numberOfStakers)
+	if err != nil {
+		return types.ProposerOdds{}, err
+	}
+	maxAltBlocks, err := razorUtils.GetMaxAltBlocks(rpcParameters)
+	if err != nil {
+		return types.ProposerOdds{}, err
+	}
+	log.Debug("GetProposerOdds: Number of maximum alternative blocks: ", maxAltBlocks)
+	stake := stakes[stakerId-1]
+	if stake.Sign() == 0 {
+		return types.ProposerOdds{}, fmt.Errorf("staker %d has no stake", stakerId)
+	}
+	biggestStake := getBiggestStake(stakes)
+	log.Debugf("GetProposerOdds: Stake: %s, Biggest stake: %s", stake, biggestStake)
+
+	proposerOdds := types.ProposerOdds{
+		Epoch:           epoch,
+		StakerId:        stakerId,
+		Stake:           stake,
+		BiggestStake:    biggestStake,
+		NumberOfStakers: numberOfStakers,
+		IsSnapshot:      isSnapshot,
+		Iteration:       -1,
+		Simulations:     simulations,
+		MaxAltBlocks:    maxAltBlocks,
+	}
+
+	if epoch == currentEpoch {
+		salt, err := cmdUtils.GetSalt(rpcParameters, epoch)
+		if err != nil {
+			return types.ProposerOdds{}, err
+		}
+		log.Debugf("GetProposerOdds: Salt: %x", salt)
+		proposerOdds.IsSaltKnown = true
+		proposerOdds.Iteration, proposerOdds.StakersAhead = getProposerRank(stakes, biggestStake, stakerId, salt)
+		log.Debugf("GetProposerOdds: Iteration: %d, Stakers elected at lower iterations: %v", proposerOdds.Iteration, proposerOdds.StakersAhead)
+	} else {
+		log.Infof("The salt of epoch %d depends on the block confirmed in epoch %d, estimating only the odds of staker %d", epoch, epoch-1, stakerId)
+	}
+
+	if len(additionalStakes) == 0 {
+		additionalStakes = []*big.Int{
+			new(big.Int).Div(stake, big.NewInt(2)),
+			new(big.Int).Set(stake),
+			new(big.Int).Mul(stake, big.NewInt(2)),
+		}
+	}
+	salts := getRandomSalts(simulations, rand.New(rand.NewSource(time.Now().UnixNano())))
+	epochsPerDay := float64(24*60*60) / float64(core.EpochLength)
+	for _, additionalStake := range append([]*big.Int{big.NewInt(0)}, additionalStakes...) {
+		simulatedStake := new(big.Int).Add(stake, additionalStake)
+		probability, proposalProbability := getProposerProbabilities(stakes, biggestStake, stakerId, simulatedStake, salts, int(maxAltBlocks))
+		log.Debugf("GetProposerOdds: Probability of proposing the confirmed block with stake %s: %f, of proposing a block: %f", simulatedStake, probability, proposalProbability)
+		proposerOdds.StakeOdds = append(proposerOdds.StakeOdds, types.ProposerStakeOdds{
+			AdditionalStake:       additionalStake,
+			Stake:                 simulatedStake,
+			Probability:           probability,
+			ConfirmedBlocksPerDay: probability * epochsPerDay,
+			ProposalProbability:   proposalProbability,
+			ProposalsPerDay:       proposalProbability * epochsPerDay,
+		})
+	}
+	return proposerOdds, nil
+}
+
+//This function returns the stakes of all the stakers indexed by staker id - 1 and whether they are the stake snapshots of the epoch.
+//The snapshot of a staker is taken when it commits, so the current stakes are used in a future epoch or before the staker has committed.
+//The current stakes are an approximation: only the stakers which committed in the last ProposerOddsActiveEpochs epochs are counted, as the
+//others would have no snapshot, and stakes can change until the snapshots are taken.
+func getProposerOddsStakes(rpcParameters rpc.RPCParameters, stakerId uint32, epoch uint32, currentEpoch uint32, numberOfStakers uint32) ([]*big.Int, bool, error) {
+	if epoch == currentEpoch {
+		stakeSnapshots, err := cmdUtils.BatchGetStakeSnapshotCalls(rpcParameters, epoch, numberOfStakers)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(stakeSnapshots) == int(numberOfStakers) && stakeSnapshots[stakerId-1].Sign() > 0 {
+			return stakeSnapshots, true, nil
+		}
+		log.Infof("Stake snapshot of staker %d isn't taken in epoch %d yet, using the current stakes of all the stakers", stakerId, epoch)
+	}
+
+	stakes := make([]*big.Int, numberOfStakers)
+	for id := uint32(1); id <= numberOfStakers; id++ {
+		staker, err := razorUtils.GetStaker(rpcParameters, id)
+		if err != nil {
+			return nil, false, err
+		}
+		stakes[id-1] = big.NewInt(0)
+		if staker.IsSlashed || staker.Stake == nil {
+			continue
+		}
+		if id != stakerId {
+			epochLastCommitted, err := razorUtils.GetEpochLastCommitted(rpcParameters, id)
+			if err != nil {
+				return nil, false, err
+			}
+			if epochLastCommitted+core.ProposerOddsActiveEpochs < currentEpoch {
+				log.Debugf("getProposerOddsStakes: Staker %d last committed in epoch %d, not counting it as active", id, epochLastCommitted)
+				continue
+			}
+		}
+		stakes[id-1] = staker.Stake
+	}
+	return stakes, false, nil
+}
+
+//This function returns the biggest of the stakes
+func getBiggestStake(stakes []*big.Int) *big.Int {
+	biggestStake := big.NewInt(0)
+	for _, stake := range stakes {
+		if stake.Cmp(biggestStake) > 0 {
+			biggestStake = stake
+		}
+	}
+	return biggestStake
+}
+
+/*
+getProposerRank returns the lowest iteration at which the staker is elected with the salt, along with the stakers elected at lower iterations in the order of their iterations.
+Every iteration picks a single staker, so the stakers ahead are known once the iteration of the staker is reached. If the staker isn't elected within MaxIterations, -1 is returned.
+*/
+func getProposerRank(stakes []*big.Int, biggestStake *big.Int, stakerId uint32, salt [32]byte) (int, []uint32) {
+	scaledStakes := getScaledStakes(stakes)
+	numberOfStakers := uint32(len(stakes))
+	isElected := make(map[uint32]bool)
+	var stakersAhead []uint32
+	for iteration := 0; iteration < core.MaxIterations; iteration++ {
+		candidate := getIterationCandidate(iteration, numberOfStakers, salt)
+		if isElected[candidate] || stakes[candidate-1].Sign() == 0 {
+			continue
+		}
+		if !isCandidateElected(iteration, candidate, scaledStakes, biggestStake, salt) {
+			continue
+		}
+		if candidate == stakerId {
+			return iteration, stakersAhead
+		}
+		isElected[candidate] = true
+		stakersAhead = append(stakersAhead, candidate)
+	}
+	return -1, stakersAhead
+}
+
+/*
+getProposerProbabilities returns the fraction of the salts for which the staker with the given stake is elected at a lower iteration than every other staker,
+so that it proposes the block that gets confirmed, and the fraction for which it is among the maxAltBlocks stakers elected at the lowest iterations,
+so that the block it proposes is accepted if every elected staker proposes.
+*/
+func getProposerProbabilities(stakes []*big.Int, biggestStake *big.Int, stakerId uint32, stake *big.Int, salts [][32]byte, maxAltBlocks int) (float64, float64) {
+	// A staker with no stake is never elected
+	if len(salts) == 0 || stake.Sign() == 0 {
+		return 0, 0
+	}
+	// The block with the lowest iteration is always accepted
+	if maxAltBlocks < 1 {
+		maxAltBlocks = 1
+	}
+	simulatedStakes := make([]*big.Int, len(stakes))
+	copy(simulatedStakes, stakes)
+	simulatedStakes[stakerId-1] = stake
+	simulatedBiggestStake := biggestStake
+	if stake.Cmp(simulatedBiggestStake) > 0 {
+		simulatedBiggestStake = stake
+	}
+	scaledStakes := getScaledStakes(simulatedStakes)
+	numberOfStakers := uint32(len(stakes))
+
+	wins, proposals := 0, 0
+	for _, salt := range salts {
+		isElected := make(map[uint32]bool)
+		for iteration := 0; iteration < core.MaxIterations && len(isElected) < maxAltBlocks; iteration++ {
+			candidate := getIterationCandidate(iteration, numberOfStakers, salt)
+			if isElected[candidate] || simulatedStakes[candidate-1].Sign() == 0 || !isCandidateElected(iteration, candidate, scaledStakes, simulatedBiggestStake, salt) {
+				continue
+			}
+			if candidate == stakerId {
+				if len(isElected) == 0 {
+					wins++
+				}
+				proposals++
+				break
+			}
+			isElected[candidate] = true
+		}
+	}
+	return float64(wins) / float64(len(salts)), float64(proposals) / float64(len(salts))
+}
+
+//This function returns the stakes multiplied by 2^32 as they are compared in IsElectedProposer
+func getScaledStakes(stakes []*big.Int) []*big.Int {
+	scaledStakes := make([]*big.Int, len(stakes))
+	for i, stake := range stakes {
+		scaledStakes[i] = big.NewInt(1).Mul(stake, big.NewInt(int64(math.Exp2(32))))
+	}
+	return scaledStakes
+}
+
+//This function returns the id of the staker picked by the pseudo random number generator in the iteration
+func getIterationCandidate(iteration int, numberOfStakers uint32, salt [32]byte) uint32 {
+	seed := solsha3.SoliditySHA3([]string{"uint256"}, []interface{}{big.NewInt(int64(iteration))})
+	//add +1 since prng returns 0 to max-1 and staker start from 1
+	return uint32(pseudoRandomNumberGenerator(seed, numberOfStakers, salt[:]).Uint64()) + 1
+}
+
+//This function returns whether the staker picked in the iteration is elected with its stake
+func isCandidateElected(iteration int, candidate uint32, scaledStakes []*big.Int, biggestStake *big.Int, salt [32]byte) bool {
+	proposer := types.ElectedProposer{
+		Iteration:       iteration,
+		StakerId:        candidate,
+		BiggestStake:    biggestStake,
+		NumberOfStakers: uint32(len(scaledStakes)),
+		Salt:            salt,
+	}
+	return cmdUtils.IsElectedProposer(proposer, scaledStakes[candidate-1])
+}
+
+//This function returns the given number of random salts
+func getRandomSalts(count uint32, random *rand.Rand) [][32]byte {
+	salts := make([][32]byte, count)
+	for i := range salts {
+		random.Read(salts[i][:])
+	}
+	return salts
+}
+
+// proposerOddsRecord is the output of proposerOdds
+type proposerOddsRecord struct {
+	Epoch           uint32                    `json:"epoch" yaml:"epoch"`
+	StakerId        uint32                    `json:"stakerId" yaml:"stakerId"`
+	Stake           string                    `json:"stake" yaml:"stake"`
+	BiggestStake    string                    `json:"biggestStake" yaml:"biggestStake"`
+	NumberOfStakers uint32                    `json:"numberOfStakers" yaml:"numberOfStakers"`
+	StakeSnapshot   bool                      `json:"stakeSnapshot" yaml:"stakeSnapshot"`
+	Iteration       *int                      `json:"iteration,omitempty" yaml:"iteration,omitempty"`
+	Rank            *int                      `json:"rank,omitempty" yaml:"rank,omitempty"`
+	StakersAhead    []uint32                  `json:"stakersAhead,omitempty" yaml:"stakersAhead,omitempty"`
+	Simulations     uint32                    `json:"simulations" yaml:"simulations"`
+	MaxAltBlocks    uint8                     `json:"maxAltBlocks" yaml:"maxAltBlocks"`
+	Odds            []proposerStakeOddsRecord `json:"odds" yaml:"odds"`
+}
+
+// proposerStakeOddsRecord is the estimated odds of a stake in the output of proposerOdds
+type proposerStakeOddsRecord struct {
+	AdditionalStake       string  `json:"additionalStake" yaml:"additionalStake"`
+	Stake                 string  `json:"stake" yaml:"stake"`
+	Probability           float64 `json:"probability" yaml:"probability"`
+	ConfirmedBlocksPerDay float64 `json:"confirmedBlocksPerDay" yaml:"confirmedBlocksPerDay"`
+	ProposalProbability   float64 `json:"proposalProbability" yaml:"proposalProbability"`
+	ProposalsPerDay       float64 `json:"proposalsPerDay" yaml:"proposalsPerDay"`
+}
+
+//This function prints the proposer odds in the given output format, the forecast of the epoch and the odds of every stake are printed one after the other in a table or csv
+func printProposerOdds(proposerOdds types.ProposerOdds, output string) error {
+	record := proposerOddsRecord{
+		Epoch:           proposerOdds.Epoch,
+		StakerId:        proposerOdds.StakerId,
+		Stake:           bigIntString(proposerOdds.Stake),
+		BiggestStake:    bigIntString(proposerOdds.BiggestStake),
+		NumberOfStakers: proposerOdds.NumberOfStakers,
+		StakeSnapshot:   proposerOdds.IsSnapshot,
+		StakersAhead:    proposerOdds.StakersAhead,
+		Simulations:     proposerOdds.Simulations,
+		MaxAltBlocks:    proposerOdds.MaxAltBlocks,
+		Odds:            make([]proposerStakeOddsRecord, 0, len(proposerOdds.StakeOdds)),
+	}
+	iteration, rank := "unknown salt", "unknown salt"
+	if proposerOdds.IsSaltKnown {
+		iteration, rank = "not elected", "not elected"
+		if proposerOdds.Iteration >= 0 {
+			stakerRank := len(proposerOdds.StakersAhead) + 1
+			record.Iteration = &proposerOdds.Iteration
+			record.Rank = &stakerRank
+			iteration, rank = strconv.Itoa(proposerOdds.Iteration), strconv.Itoa(stakerRank)
+		}
+	}
+
+	var oddsRows [][]string
+	for _, stakeOdds := range proposerOdds.StakeOdds {
+		record.Odds = append(record.Odds, proposerStakeOddsRecord{
+			AdditionalStake:       bigIntString(stakeOdds.AdditionalStake),
+			Stake:                 bigIntString(stakeOdds.Stake),
+			Probability:           stakeOdds.Probability,
+			ConfirmedBlocksPerDay: stakeOdds.ConfirmedBlocksPerDay,
+			ProposalProbability:   stakeOdds.ProposalProbability,
+			ProposalsPerDay:       stakeOdds.ProposalsPerDay,
+		})
+		oddsRows = append(oddsRows, []string{
+			utils.GetAmountInDecimal(stakeOdds.AdditionalStake).Text('f', 2),
+			utils.GetAmountInDecimal(stakeOdds.Stake).Text('f', 2),
+			strconv.FormatFloat(stakeOdds.Probability*100, 'f', 2, 64) + "%",
+			strconv.FormatFloat(stakeOdds.ConfirmedBlocksPerDay, 'f', 2, 64),
+			strconv.FormatFloat(stakeOdds.ProposalProbability*100, 'f', 2, 64) + "%",
+			strconv.FormatFloat(stakeOdds.ProposalsPerDay, 'f', 2, 64),
+		})
+	}
+
+	switch strings.ToLower(output) {
+	case OutputJSON, OutputYAML:
+		return printOutput(output, outputData{Records: record})
+	}
+
+	stakersAhead := make([]string, 0, len(proposerOdds.StakersAhead))
+	for _, stakerId := range proposerOdds.StakersAhead {
+		stakersAhead = append(stakersAhead, strconv.Itoa(int(stakerId)))
+	}
+	err := printOutput(output, outputData{
+		Header: []string{"Epoch", "Staker Id", "Stake (RZR)", "Biggest Stake (RZR)", "Stakers", "Stake Snapshot", "Max Alt Blocks", "Iteration", "Rank", "Stakers Ahead"},
+		Fields: []string{"epoch", "stakerId", "stake", "biggestStake", "numberOfStakers", "stakeSnapshot", "maxAltBlocks", "iteration", "rank", "stakersAhead"},
+		Rows: [][]string{{
+			strconv.Itoa(int(proposerOdds.Epoch)),
+			strconv.Itoa(int(proposerOdds.StakerId)),
+			utils.GetAmountInDecimal(proposerOdds.Stake).Text('f', 2),
+			utils.GetAmountInDecimal(proposerOdds.BiggestStake).Text('f', 2),
+			strconv.Itoa(int(proposerOdds.NumberOfStakers)),
+			strconv.FormatBool(proposerOdds.IsSnapshot),
+			strconv.Itoa(int(proposerOdds.MaxAltBlocks)),
+			iteration,
+			rank,
+			strings.Join(stakersAhead, " "),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return printOutput(output, outputData{
+		Header: []string{"Additional Stake (RZR)", "Stake (RZR)", fmt.Sprintf("Confirmed Block Odds (%d Epochs)", proposerOdds.Simulations), "Confirmed Blocks Per Day", "Proposal Odds", "Proposals Per Day"},
+		Fields: []string{"additionalStake", "stake", "probability", "confirmedBlocksPerDay", "proposalProbability", "proposalsPerDay"},
+		Rows:   oddsRows,
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(proposerOddsCmd)
+
+	var (
+		StakerId        uint32
+		Epoch           uint32
+		AdditionalStake []string
+		Simulations     uint32
+	)
+
+	proposerOddsCmd.Flags().Uint32VarP(&StakerId, "stakerId", "", 0, "staker id")
+	proposerOddsCmd.Flags().Uint32VarP(&Epoch, "epoch", "", 0, "current or future epoch, defaults to the current epoch")
+	proposerOddsCmd.Flags().StringSliceVarP(&AdditionalStake, "additionalStake", "", []string{}, "amounts of RZR added to the stake of the staker to estimate the odds with")
+	proposerOddsCmd.Flags().Uint32VarP(&Simulations, "simulations", "", core.ProposerOddsDefaultSimulations, "number of epochs with random salts simulated to estimate the odds")
+	addOutputFlag(proposerOddsCmd)
+
+	stakerIdErr := proposerOddsCmd.MarkFlagRequired("stakerId")
+	utils.CheckError("StakerId error: ", stakerIdErr)
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/rpc"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+//This function returns the lowest iteration at which the staker is elected by checking every iteration as GetIteration does
+func getIterationByElection(stakes []*big.Int, biggestStake *big.Int, stakerId uint32, salt [32]byte) int {
+	ut := &UtilsStruct{}
+	currentStakerStake := big.NewInt(1).Mul(stakes[stakerId-1], big.NewInt(1<<32))
+	for iteration := 0; ; iteration++ {
+		proposer := types.ElectedProposer{Iteration: iteration, StakerId: stakerId, BiggestStake: biggestStake, NumberOfStakers: uint32(len(stakes)), Salt: salt}
+		if ut.IsElectedProposer(proposer, currentStakerStake) {
+			return iteration
+		}
+	}
+}
+
+func TestGetProposerOdds(t *testing.T) {
+	stakes := []*big.Int{
+		big.NewInt(1).Mul(big.NewInt(5000), big.NewInt(1e18)),
+		big.NewInt(1).Mul(big.NewInt(20000), big.NewInt(1e18)),
+		big.NewInt(1).Mul(big.NewInt(1000), big.NewInt(1e18)),
+		big.NewInt(1).Mul(big.NewInt(8000), big.NewInt(1e18)),
+	}
+	salt := [32]byte{1, 2, 3}
+	zeroSnapshots := []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+
+	type args struct {
+		stakerId         uint32
+		epoch            uint32
+		stakeSnapshots   []*big.Int
+		additionalStakes []*big.Int
+		stakerErr        error
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantSaltKnown bool
+		wantSnapshot  bool
+		wantStakeOdds int
+		wantErr       bool
+	}{
+		{
+			name: "Test 1: When the iteration and rank are computed from the stake snapshots of the current epoch",
+			args: args{
+				stakerId:       1,
+				stakeSnapshots: stakes,
+			},
+			wantSaltKnown: true,
+			wantSnapshot:  true,
+			wantStakeOdds: 4,
+		},
+		{
+			name: "Test 2: When the staker hasn't committed in the current epoch and the current stakes are used",
+			args: args{
+				stakerId:         3,
+				epoch:            100,
+				stakeSnapshots:   zeroSnapshots,
+				additionalStakes: []*big.Int{big.NewInt(1e18)},
+			},
+			wantSaltKnown: true,
+			wantStakeOdds: 2,
+		},
+		{
+			name: "Test 3: When only the odds are estimated for a future epoch",
+			args: args{
+				stakerId: 4,
+				epoch:    105,
+			},
+			wantStakeOdds: 4,
+		},
+		{
+			name: "Test 4: When the epoch has passed",
+			args: args{
+				stakerId: 1,
+				epoch:    99,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 5: When the staker doesn't exist",
+			args: args{
+				stakerId: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 6: When there is an error in getting a staker",
+			args: args{
+				stakerId:  1,
+				epoch:     105,
+				stakerErr: errors.New("staker error"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetEpoch", mock.Anything).Return(uint32(100), nil)
+			utilsMock.On("GetNumberOfStakers", mock.Anything).Return(uint32(len(stakes)), nil)
+			utilsMock.On("GetStaker", mock.Anything, mock.AnythingOfType("uint32")).Return(func(_ rpc.RPCParameters, stakerId uint32) bindings.StructsStaker {
+				return bindings.StructsStaker{Id: stakerId, Stake: stakes[stakerId-1]}
+			}, tt.args.stakerErr)
+			utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.AnythingOfType("uint32")).Return(uint32(99), nil)
+			utilsMock.On("GetMaxAltBlocks", mock.Anything).Return(uint8(2), nil)
+			cmdUtilsMock.On("BatchGetStakeSnapshotCalls", mock.Anything, uint32(100), uint32(len(stakes))).Return(tt.args.stakeSnapshots, nil)
+			cmdUtilsMock.On("GetSalt", mock.Anything, uint32(100)).Return(salt, nil)
+			cmdUtilsMock.On("IsElectedProposer", mock.Anything, mock.Anything).Return(func(proposer types.ElectedProposer, currentStakerStake *big.Int) bool {
+				return (&UtilsStruct{}).IsElectedProposer(proposer, currentStakerStake)
+			})
+
+			ut := &UtilsStruct{}
+			got, err := ut.GetProposerOdds(rpcParameters, tt.args.stakerId, tt.args.epoch, tt.args.additionalStakes, 50)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetProposerOdds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.IsSaltKnown != tt.wantSaltKnown || got.IsSnapshot != tt.wantSnapshot {
+				t.Errorf("GetProposerOdds() salt known = %v, snapshot = %v, want %v, %v", got.IsSaltKnown, got.IsSnapshot, tt.wantSaltKnown, tt.wantSnapshot)
+			}
+			if got.Stake.Cmp(stakes[tt.args.stakerId-1]) != 0 || got.BiggestStake.Cmp(stakes[1]) != 0 {
+				t.Errorf("GetProposerOdds() stake = %s, biggest stake = %s, want %s, %s", got.Stake, got.BiggestStake, stakes[tt.args.stakerId-1], stakes[1])
+			}
+
+			if tt.wantSaltKnown {
+				iterations := make(map[uint32]int)
+				for stakerId := uint32(1); stakerId <= uint32(len(stakes)); stakerId++ {
+					iterations[stakerId] = getIterationByElection(stakes, stakes[1], stakerId, salt)
+				}
+				var wantStakersAhead []uint32
+				for stakerId, iteration := range iterations {
+					if iteration < iterations[tt.args.stakerId] {
+						wantStakersAhead = append(wantStakersAhead, stakerId)
+					}
+				}
+				sort.Slice(wantStakersAhead, func(i, j int) bool {
+					return iterations[wantStakersAhead[i]] < iterations[wantStakersAhead[j]]
+				})
+				if got.Iteration != iterations[tt.args.stakerId] || !reflect.DeepEqual(got.StakersAhead, wantStakersAhead) {
+					t.Errorf("GetProposerOdds() iteration = %d, stakers ahead = %v, want %d, %v", got.Iteration, got.StakersAhead, iterations[tt.args.stakerId], wantStakersAhead)
+				}
+			} else if got.Iteration != -1 {
+				t.Errorf("GetProposerOdds() iteration = %d for unknown salt, want -1", got.Iteration)
+			}
+
+			if len(got.StakeOdds) != tt.wantStakeOdds {
+				t.Fatalf("GetProposerOdds() estimated odds of %d stakes, want %d", len(got.StakeOdds), tt.wantStakeOdds)
+			}
+			if got.StakeOdds[0].AdditionalStake.Sign() != 0 || got.StakeOdds[0].Stake.Cmp(got.Stake) != 0 {
+				t.Errorf("GetProposerOdds() first odds = %+v, want the odds of the current stake", got.StakeOdds[0])
+			}
+			for _, stakeOdds := range got.StakeOdds {
+				if stakeOdds.ProposalProbability < stakeOdds.Probability {
+					t.Errorf("GetProposerOdds() proposal probability %f is lower than the probability %f of proposing the confirmed block", stakeOdds.ProposalProbability, stakeOdds.Probability)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProposerOddsStakes(t *testing.T) {
+	stakes := []*big.Int{big.NewInt(3000), big.NewInt(12000), big.NewInt(500), big.NewInt(7000)}
+	lastCommittedEpochs := []uint32{99, 97, 90, 98}
+
+	SetUpMockInterfaces()
+	utilsMock.On("GetStaker", mock.Anything, mock.AnythingOfType("uint32")).Return(func(_ rpc.RPCParameters, stakerId uint32) bindings.StructsStaker {
+		return bindings.StructsStaker{Id: stakerId, Stake: stakes[stakerId-1], IsSlashed: stakerId == 4}
+	}, nil)
+	utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.AnythingOfType("uint32")).Return(func(_ rpc.RPCParameters, stakerId uint32) uint32 {
+		return lastCommittedEpochs[stakerId-1]
+	}, nil)
+
+	// Staker 1 and staker 3 itself are counted, staker 2 hasn't committed in the last epochs and staker 4 is slashed
+	got, isSnapshot, err := getProposerOddsStakes(rpcParameters, 3, 105, 100, uint32(len(stakes)))
+	if err != nil {
+		t.Fatalf("getProposerOddsStakes() error = %v", err)
+	}
+	want := []*big.Int{big.NewInt(3000), big.NewInt(0), big.NewInt(500), big.NewInt(0)}
+	if isSnapshot || !reflect.DeepEqual(got, want) {
+		t.Errorf("getProposerOddsStakes() = %v, snapshot = %v, want %v, false", got, isSnapshot, want)
+	}
+}
+
+func TestGetProposerProbabilities(t *testing.T) {
+	SetUpMockInterfaces()
+	cmdUtils = &UtilsStruct{}
+
+	stakes := []*big.Int{big.NewInt(3000), big.NewInt(12000), big.NewInt(500), big.NewInt(0), big.NewInt(7000)}
+	biggestStake := getBiggestStake(stakes)
+	salts := getRandomSalts(200, rand.New(rand.NewSource(1)))
+
+	// Some staker is always the proposer with the lowest iteration, a staker with no stake never is.
+	// As many stakers as blocks accepted propose an accepted block, and every staker with stake does if all of them are accepted.
+	var sum, proposalSum float64
+	for stakerId := uint32(1); stakerId <= uint32(len(stakes)); stakerId++ {
+		probability, proposalProbability := getProposerProbabilities(stakes, biggestStake, stakerId, stakes[stakerId-1], salts, 2)
+		if stakes[stakerId-1].Sign() == 0 && (probability != 0 || proposalProbability != 0) {
+			t.Errorf("getProposerProbabilities() of staker %d with no stake = %f, %f, want 0", stakerId, probability, proposalProbability)
+		}
+		if proposalProbability < probability {
+			t.Errorf("getProposerProbabilities() of staker %d proposal probability %f is lower than %f", stakerId, proposalProbability, probability)
+		}
+		sum += probability
+		proposalSum += proposalProbability
+
+		if stakes[stakerId-1].Sign() > 0 {
+			_, allAcceptedProbability := getProposerProbabilities(stakes, biggestStake, stakerId, stakes[stakerId-1], salts, len(stakes))
+			if allAcceptedProbability != 1 {
+				t.Errorf("getProposerProbabilities() of staker %d when every block is accepted = %f, want 1", stakerId, allAcceptedProbability)
+			}
+		}
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("Sum of getProposerProbabilities() of all the stakers = %f, want 1", sum)
+	}
+	if proposalSum < 1.999 || proposalSum > 2.001 {
+		t.Errorf("Sum of proposal probabilities of all the stakers = %f, want 2", proposalSum)
+	}
+
+	// With the same salts, adding stake never lowers the probability
+	previous := -1.0
+	for _, stake := range []int64{500, 1000, 6000, 12000, 50000} {
+		probability, _ := getProposerProbabilities(stakes, biggestStake, 3, big.NewInt(stake), salts, 2)
+		if probability < previous {
+			t.Errorf("getProposerProbabilities() with stake %d = %f, lower than %f with a smaller stake", stake, probability, previous)
+		}
+		previous = probability
+	}
+	if previous == 0 {
+		t.Error("getProposerProbabilities() with the biggest stake by far = 0, want a positive probability")
+	}
+}
+
+func TestParseAdditionalStakes(t *testing.T) {
+	got, err := parseAdditionalStakes([]string{"1000", " 0.5", "0"})
+	if err != nil {
+		t.Fatalf("parseAdditionalStakes() error = %v", err)
+	}
+	want := []*big.Int{big.NewInt(1).Mul(big.NewInt(1000), big.NewInt(1e18)), big.NewInt(5e17)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAdditionalStakes() = %v, want %v", got, want)
+	}
+	if _, err := parseAdditionalStakes([]string{"-1"}); err == nil {
+		t.Error("parseAdditionalStakes() of a negative amount, expected an error")
+	}
+}
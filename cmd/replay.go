@@ -0,0 +1,314 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"razor/cache"
+	"razor/core/types"
+	"razor/journal"
+	"razor/recording"
+	"razor/rpc"
+	"razor/utils"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "explain the values committed in an epoch from the recorded API responses",
+	Long: `Recomputes the leaves of an epoch from the job responses recorded by vote with --recordResponses and shows how the value of every collection was derived from the response of each of its jobs.
+The recorded responses are parsed with the current job and collection definitions and assets.json.
+If the address of a staker is given, the collections assigned to it are replayed and compared with the leaves committed in its epoch journal, otherwise every active collection with recorded responses is replayed.
+
+Example:
+  ./razor replay --epoch 1200
+  ./razor replay --epoch 1200 --stakerAddress 0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c --output json`,
+	Run: initialiseReplay,
+}
+
+//This function initialises the ExecuteReplay function
+func initialiseReplay(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteReplay(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the ReplayEpoch function
+func (*UtilsStruct) ExecuteReplay(flagSet *pflag.FlagSet) {
+	config, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	epoch, err := flagSetUtils.GetUint32Epoch(flagSet)
+	utils.CheckError("Error in getting epoch: ", err)
+	log.Debug("ExecuteReplay: Epoch: ", epoch)
+
+	stakerAddress, err := flagSetUtils.GetStringStakerAddress(flagSet)
+	utils.CheckError("Error in getting staker address: ", err)
+	log.Debug("ExecuteReplay: Staker address: ", stakerAddress)
+
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
+	recordingFilePath, err := pathUtils.GetRecordingFileName(epoch)
+	utils.CheckError("Error in getting recording file path: ", err)
+	responses, err := recording.Load(recordingFilePath)
+	utils.CheckError("Error in loading recorded responses: ", err)
+
+	var commitData *types.CommitFileData
+	if stakerAddress != "" {
+		var committedAt time.Time
+		commitData, committedAt, err = getCommittedDataFromJournal(stakerAddress, epoch)
+		utils.CheckError("Error in getting committed data: ", err)
+		// The data sources may be fetched again after the commit, the committed values were computed from the responses recorded before it
+		responses = recording.RecordedBefore(responses, committedAt)
+	}
+
+	jobsCache, collectionsCache, initCacheBlockNumber, err := cmdUtils.InitJobAndCollectionCache(rpcParameters)
+	utils.CheckError("Error in initializing asset cache: ", err)
+
+	commitParams := &types.CommitParams{
+		LocalCache:                cache.NewLocalCache(),
+		JobsCache:                 jobsCache,
+		CollectionsCache:          collectionsCache,
+		HttpClient:                &http.Client{Timeout: time.Duration(config.HTTPTimeout) * time.Second},
+		FromBlockToCheckForEvents: initCacheBlockNumber,
+	}
+
+	log.Debugf("ExecuteReplay: Calling ReplayEpoch() with arguments epoch = %d, number of recorded responses = %d", epoch, len(responses))
+	replayedCollections, err := cmdUtils.ReplayEpoch(rpcParameters, epoch, responses, commitData, commitParams)
+	utils.CheckError("Error in replaying epoch: ", err)
+
+	err = printReplay(replayedCollections, output)
+	utils.CheckError("Error in printing replay: ", err)
+}
+
+//This function returns the recorder of the responses of the job data sources if they are to be recorded and nil otherwise
+func getResponseRecorder(flagSet *pflag.FlagSet) (*recording.Recorder, error) {
+	recordResponses, err := flagSetUtils.GetBoolRecordResponses(flagSet)
+	if err != nil || !recordResponses {
+		return nil, err
+	}
+	log.Info("Recording the responses of the job data sources of every epoch, replay an epoch with the replay command")
+	return recording.NewRecorder(pathUtils.GetRecordingFileName), nil
+}
+
+//This function returns the commit data of the epoch from the epoch journal of the staker along with the time it was committed at
+func getCommittedDataFromJournal(address string, epoch uint32) (*types.CommitFileData, time.Time, error) {
+	journalFilePath, err := pathUtils.GetEpochJournalFileName(address)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !dataFileExists(journalFilePath) {
+		return nil, time.Time{}, fmt.Errorf("epoch journal of %s doesn't exist", address)
+	}
+	openedJournal, err := journal.Open(journalFilePath)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer openedJournal.Close()
+
+	state := &stakerState{epochJournal: openedJournal}
+	commitData, found := state.getCommitDataFromJournal(epoch)
+	if !found {
+		return nil, time.Time{}, fmt.Errorf("no commit of epoch %d in epoch journal of %s", epoch, address)
+	}
+	commitEntry, _ := openedJournal.LatestForEpoch(journal.KindCommit, epoch)
+	return &commitData, commitEntry.Timestamp, nil
+}
+
+/*
+ReplayEpoch recomputes the leaves of the collections from the responses recorded in the epoch, as HandleCommitState computed them.
+If the commit data is given, only the assigned collections are replayed and compared with the committed leaves,
+otherwise every active collection with a recorded response is replayed.
+*/
+func (*UtilsStruct) ReplayEpoch(rpcParameters rpc.RPCParameters, epoch uint32, responses []recording.Response, commitData *types.CommitFileData, commitParams *types.CommitParams) ([]types.ReplayedCollection, error) {
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no responses recorded in epoch %d", epoch)
+	}
+	replay := recording.NewReplay(responses)
+	utils.ResponseReplay = replay
+	defer func() {
+		utils.ResponseReplay = nil
+	}()
+
+	numActiveCollections, err := razorUtils.GetNumActiveCollections(rpcParameters)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("ReplayEpoch: Number of active collections: ", numActiveCollections)
+
+	var replayedCollections []types.ReplayedCollection
+	for i := 0; i < int(numActiveCollections); i++ {
+		if commitData != nil && !commitData.AssignedCollections[i] {
+			continue
+		}
+		collectionId, err := razorUtils.GetCollectionIdFromIndex(rpcParameters, uint16(i))
+		if err != nil {
+			log.Error("Error in getting collection ID: ", err)
+			return nil, err
+		}
+		replayedCollection := types.ReplayedCollection{
+			LeafIndex:    i,
+			CollectionId: collectionId,
+		}
+		if collection, isPresent := commitParams.CollectionsCache.GetCollection(collectionId); isPresent {
+			replayedCollection.CollectionName = collection.Name
+		}
+
+		// Collections are replayed one after the other, so that the replayed values belong to this collection
+		leaf, err := razorUtils.GetAggregatedDataOfCollection(rpcParameters, collectionId, epoch, commitParams)
+		replayedCollection.Jobs = getReplayedJobs(replay.TakeValues())
+		if commitData == nil && !isAnyJobRecorded(replayedCollection.Jobs) {
+			log.Debugf("ReplayEpoch: No responses recorded for collection %d, skipping", collectionId)
+			continue
+		}
+		if err != nil {
+			log.Errorf("Error in replaying collection %d: %v", collectionId, err)
+			replayedCollection.Error = err.Error()
+		} else {
+			replayedCollection.ReplayedLeaf = leaf
+		}
+		if commitData != nil && i < len(commitData.Leaves) {
+			replayedCollection.CommittedLeaf = commitData.Leaves[i]
+		}
+		replayedCollections = append(replayedCollections, replayedCollection)
+	}
+	return replayedCollections, nil
+}
+
+//This function returns the replayed values of the jobs of a collection sorted by job id and URL
+func getReplayedJobs(values []recording.ReplayedValue) []types.ReplayedJob {
+	jobs := make([]types.ReplayedJob, 0, len(values))
+	for _, value := range values {
+		jobs = append(jobs, types.ReplayedJob{
+			JobId:         value.Response.JobId,
+			JobName:       value.Response.JobName,
+			URL:           value.Response.URL,
+			BodyHash:      value.Response.BodyHash,
+			Timestamp:     value.Response.Timestamp,
+			RecordedValue: value.Response.Value,
+			ReplayedValue: value.Value,
+			Error:         value.Error,
+		})
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].JobId != jobs[j].JobId {
+			return jobs[i].JobId < jobs[j].JobId
+		}
+		return jobs[i].URL < jobs[j].URL
+	})
+	return jobs
+}
+
+//This function returns whether the response of any of the jobs was recorded, recorded responses always have a body hash
+func isAnyJobRecorded(jobs []types.ReplayedJob) bool {
+	for _, job := range jobs {
+		if job.BodyHash != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// replayedJobRecord is a job of a collection in the output of replay
+type replayedJobRecord struct {
+	JobId         uint16 `json:"jobId" yaml:"jobId"`
+	JobName       string `json:"jobName" yaml:"jobName"`
+	URL           string `json:"url" yaml:"url"`
+	BodyHash      string `json:"bodyHash" yaml:"bodyHash"`
+	Timestamp     string `json:"timestamp" yaml:"timestamp"`
+	RecordedValue string `json:"recordedValue" yaml:"recordedValue"`
+	ReplayedValue string `json:"replayedValue" yaml:"replayedValue"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// replayedCollectionRecord is a collection in the output of replay
+type replayedCollectionRecord struct {
+	LeafIndex      int                 `json:"leafIndex" yaml:"leafIndex"`
+	CollectionId   uint16              `json:"collectionId" yaml:"collectionId"`
+	CollectionName string              `json:"collectionName" yaml:"collectionName"`
+	ReplayedLeaf   string              `json:"replayedLeaf" yaml:"replayedLeaf"`
+	CommittedLeaf  string              `json:"committedLeaf,omitempty" yaml:"committedLeaf,omitempty"`
+	Error          string              `json:"error,omitempty" yaml:"error,omitempty"`
+	Jobs           []replayedJobRecord `json:"jobs" yaml:"jobs"`
+}
+
+//This function prints the replayed collections in the given output format, a row is printed for every job and then for the leaf of the collection
+func printReplay(replayedCollections []types.ReplayedCollection, output string) error {
+	records := make([]replayedCollectionRecord, 0, len(replayedCollections))
+	var rows [][]string
+	for _, replayedCollection := range replayedCollections {
+		leafIndex := strconv.Itoa(replayedCollection.LeafIndex)
+		collection := fmt.Sprintf("%d %s", replayedCollection.CollectionId, replayedCollection.CollectionName)
+		record := replayedCollectionRecord{
+			LeafIndex:      replayedCollection.LeafIndex,
+			CollectionId:   replayedCollection.CollectionId,
+			CollectionName: replayedCollection.CollectionName,
+			ReplayedLeaf:   bigIntString(replayedCollection.ReplayedLeaf),
+			CommittedLeaf:  bigIntString(replayedCollection.CommittedLeaf),
+			Error:          replayedCollection.Error,
+			Jobs:           make([]replayedJobRecord, 0, len(replayedCollection.Jobs)),
+		}
+		for _, job := range replayedCollection.Jobs {
+			var timestamp string
+			if !job.Timestamp.IsZero() {
+				timestamp = job.Timestamp.UTC().Format(time.RFC3339)
+			}
+			record.Jobs = append(record.Jobs, replayedJobRecord{
+				JobId:         job.JobId,
+				JobName:       job.JobName,
+				URL:           job.URL,
+				BodyHash:      job.BodyHash,
+				Timestamp:     timestamp,
+				RecordedValue: job.RecordedValue,
+				ReplayedValue: job.ReplayedValue,
+				Error:         job.Error,
+			})
+			rows = append(rows, []string{leafIndex, collection, fmt.Sprintf("%d %s", job.JobId, job.JobName), job.URL, job.BodyHash, job.RecordedValue, valueOrError(job.ReplayedValue, job.Error)})
+		}
+		rows = append(rows, []string{leafIndex, collection, "leaf", "", "", record.CommittedLeaf, valueOrError(record.ReplayedLeaf, record.Error)})
+		records = append(records, record)
+	}
+	return printOutput(output, outputData{
+		Header:  []string{"Leaf Index", "Collection", "Job", "URL", "Body Hash", "Recorded / Committed", "Replayed"},
+		Fields:  []string{"leafIndex", "collection", "job", "url", "bodyHash", "recorded", "replayed"},
+		Rows:    rows,
+		Records: records,
+	})
+}
+
+//This function returns the decimal string of a big integer, or an empty string if it is nil
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+//This function returns the error if there is one and the value otherwise
+func valueOrError(value string, err string) string {
+	if err != "" {
+		return "error: " + err
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	var (
+		Epoch         uint32
+		StakerAddress string
+	)
+
+	replayCmd.Flags().Uint32VarP(&Epoch, "epoch", "", 0, "epoch to replay")
+	replayCmd.Flags().StringVarP(&StakerAddress, "stakerAddress", "", "", "address of the staker whose committed leaves are compared with the replayed leaves")
+	addOutputFlag(replayCmd)
+
+	epochErr := replayCmd.MarkFlagRequired("epoch")
+	utils.CheckError("Epoch error: ", epochErr)
+}
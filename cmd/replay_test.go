@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"math/big"
+	"razor/cache"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/recording"
+	"razor/rpc"
+	"razor/utils"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReplayEpoch(t *testing.T) {
+	responses := []recording.Response{
+		{JobId: 1, JobName: "ethusd_gemini", URL: "https://api.gemini.com/v1/pubticker/ethusd", Selector: "last", Body: `{"last":"2000"}`, BodyHash: "hash1", Value: "200000"},
+		{JobId: 2, JobName: "btcusd_gemini", URL: "https://api.gemini.com/v1/pubticker/btcusd", Selector: "last", Body: `{"last":"60000"}`, BodyHash: "hash2", Value: "6000000"},
+	}
+	// Collection 1 at index 0 has job 1 and collection 3 at index 2 has job 2, no response of collection 2 is recorded
+	collectionResponses := map[uint16]*recording.Response{1: &responses[0], 2: nil, 3: &responses[1]}
+
+	collectionsCache := cache.NewCollectionsCache()
+	collectionsCache.UpdateCollection(1, bindings.StructsCollection{Id: 1, Name: "ethCollection"})
+	collectionsCache.UpdateCollection(3, bindings.StructsCollection{Id: 3, Name: "btcCollection"})
+
+	type args struct {
+		responses        []recording.Response
+		commitData       *types.CommitFileData
+		aggregatedErr    error
+		numCollectionErr error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.ReplayedCollection
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When every active collection with recorded responses is replayed",
+			args: args{
+				responses: responses,
+			},
+			want: []types.ReplayedCollection{
+				{LeafIndex: 0, CollectionId: 1, CollectionName: "ethCollection", ReplayedLeaf: big.NewInt(200000), Jobs: []types.ReplayedJob{
+					{JobId: 1, JobName: "ethusd_gemini", URL: "https://api.gemini.com/v1/pubticker/ethusd", BodyHash: "hash1", RecordedValue: "200000", ReplayedValue: "200000"},
+				}},
+				{LeafIndex: 2, CollectionId: 3, CollectionName: "btcCollection", ReplayedLeaf: big.NewInt(6000000), Jobs: []types.ReplayedJob{
+					{JobId: 2, JobName: "btcusd_gemini", URL: "https://api.gemini.com/v1/pubticker/btcusd", BodyHash: "hash2", RecordedValue: "6000000", ReplayedValue: "6000000"},
+				}},
+			},
+		},
+		{
+			name: "Test 2: When the assigned collections are replayed and compared with the committed leaves",
+			args: args{
+				responses: responses,
+				commitData: &types.CommitFileData{
+					AssignedCollections: map[int]bool{2: true},
+					Leaves:              []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(5900000)},
+				},
+			},
+			want: []types.ReplayedCollection{
+				{LeafIndex: 2, CollectionId: 3, CollectionName: "btcCollection", ReplayedLeaf: big.NewInt(6000000), CommittedLeaf: big.NewInt(5900000), Jobs: []types.ReplayedJob{
+					{JobId: 2, JobName: "btcusd_gemini", URL: "https://api.gemini.com/v1/pubticker/btcusd", BodyHash: "hash2", RecordedValue: "6000000", ReplayedValue: "6000000"},
+				}},
+			},
+		},
+		{
+			name: "Test 3: When aggregating a collection fails",
+			args: args{
+				responses: responses,
+				commitData: &types.CommitFileData{
+					AssignedCollections: map[int]bool{0: true},
+					Leaves:              []*big.Int{big.NewInt(200000)},
+				},
+				aggregatedErr: errors.New("no jobs present in the collection"),
+			},
+			want: []types.ReplayedCollection{
+				{LeafIndex: 0, CollectionId: 1, CollectionName: "ethCollection", CommittedLeaf: big.NewInt(200000), Error: "no jobs present in the collection", Jobs: []types.ReplayedJob{
+					{JobId: 1, JobName: "ethusd_gemini", URL: "https://api.gemini.com/v1/pubticker/ethusd", BodyHash: "hash1", RecordedValue: "200000", ReplayedValue: "200000"},
+				}},
+			},
+		},
+		{
+			name:    "Test 4: When no responses are recorded in the epoch",
+			wantErr: true,
+		},
+		{
+			name: "Test 5: When there is an error in getting the number of active collections",
+			args: args{
+				responses:        responses,
+				numCollectionErr: errors.New("numActiveCollections error"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetNumActiveCollections", mock.Anything).Return(uint16(3), tt.args.numCollectionErr)
+			utilsMock.On("GetCollectionIdFromIndex", mock.Anything, mock.AnythingOfType("uint16")).Return(func(_ rpc.RPCParameters, index uint16) uint16 {
+				return index + 1
+			}, nil)
+			// The aggregation replays the recorded response of the job of the collection
+			utilsMock.On("GetAggregatedDataOfCollection", mock.Anything, mock.AnythingOfType("uint16"), mock.Anything, mock.Anything).Return(func(_ rpc.RPCParameters, collectionId uint16, _ uint32, _ *types.CommitParams) (*big.Int, error) {
+				response := collectionResponses[collectionId]
+				if response == nil {
+					return nil, errors.New("no jobs present in the collection")
+				}
+				utils.ResponseReplay.Observe(*response, response.Value, nil)
+				if tt.args.aggregatedErr != nil {
+					return nil, tt.args.aggregatedErr
+				}
+				value, _ := new(big.Int).SetString(response.Value, 10)
+				return value, nil
+			})
+
+			ut := &UtilsStruct{}
+			got, err := ut.ReplayEpoch(rpcParameters, 100, tt.args.responses, tt.args.commitData, &types.CommitParams{CollectionsCache: collectionsCache})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplayEpoch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplayEpoch() = %+v, want %+v", got, tt.want)
+			}
+			if utils.ResponseReplay != nil {
+				t.Error("ReplayEpoch() didn't reset the replay")
+			}
+		})
+	}
+}
@@ -63,6 +63,7 @@ func (*UtilsStruct) Reveal(rpcParameters rpc.RPCParameters, config types.Configu
 		MethodName:      "reveal",
 		Parameters:      []interface{}{epoch, treeRevealData, signature},
 		Account:         account,
+		StateWindow:     &types.StateWindow{Epoch: epoch, State: 1},
 	})
 	if err != nil {
 		log.Error(err)
@@ -8,6 +8,7 @@ import (
 	"razor/logger"
 	"razor/path"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -27,6 +28,7 @@ var (
 	LogFileMaxSize     int
 	LogFileMaxBackups  int
 	LogFileMaxAge      int
+	SignerURL          string
 )
 
 var log = logger.GetLogger()
@@ -49,7 +51,12 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// The commands exiting with a fatal log don't return here, the entries still being shipped to the log sinks are flushed before they exit
+	logrus.RegisterExitHandler(logger.Close)
+	err := rootCmd.Execute()
+	// Flushes the entries which are still being shipped to the log sinks
+	logger.Close()
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -73,6 +80,7 @@ func init() {
 	rootCmd.PersistentFlags().IntVarP(&LogFileMaxSize, "logFileMaxSize", "", 0, "max size of log file MB")
 	rootCmd.PersistentFlags().IntVarP(&LogFileMaxBackups, "logFileMaxBackups", "", 0, "max number of old log files to retain")
 	rootCmd.PersistentFlags().IntVarP(&LogFileMaxAge, "logFileMaxAge", "", 0, "max number of days to retain old log files")
+	rootCmd.PersistentFlags().StringVarP(&SignerURL, "signerUrl", "", "", "URL of remote signer to sign transactions with")
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
@@ -46,6 +46,7 @@ func (*UtilsStruct) SetConfig(flagSet *pflag.FlagSet) error {
 		{Name: "logFileMaxSize", Type: "int"},
 		{Name: "logFileMaxBackups", Type: "int"},
 		{Name: "logFileMaxAge", Type: "int"},
+		{Name: "signerUrl", Type: "string"},
 	}
 
 	// Storing the fetched flag values in a map
@@ -73,6 +74,7 @@ func (*UtilsStruct) SetConfig(flagSet *pflag.FlagSet) error {
 		{FlagName: "logFileMaxSize", Key: "logFileMaxSize", DefaultValue: core.DefaultLogFileMaxSize},
 		{FlagName: "logFileMaxBackups", Key: "logFileMaxBackups", DefaultValue: core.DefaultLogFileMaxBackups},
 		{FlagName: "logFileMaxAge", Key: "logFileMaxAge", DefaultValue: core.DefaultLogFileMaxAge},
+		{FlagName: "signerUrl", Key: "signerUrl", DefaultValue: core.DefaultSignerURL},
 	}
 
 	var areConfigSet bool
@@ -165,6 +167,7 @@ func init() {
 		LogFileMaxSize     int
 		LogFileMaxBackups  int
 		LogFileMaxAge      int
+		SignerURL          string
 	)
 	setConfig.Flags().StringVarP(&Provider, "provider", "p", "", "provider name")
 	setConfig.Flags().Float32VarP(&GasMultiplier, "gasmultiplier", "g", -1, "gas multiplier value")
@@ -182,5 +185,6 @@ func init() {
 	setConfig.Flags().IntVarP(&LogFileMaxSize, "logFileMaxSize", "", 0, "max size of log file in MB")
 	setConfig.Flags().IntVarP(&LogFileMaxBackups, "logFileMaxBackups", "", 0, "max number of old log files to retain")
 	setConfig.Flags().IntVarP(&LogFileMaxAge, "logFileMaxAge", "", 0, "max number of days to retain old log files")
+	setConfig.Flags().StringVarP(&SignerURL, "signerUrl", "", "", "URL of remote signer to sign transactions with")
 
 }
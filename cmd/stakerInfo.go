@@ -2,10 +2,8 @@
 package cmd
 
 import (
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"os"
 	"razor/rpc"
 	"razor/utils"
 	"strconv"
@@ -17,7 +15,8 @@ var stakerInfoCmd = &cobra.Command{
 	Long: `Provides the staker details like age, stake, maturity etc.
 
 Example:
-  ./razor stakerInfo --stakerId 2`,
+  ./razor stakerInfo --stakerId 2
+  ./razor stakerInfo --stakerId 2 --output yaml`,
 	Run: initialiseStakerInfo,
 }
 
@@ -35,14 +34,27 @@ func (*UtilsStruct) ExecuteStakerinfo(flagSet *pflag.FlagSet) {
 	utils.CheckError("Error in getting stakerId: ", err)
 	log.Debug("ExecuteStakerinfo: StakerId: ", stakerId)
 
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
 	log.Debug("ExecuteStakerinfo: Calling GetStakerInfo() with argument stakerId = ", stakerId)
-	err = cmdUtils.GetStakerInfo(rpcParameters, stakerId)
+	err = cmdUtils.GetStakerInfo(rpcParameters, stakerId, output)
 	utils.CheckError("Error in getting staker info: ", err)
 
 }
 
-//This function provides the staker details like age, stake, maturity etc.
-func (*UtilsStruct) GetStakerInfo(rpcParameters rpc.RPCParameters, stakerId uint32) error {
+// stakerInfoRecord is the output of stakerInfo
+type stakerInfoRecord struct {
+	Id        uint32 `json:"id" yaml:"id"`
+	Address   string `json:"address" yaml:"address"`
+	Stake     string `json:"stake" yaml:"stake"`
+	Age       uint32 `json:"age" yaml:"age"`
+	Maturity  uint16 `json:"maturity" yaml:"maturity"`
+	Influence string `json:"influence" yaml:"influence"`
+}
+
+//This function provides the staker details like age, stake, maturity etc. in the given output format
+func (*UtilsStruct) GetStakerInfo(rpcParameters rpc.RPCParameters, stakerId uint32, output string) error {
 	stakerInfo, err := razorUtils.StakerInfo(rpcParameters, stakerId)
 	if err != nil {
 		return err
@@ -59,18 +71,27 @@ func (*UtilsStruct) GetStakerInfo(rpcParameters rpc.RPCParameters, stakerId uint
 	if err != nil {
 		return err
 	}
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Staker Id", "Staker Address", "Stake", "Age", "Maturity", "Influence"})
-	table.Append([]string{
-		strconv.Itoa(int(stakerInfo.Id)),
-		stakerInfo.Address.String(),
-		stakerInfo.Stake.String(),
-		strconv.Itoa(int(stakerInfo.Age)),
-		strconv.Itoa(int(maturity)),
-		influence.String(),
+	record := stakerInfoRecord{
+		Id:        stakerInfo.Id,
+		Address:   stakerInfo.Address.String(),
+		Stake:     stakerInfo.Stake.String(),
+		Age:       stakerInfo.Age,
+		Maturity:  maturity,
+		Influence: influence.String(),
+	}
+	return printOutput(output, outputData{
+		Header: []string{"Staker Id", "Staker Address", "Stake", "Age", "Maturity", "Influence"},
+		Fields: []string{"id", "address", "stake", "age", "maturity", "influence"},
+		Rows: [][]string{{
+			strconv.Itoa(int(record.Id)),
+			record.Address,
+			record.Stake,
+			strconv.Itoa(int(record.Age)),
+			strconv.Itoa(int(record.Maturity)),
+			record.Influence,
+		}},
+		Records: record,
 	})
-	table.Render()
-	return nil
 }
 
 func init() {
@@ -81,4 +102,5 @@ func init() {
 	)
 
 	stakerInfoCmd.Flags().Uint32VarP(&StakerId, "stakerId", "", 0, "staker id")
+	addOutputFlag(stakerInfoCmd)
 }
@@ -212,7 +212,7 @@ func TestUtilsStruct_GetStakerInfo(t *testing.T) {
 			utilsMock.On("GetInfluenceSnapshot", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.influence, tt.args.influenceErr)
 			utilsMock.On("GetEpoch", mock.Anything).Return(tt.args.epoch, tt.args.epochErr)
 			utils := &UtilsStruct{}
-			err := utils.GetStakerInfo(rpcParameters, tt.args.stakerId)
+			err := utils.GetStakerInfo(rpcParameters, tt.args.stakerId, OutputTable)
 			if err == nil || tt.wantErr == nil {
 				if err != tt.wantErr {
 					t.Errorf("Error for StakerInfo function, got = %v, want %v", err, tt.wantErr)
@@ -302,7 +302,8 @@ func TestUtilsStruct_ExecuteStakerinfo(t *testing.T) {
 			cmdUtilsMock.On("GetConfigData").Return(tt.args.config, tt.args.configErr)
 			utilsMock.On("ConnectToClient", mock.AnythingOfType("string")).Return(client)
 			flagSetMock.On("GetUint32StakerId", flagSet).Return(tt.args.stakerId, tt.args.stakerIdErr)
-			cmdUtilsMock.On("GetStakerInfo", mock.Anything, mock.Anything).Return(tt.args.stakerInfoErr)
+			flagSetMock.On("GetStringOutput", flagSet).Return(OutputTable, nil)
+			cmdUtilsMock.On("GetStakerInfo", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.stakerInfoErr)
 
 			utils := &UtilsStruct{}
 			fatal = false
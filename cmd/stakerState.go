@@ -0,0 +1,79 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"context"
+	"razor/core/types"
+	"razor/journal"
+	"razor/metrics"
+)
+
+// stakerState is the voting state which a staker keeps in memory between blocks.
+// Every staker run by the supervisor has its own state, which is carried in the context of its RPC parameters.
+type stakerState struct {
+	commitData          types.CommitFileData
+	proposedData        types.ProposeFileData
+	lastVerification    uint32
+	blockConfirmed      uint32
+	disputeData         types.DisputeFileData
+	lastRPCRefreshEpoch uint32
+	giveSortedLeafIds   []int
+	lastTopUpEpoch      uint32
+	lastCompoundEpoch   uint32
+	// lastDisputeCheck is the last epoch in which the block proposed by the staker was checked for a dispute
+	lastDisputeCheck uint32
+	// epochJournal records the data used and produced by the staker in every epoch.
+	// It is nil if the journal couldn't be opened, in which case recovery falls back to the data files.
+	epochJournal *journal.Journal
+	// status is the status of the staker. It is nil for the staker of a vote process, whose status is served at the status endpoint.
+	status *metrics.StatusStore
+	// isSlashed is set once the staker is found to be slashed, after which it stops voting
+	isSlashed bool
+}
+
+// defaultStakerState is the state of the staker of a vote process, it is used when the context doesn't carry a state
+var defaultStakerState = &stakerState{}
+
+type stakerStateKey struct{}
+
+// withStakerState returns a copy of the context which carries the given staker state
+func withStakerState(ctx context.Context, state *stakerState) context.Context {
+	return context.WithValue(ctx, stakerStateKey{}, state)
+}
+
+// getStakerState returns the staker state carried by the context and the default staker state if there is none
+func getStakerState(ctx context.Context) *stakerState {
+	if ctx != nil {
+		if state, ok := ctx.Value(stakerStateKey{}).(*stakerState); ok {
+			return state
+		}
+	}
+	return defaultStakerState
+}
+
+// statusStore returns the store which keeps the status of the staker
+func (state *stakerState) statusStore() *metrics.StatusStore {
+	if state.status == nil {
+		return metrics.NodeStatusStore
+	}
+	return state.status
+}
+
+// updateCommitData replaces the committed data kept in memory with the data committed in the given epoch
+func (state *stakerState) updateCommitData(commitData types.CommitData, commitment [32]byte, epoch uint32) types.CommitFileData {
+	state.commitData.Leaves = commitData.Leaves
+	state.commitData.AssignedCollections = commitData.AssignedCollections
+	state.commitData.SeqAllottedCollections = commitData.SeqAllottedCollections
+	state.commitData.Epoch = epoch
+	state.commitData.Commitment = commitment
+	return state.commitData
+}
+
+// updateProposedData replaces the proposed data kept in memory with the given proposed data
+func (state *stakerState) updateProposedData(proposedData types.ProposeFileData) types.ProposeFileData {
+	state.proposedData.MediansData = proposedData.MediansData
+	state.proposedData.RevealedDataMaps = proposedData.RevealedDataMaps
+	state.proposedData.RevealedCollectionIds = proposedData.RevealedCollectionIds
+	state.proposedData.Epoch = proposedData.Epoch
+	return state.proposedData
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"math/big"
-	"os"
 	"razor/core"
 	"razor/core/types"
 	"razor/path"
@@ -24,6 +23,7 @@ import (
 	Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -134,6 +134,11 @@ func (transactionUtils TransactionUtils) Hash(txn *Types.Transaction) common.Has
 	return txn.Hash()
 }
 
+//This function transfers the value of the transaction options in sFUEL to the recipient
+func (transactionUtils TransactionUtils) Transfer(client *ethclient.Client, opts *bind.TransactOpts, recipient common.Address) (*Types.Transaction, error) {
+	return bind.NewBoundContract(recipient, abi.ABI{}, client, client, client).Transfer(opts)
+}
+
 //This function is of staking the razors
 func (stakeManagerUtils StakeManagerUtils) Stake(client *ethclient.Client, txnOpts *bind.TransactOpts, epoch uint32, amount *big.Int) (*Types.Transaction, error) {
 	stakeManager := razorUtils.GetStakeManager(client)
@@ -558,6 +563,11 @@ func (flagSetUtils FLagSetUtils) GetBoolRogue(flagSet *pflag.FlagSet) (bool, err
 	return flagSet.GetBool("rogue")
 }
 
+//This function is used to check if dryRun is passed or not
+func (flagSetUtils FLagSetUtils) GetBoolDryRun(flagSet *pflag.FlagSet) (bool, error) {
+	return flagSet.GetBool("dryRun")
+}
+
 //This function is used to check if rogueMode is passed or not
 func (flagSetUtils FLagSetUtils) GetStringSliceRogueMode(flagSet *pflag.FlagSet) ([]string, error) {
 	return flagSet.GetStringSlice("rogueMode")
@@ -583,6 +593,96 @@ func (flagSetUtils FLagSetUtils) GetStringCertKey(flagSet *pflag.FlagSet) (strin
 	return flagSet.GetString("certKey")
 }
 
+//This function returns the from epoch in uint32
+func (flagSetUtils FLagSetUtils) GetUint32FromEpoch(flagSet *pflag.FlagSet) (uint32, error) {
+	return flagSet.GetUint32("fromEpoch")
+}
+
+//This function returns the to epoch in uint32
+func (flagSetUtils FLagSetUtils) GetUint32ToEpoch(flagSet *pflag.FlagSet) (uint32, error) {
+	return flagSet.GetUint32("toEpoch")
+}
+
+//This function returns the output format in string
+func (flagSetUtils FLagSetUtils) GetStringOutput(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("output")
+}
+
+//This function returns the stakers file path in string
+func (flagSetUtils FLagSetUtils) GetStringStakersFile(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("stakersFile")
+}
+
+//This function returns the lease file path in string
+func (flagSetUtils FLagSetUtils) GetStringLeaseFile(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("leaseFile")
+}
+
+//This function returns the URL of the status endpoint of a running node in string
+func (flagSetUtils FLagSetUtils) GetStringStatusURL(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("statusURL")
+}
+
+//This function returns the address of the account funding the sFUEL top ups in string
+func (flagSetUtils FLagSetUtils) GetStringFundingAccount(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("fundingAccount")
+}
+
+//This function returns the password path of the funding account in string
+func (flagSetUtils FLagSetUtils) GetStringFundingPassword(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("fundingPassword")
+}
+
+//This function returns the minimum sFUEL balance of the balance guard in string
+func (flagSetUtils FLagSetUtils) GetStringMinSFuelBalance(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("minSFuelBalance")
+}
+
+//This function returns the sFUEL amount of a top up in string
+func (flagSetUtils FLagSetUtils) GetStringTopUpAmount(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("topUpAmount")
+}
+
+//This function returns whether the rewards are auto compounded in bool
+func (flagSetUtils FLagSetUtils) GetBoolAutoCompound(flagSet *pflag.FlagSet) (bool, error) {
+	return flagSet.GetBool("autoCompound")
+}
+
+//This function returns the RZR reserve which is not compounded in string
+func (flagSetUtils FLagSetUtils) GetStringCompoundReserve(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("compoundReserve")
+}
+
+//This function returns the maximum RZR compounded in an epoch in string
+func (flagSetUtils FLagSetUtils) GetStringMaxCompoundAmount(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("maxCompoundAmount")
+}
+
+//This function returns whether the responses of the job data sources are recorded in bool
+func (flagSetUtils FLagSetUtils) GetBoolRecordResponses(flagSet *pflag.FlagSet) (bool, error) {
+	return flagSet.GetBool("recordResponses")
+}
+
+//This function returns the epoch in Uint32
+func (flagSetUtils FLagSetUtils) GetUint32Epoch(flagSet *pflag.FlagSet) (uint32, error) {
+	return flagSet.GetUint32("epoch")
+}
+
+//This function returns the address of the staker in string
+func (flagSetUtils FLagSetUtils) GetStringStakerAddress(flagSet *pflag.FlagSet) (string, error) {
+	return flagSet.GetString("stakerAddress")
+}
+
+//This function returns the additional stakes in string slice
+func (flagSetUtils FLagSetUtils) GetStringSliceAdditionalStake(flagSet *pflag.FlagSet) ([]string, error) {
+	return flagSet.GetStringSlice("additionalStake")
+}
+
+//This function returns the number of simulations in uint32
+func (flagSetUtils FLagSetUtils) GetUint32Simulations(flagSet *pflag.FlagSet) (uint32, error) {
+	return flagSet.GetUint32("simulations")
+}
+
 //This function returns the max size of log file in Int
 func (flagSetUtils FLagSetUtils) GetIntLogFileMaxSize(flagSet *pflag.FlagSet) (int, error) {
 	return flagSet.GetInt("logFileMaxSize")
@@ -640,7 +740,7 @@ func (a AbiUtils) Unpack(abi abi.ABI, name string, data []byte) ([]interface{},
 	return abi.Unpack(name, data)
 }
 
-//This function is used for exiting the code
+//This function is used for exiting the code, the exit handlers flushing the log sinks are run before exiting
 func (o OSUtils) Exit(code int) {
-	os.Exit(code)
+	logrus.Exit(code)
 }
@@ -0,0 +1,246 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"razor/block"
+	"razor/cache"
+	"razor/core"
+	"razor/core/types"
+	"razor/metrics"
+	"razor/rpc"
+	"razor/utils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var superviseCmd = &cobra.Command{
+	Use:   "supervise",
+	Short: "Vote for several stakers from one process",
+	Long: `supervise command runs the voting of every staker listed in the stakers file.
+The stakers share the RPC endpoints, the block monitor, the job and collection caches and the API results, while every staker keeps its own voting state, data files and epoch journal.
+
+The stakers file is a JSON array with the address of every staker and the path of the file containing its keystore password:
+
+  [
+    {"address": "0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c", "password": "/home/razor/.razor/staker1_password"},
+    {"address": "0x2b7d08b7aebb1fd5d8a2cf9a3a2b1cc9f91c6d4e", "password": "/home/razor/.razor/staker2_password"}
+  ]
+
+Example:
+  ./razor supervise --stakersFile /home/razor/.razor/stakers.json --autoClaimBounty`,
+	Run: initialiseSupervise,
+}
+
+// stakersFileEntry is an account listed in the stakers file of the supervisor
+type stakersFileEntry struct {
+	Address  string `json:"address"`
+	Password string `json:"password"`
+}
+
+//This function initialises the ExecuteSupervise function
+func initialiseSupervise(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteSupervise(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the Supervise function
+func (*UtilsStruct) ExecuteSupervise(flagSet *pflag.FlagSet) {
+	config, rpcParameters, blockMonitor, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	err = ValidateBufferPercentLimit(rpcParameters, config.BufferPercent)
+	utils.CheckError("Error in validating buffer percent: ", err)
+
+	stakersFilePath, err := flagSetUtils.GetStringStakersFile(flagSet)
+	utils.CheckError("Error in getting stakers file path: ", err)
+	log.Debug("ExecuteSupervise: Stakers file path: ", stakersFilePath)
+
+	backupNodeActionsToIgnore, err := flagSetUtils.GetStringSliceBackupNode(flagSet)
+	utils.CheckError("Error in getting backupNode actions to ignore: ", err)
+	log.Debug("ExecuteSupervise: Backup node actions to ignore: ", backupNodeActionsToIgnore)
+
+	stakers, err := cmdUtils.GetSupervisedStakers(rpcParameters, config, stakersFilePath)
+	utils.CheckError("Error in getting stakers to supervise: ", err)
+
+	sFuelBalanceGuard, err = getBalanceGuard(flagSet, config)
+	utils.CheckError("Error in setting up sFUEL balance guard: ", err)
+
+	autoCompoundingPolicy, err = getCompoundingPolicy(flagSet)
+	utils.CheckError("Error in setting up auto compounding: ", err)
+
+	utils.ResponseRecorder, err = getResponseRecorder(flagSet)
+	utils.CheckError("Error in setting up response recording: ", err)
+
+	cmdUtils.HandleExit()
+
+	startTransactionManager(rpcParameters, config)
+
+	httpClient := &http.Client{
+		Timeout: time.Duration(config.HTTPTimeout) * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        core.HTTPClientMaxIdleConns,
+			MaxIdleConnsPerHost: core.HTTPClientMaxIdleConnsPerHost,
+		},
+	}
+	startNotifier(httpClient)
+
+	jobsCache, collectionsCache, initCacheBlockNumber, err := cmdUtils.InitJobAndCollectionCache(rpcParameters)
+	utils.CheckError("Error in initializing asset cache: ", err)
+
+	commitParams := &types.CommitParams{
+		LocalCache:                cache.NewLocalCache(), // Creating a local cache which will store API results of all the stakers
+		JobsCache:                 jobsCache,
+		CollectionsCache:          collectionsCache,
+		HttpClient:                httpClient,
+		FromBlockToCheckForEvents: initCacheBlockNumber,
+	}
+
+	log.Debugf("Calling Supervise() with arguments number of stakers = %d, backup node actions to ignore = %s", len(stakers), backupNodeActionsToIgnore)
+	if err := cmdUtils.Supervise(rpcParameters, blockMonitor, config, stakers, commitParams, backupNodeActionsToIgnore); err != nil {
+		log.Errorf("%v\n", err)
+		osUtils.Exit(1)
+	}
+}
+
+//This function reads the stakers file and returns the unlocked account and staker id of every staker listed in it
+func (*UtilsStruct) GetSupervisedStakers(rpcParameters rpc.RPCParameters, config types.Configurations, stakersFilePath string) ([]types.SupervisedStaker, error) {
+	stakersFileData, err := os.ReadFile(stakersFilePath)
+	if err != nil {
+		return nil, err
+	}
+	var entries []stakersFileEntry
+	if err := json.Unmarshal(stakersFileData, &entries); err != nil {
+		return nil, fmt.Errorf("invalid stakers file %s: %w", stakersFilePath, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no stakers present in stakers file %s", stakersFilePath)
+	}
+
+	var (
+		stakers   []types.SupervisedStaker
+		addresses = make(map[string]bool)
+	)
+	for _, entry := range entries {
+		if !common.IsHexAddress(entry.Address) {
+			return nil, fmt.Errorf("invalid address %q in stakers file", entry.Address)
+		}
+		if addresses[strings.ToLower(entry.Address)] {
+			return nil, fmt.Errorf("address %s is present more than once in stakers file", entry.Address)
+		}
+		addresses[strings.ToLower(entry.Address)] = true
+
+		if config.SignerURL == "" && entry.Password == "" {
+			return nil, fmt.Errorf("password path of address %s is not present in stakers file", entry.Address)
+		}
+		account, err := initialiseAccountWithPassword(config, entry.Address, func() string {
+			return utils.GetPasswordFromFile(entry.Password)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error in initialising account %s: %w", entry.Address, err)
+		}
+
+		stakerId, err := razorUtils.GetStakerId(rpcParameters, account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("error in getting staker id of %s: %w", account.Address, err)
+		}
+		if stakerId == 0 {
+			return nil, fmt.Errorf("staker with address %s doesn't exist", account.Address)
+		}
+		log.Infof("Supervising staker %d with address %s", stakerId, account.Address)
+		stakers = append(stakers, types.SupervisedStaker{
+			Account:  account,
+			StakerId: stakerId,
+		})
+	}
+	return stakers, nil
+}
+
+//This function runs the voting of every staker concurrently, each with its own voting state, until all of them stop
+func (*UtilsStruct) Supervise(rpcParameters rpc.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, stakers []types.SupervisedStaker, commitParams *types.CommitParams, backupNodeActionsToIgnore []string) error {
+	var (
+		wg         sync.WaitGroup
+		errorsMu   sync.Mutex
+		voteErrors []error
+	)
+	for _, staker := range stakers {
+		// The status endpoint is not served by the supervisor, every staker keeps its status in its own store
+		// so that the stakers don't overwrite the status of each other
+		state := &stakerState{status: metrics.NewStatusStore()}
+		state.status.Update(func(status *metrics.NodeStatus) {
+			status.Address = staker.Account.Address
+			status.StakerId = staker.StakerId
+		})
+		epochJournal, err := openEpochJournal(staker.Account.Address)
+		if err != nil {
+			log.Errorf("Error in opening epoch journal of %s, recovery will only use data files: %v", staker.Account.Address, err)
+		}
+		state.epochJournal = epochJournal
+
+		stakerRPCParameters := rpcParameters
+		stakerRPCParameters.Ctx = withStakerState(rpcParameters.Ctx, state)
+
+		// The caches are shared, but every staker checks for job and collection events from its own block
+		stakerCommitParams := *commitParams
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Infof("Starting to vote for staker %d with address %s", staker.StakerId, staker.Account.Address)
+			err := cmdUtils.Vote(stakerRPCParameters, blockMonitor, config, staker.Account, staker.StakerId, &stakerCommitParams, types.Rogue{IsRogue: false}, backupNodeActionsToIgnore)
+			if errors.Is(err, errStakerSlashed) {
+				log.Errorf("Stopped voting for staker %d as it is slashed, still voting for the other stakers", staker.StakerId)
+				return
+			}
+			if err != nil {
+				log.Errorf("Error in voting for staker %d: %v", staker.StakerId, err)
+				errorsMu.Lock()
+				voteErrors = append(voteErrors, fmt.Errorf("staker %d: %w", staker.StakerId, err))
+				errorsMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(voteErrors...)
+}
+
+func init() {
+	rootCmd.AddCommand(superviseCmd)
+
+	var (
+		StakersFile     string
+		AutoClaimBounty bool
+		BackupNode      []string
+		FundingAccount  string
+		FundingPassword string
+		MinSFuelBalance string
+		TopUpAmount     string
+		AutoCompound    bool
+		CompoundReserve string
+		MaxCompound     string
+		RecordResponses bool
+	)
+
+	superviseCmd.Flags().StringVarP(&StakersFile, "stakersFile", "", "", "path of the JSON file listing the address and password path of every staker")
+	superviseCmd.Flags().BoolVarP(&AutoClaimBounty, "autoClaimBounty", "", false, "auto claim bounty")
+	superviseCmd.Flags().StringSliceVarP(&BackupNode, "backupNode", "", []string{}, "actions that backup node will ignore")
+	superviseCmd.Flags().StringVarP(&FundingAccount, "fundingAccount", "", "", "address of the account which tops up the sFUEL balance of the stakers")
+	superviseCmd.Flags().StringVarP(&FundingPassword, "fundingPassword", "", "", "password path of the funding account")
+	superviseCmd.Flags().StringVarP(&MinSFuelBalance, "minSFuelBalance", "", "", "sFUEL balance below which the balance is topped up and non-essential transactions are paused")
+	superviseCmd.Flags().StringVarP(&TopUpAmount, "topUpAmount", "", "", "sFUEL transferred from the funding account in a top up, defaults to minSFuelBalance")
+	superviseCmd.Flags().BoolVarP(&AutoCompound, "autoCompound", "", false, "claim the commission and stake the RZR balance above the reserve of every staker in every epoch")
+	superviseCmd.Flags().StringVarP(&CompoundReserve, "compoundReserve", "", "", "RZR balance of every staker which is kept and not staked by auto compounding")
+	superviseCmd.Flags().StringVarP(&MaxCompound, "maxCompoundAmount", "", "", "maximum RZR staked by auto compounding for a staker in an epoch")
+	superviseCmd.Flags().BoolVarP(&RecordResponses, "recordResponses", "", false, "record the raw responses of the job data sources of every epoch in the data directory to replay them later")
+
+	stakersFileErr := superviseCmd.MarkFlagRequired("stakersFile")
+	utils.CheckError("Stakers file error: ", stakersFileErr)
+}
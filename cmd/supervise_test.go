@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"razor/core/types"
+	"razor/rpc"
+	"sync"
+	"testing"
+
+	accountsPkgMocks "razor/accounts/mocks"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSupervisedStakers(t *testing.T) {
+	keystoreAccountManager := new(accountsPkgMocks.AccountManagerInterface)
+	address1 := "0x57Baf83BAD5bee0F7F44d84669A50C35c57E3576"
+	address2 := "0x2b7d08b7aebb1fd5d8a2cf9a3a2b1cc9f91c6d4e"
+
+	testDir := t.TempDir()
+	passwordPath := filepath.Join(testDir, "password")
+	if err := os.WriteFile(passwordPath, []byte("test\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		stakersFile   string
+		config        types.Configurations
+		stakerIds     map[string]uint32
+		stakerIdErr   error
+		noStakersFile bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.SupervisedStaker
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When stakers file lists two stakers",
+			args: args{
+				stakersFile: `[{"address":"` + address1 + `","password":"` + passwordPath + `"},{"address":"` + address2 + `","password":"` + passwordPath + `"}]`,
+				stakerIds:   map[string]uint32{address1: 1, address2: 2},
+			},
+			want: []types.SupervisedStaker{
+				{Account: types.Account{Address: address1, Password: "test", AccountManager: keystoreAccountManager}, StakerId: 1},
+				{Account: types.Account{Address: address2, Password: "test", AccountManager: keystoreAccountManager}, StakerId: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test 2: When stakers file is not valid JSON",
+			args: args{
+				stakersFile: `[{"address":`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 3: When stakers file has no stakers",
+			args: args{
+				stakersFile: `[]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 4: When an address is present more than once",
+			args: args{
+				stakersFile: `[{"address":"` + address2 + `","password":"` + passwordPath + `"},{"address":"0x2B7D08B7AEBB1FD5D8A2CF9A3A2B1CC9F91C6D4E","password":"` + passwordPath + `"}]`,
+				stakerIds:   map[string]uint32{address2: 2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 5: When address is invalid",
+			args: args{
+				stakersFile: `[{"address":"0x123","password":"` + passwordPath + `"}]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 6: When password path is not present without remote signer",
+			args: args{
+				stakersFile: `[{"address":"` + address1 + `"}]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 7: When staker doesn't exist",
+			args: args{
+				stakersFile: `[{"address":"` + address1 + `","password":"` + passwordPath + `"}]`,
+				stakerIds:   map[string]uint32{address1: 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 8: When there is an error in getting staker id",
+			args: args{
+				stakersFile: `[{"address":"` + address1 + `","password":"` + passwordPath + `"}]`,
+				stakerIds:   map[string]uint32{address1: 1},
+				stakerIdErr: errors.New("stakerId error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 9: When stakers file doesn't exist",
+			args: args{
+				noStakersFile: true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			stakersFilePath := filepath.Join(t.TempDir(), "stakers.json")
+			if !tt.args.noStakersFile {
+				if err := os.WriteFile(stakersFilePath, []byte(tt.args.stakersFile), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			utilsMock.On("AccountManagerForKeystore").Return(keystoreAccountManager, nil)
+			utilsMock.On("CheckPassword", mock.Anything).Return(nil)
+			for address, stakerId := range tt.args.stakerIds {
+				utilsMock.On("GetStakerId", mock.Anything, address).Return(stakerId, tt.args.stakerIdErr)
+			}
+
+			ut := &UtilsStruct{}
+			got, err := ut.GetSupervisedStakers(rpcParameters, tt.args.config, stakersFilePath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSupervisedStakers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetSupervisedStakers() got %d stakers, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].StakerId != tt.want[i].StakerId || got[i].Account.Address != tt.want[i].Account.Address || got[i].Account.Password != tt.want[i].Account.Password {
+					t.Errorf("GetSupervisedStakers() staker %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	var config types.Configurations
+	stakers := []types.SupervisedStaker{
+		{Account: types.Account{Address: "0x57Baf83BAD5bee0F7F44d84669A50C35c57E3576"}, StakerId: 1},
+		{Account: types.Account{Address: "0x2b7d08b7aebb1fd5d8a2cf9a3a2b1cc9f91c6d4e"}, StakerId: 2},
+	}
+
+	tests := []struct {
+		name    string
+		voteErr error
+		wantErr bool
+	}{
+		{
+			name:    "Test 1: When every staker votes with its own state",
+			wantErr: false,
+		},
+		{
+			name:    "Test 2: When voting of stakers returns an error",
+			voteErr: errors.New("vote error"),
+			wantErr: true,
+		},
+		{
+			name:    "Test 3: When voting of stakers stops as they are slashed",
+			voteErr: errStakerSlashed,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			var (
+				mu          sync.Mutex
+				states      = make(map[uint32]*stakerState)
+				commitParam = make(map[uint32]*types.CommitParams)
+			)
+			commitParams := &types.CommitParams{}
+
+			pathMock.On("GetEpochJournalFileName", mock.Anything).Return("", errors.New("path error"))
+			cmdUtilsMock.On("Vote", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				mu.Lock()
+				defer mu.Unlock()
+				stakerId := args.Get(4).(uint32)
+				states[stakerId] = getStakerState(args.Get(0).(rpc.RPCParameters).Ctx)
+				commitParam[stakerId] = args.Get(5).(*types.CommitParams)
+			}).Return(tt.voteErr)
+
+			ut := &UtilsStruct{}
+			err := ut.Supervise(rpcParameters, nil, config, stakers, commitParams, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Supervise() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(states) != len(stakers) {
+				t.Fatalf("Expected %d stakers to vote, got %d", len(stakers), len(states))
+			}
+			if states[1] == states[2] || states[1] == defaultStakerState || states[2] == defaultStakerState {
+				t.Error("Expected every staker to vote with its own state")
+			}
+			if states[1].statusStore() == states[2].statusStore() || states[1].statusStore().Get().StakerId != 1 {
+				t.Error("Expected every staker to keep its own status")
+			}
+			if commitParam[1] == commitParam[2] || commitParam[1] == commitParams {
+				t.Error("Expected every staker to vote with its own commit params")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/rpc"
+	"razor/txmanager"
+	"razor/utils"
+	"time"
+
+	Types "github.com/ethereum/go-ethereum/core/types"
+)
+
+// startTransactionManager starts tracking the transactions sent by the vote process so that stuck transactions
+// are resubmitted with a higher gas price and transactions which missed their state are cancelled.
+// The pending transactions are also checked in the background, as no new block is handled while a transaction is waited for.
+func startTransactionManager(rpcParameters rpc.RPCParameters, config types.Configurations) {
+	utils.TransactionManager = txmanager.NewManager(txmanager.DefaultConfig(), func() *big.Int {
+		// The gas price is taken from razor.yaml as it is when the transaction is resubmitted
+		return gasUtils.GetGasPrice(rpcParameters, currentConfig(config))
+	})
+	go utils.TransactionManager.Watch(rpcParameters.Ctx, core.TransactionCheckInterval*time.Second, func() (txmanager.Backend, *Types.Header, error) {
+		client, err := rpcParameters.RPCManager.GetBestRPCClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		latestHeader, err := clientUtils.GetLatestBlockWithRetry(rpcParameters)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, latestHeader, nil
+	}, logTransactionResults)
+}
+
+// processPendingTransactions checks the pending transactions of the transaction manager against the latest header
+func processPendingTransactions(rpcParameters rpc.RPCParameters, latestHeader *Types.Header) {
+	if utils.TransactionManager == nil || utils.TransactionManager.PendingCount() == 0 {
+		return
+	}
+	client, err := rpcParameters.RPCManager.GetBestRPCClient()
+	if err != nil {
+		log.Error("Error in getting best RPC client to check pending transactions: ", err)
+		return
+	}
+	logTransactionResults(utils.TransactionManager.Check(rpcParameters.Ctx, client, latestHeader))
+}
+
+// logTransactionResults warns about the transactions which reached a final outcome without being mined
+func logTransactionResults(results []txmanager.Result) {
+	for _, result := range results {
+		if result.Outcome != txmanager.OutcomeMined {
+			log.Warnf("%s transaction with nonce %d was not mined, final outcome: %s", result.Action, result.Nonce, result.Outcome)
+		}
+	}
+}
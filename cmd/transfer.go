@@ -2,7 +2,7 @@
 package cmd
 
 import (
-	"razor/accounts"
+	"errors"
 	"razor/core"
 	"razor/core/types"
 	"razor/pkg/bindings"
@@ -40,16 +40,8 @@ func (*UtilsStruct) ExecuteTransfer(flagSet *pflag.FlagSet) {
 	fromAddress, err := flagSetUtils.GetStringFrom(flagSet)
 	utils.CheckError("Error in getting fromAddress: ", err)
 
-	log.Debug("Getting password...")
-	password := razorUtils.AssignPassword(flagSet)
-
-	accountManager, err := razorUtils.AccountManagerForKeystore()
-	utils.CheckError("Error in getting accounts manager for keystore: ", err)
-
-	account := accounts.InitAccountStruct(fromAddress, password, accountManager)
-
-	err = razorUtils.CheckPassword(account)
-	utils.CheckError("Error in fetching private key from given password: ", err)
+	account, err := initialiseAccount(flagSet, config, fromAddress)
+	utils.CheckError("Error in initialising account: ", err)
 
 	toAddress, err := flagSetUtils.GetStringTo(flagSet)
 	utils.CheckError("Error in getting toAddress: ", err)
@@ -110,6 +102,39 @@ func (*UtilsStruct) Transfer(rpcParameters rpc.RPCParameters, config types.Confi
 	return txnHash, nil
 }
 
+//This function transfers sFUEL from the account in the transfer input to the to address
+func (*UtilsStruct) TransferSFuel(rpcParameters rpc.RPCParameters, config types.Configurations, transferInput types.TransferInput) (common.Hash, error) {
+	if transferInput.ValueInWei.Cmp(transferInput.Balance) > 0 {
+		return core.NilHash, errors.New("not enough sFUEL balance")
+	}
+
+	txnOpts, err := razorUtils.GetTxnOpts(rpcParameters, types.TransactionOptions{
+		ChainId:    core.ChainId,
+		Config:     config,
+		EtherValue: transferInput.ValueInWei,
+		Account:    transferInput.Account,
+	})
+	if err != nil {
+		return core.NilHash, err
+	}
+	log.Infof("Transferring %g sFUEL from %s to %s", utils.GetAmountInDecimal(transferInput.ValueInWei), transferInput.Account.Address, transferInput.ToAddress)
+	client, err := rpcParameters.RPCManager.GetBestRPCClient()
+	if err != nil {
+		return core.NilHash, err
+	}
+
+	log.Debugf("Executing sFUEL transfer transaction with toAddress: %s, amount: %s", transferInput.ToAddress, transferInput.ValueInWei)
+	txn, err := transactionUtils.Transfer(client, txnOpts, common.HexToAddress(transferInput.ToAddress))
+	if err != nil {
+		log.Error("Error in transferring sFUEL")
+		return core.NilHash, err
+	}
+
+	txnHash := transactionUtils.Hash(txn)
+	log.Info("Txn Hash: ", txnHash.Hex())
+	return txnHash, nil
+}
+
 func init() {
 	rootCmd.AddCommand(transferCmd)
 	var (
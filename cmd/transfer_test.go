@@ -100,6 +100,83 @@ func TestTransfer(t *testing.T) {
 	}
 }
 
+func TestTransferSFuel(t *testing.T) {
+	var config types.Configurations
+
+	type args struct {
+		balance      *big.Int
+		txnOptsErr   error
+		transferTxn  *Types.Transaction
+		transferErr  error
+		transferHash common.Hash
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    common.Hash
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When sFUEL is transferred successfully",
+			args: args{
+				balance:      big.NewInt(1e18),
+				transferTxn:  &Types.Transaction{},
+				transferHash: common.BigToHash(big.NewInt(1)),
+			},
+			want:    common.BigToHash(big.NewInt(1)),
+			wantErr: false,
+		},
+		{
+			name: "Test 2: When the sFUEL balance is lower than the amount to transfer",
+			args: args{
+				balance: big.NewInt(1e15),
+			},
+			want:    core.NilHash,
+			wantErr: true,
+		},
+		{
+			name: "Test 3: When there is an error in getting txnOpts",
+			args: args{
+				balance:    big.NewInt(1e18),
+				txnOptsErr: errors.New("txnOpts error"),
+			},
+			want:    core.NilHash,
+			wantErr: true,
+		},
+		{
+			name: "Test 4: When the transfer transaction fails",
+			args: args{
+				balance:     big.NewInt(1e18),
+				transferErr: errors.New("transfer error"),
+			},
+			want:    core.NilHash,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetTxnOpts", mock.Anything, mock.Anything).Return(TxnOpts, tt.args.txnOptsErr)
+			transactionMock.On("Transfer", mock.AnythingOfType("*ethclient.Client"), mock.AnythingOfType("*bind.TransactOpts"), mock.AnythingOfType("common.Address")).Return(tt.args.transferTxn, tt.args.transferErr)
+			transactionMock.On("Hash", mock.Anything).Return(tt.args.transferHash)
+
+			utils := &UtilsStruct{}
+			got, err := utils.TransferSFuel(rpcParameters, config, types.TransferInput{
+				ToAddress:  "0x000000000000000000000000000000000000dead",
+				ValueInWei: big.NewInt(1e16),
+				Balance:    tt.args.balance,
+			})
+			if got != tt.want {
+				t.Errorf("Txn hash for TransferSFuel function, got = %v, want = %v", got, tt.want)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error for TransferSFuel function, got = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestExecuteTransfer(t *testing.T) {
 	var client *ethclient.Client
 	var flagSet *pflag.FlagSet
@@ -0,0 +1,414 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"razor/cache"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/rpc"
+	"razor/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var validateAssetsCmd = &cobra.Command{
+	Use:   "validateAssets",
+	Short: "validate the overridden and custom jobs of assets.json",
+	Long: `Parses the assets.json file in the .razor directory against its schema, checks that its collections and official jobs exist on chain,
+fetches every overridden and custom job and shows its value, latency and deviation from the value of the collection in the previous epoch.
+The command fails if the file has any problem or any of its jobs fails.
+
+Example:
+  ./razor validateAssets
+  ./razor validateAssets --output json`,
+	Run: initialiseValidateAssets,
+}
+
+// assetsFile is the schema of the assets.json file
+type assetsFile struct {
+	Assets struct {
+		Collection map[string]assetsFileCollection `json:"collection"`
+	} `json:"assets"`
+}
+
+type assetsFileCollection struct {
+	Power        int8                     `json:"power"`
+	Aggregation  *types.AggregationPolicy `json:"aggregation"`
+	OfficialJobs map[string]assetsFileJob `json:"official jobs"`
+	CustomJobs   []assetsFileJob          `json:"custom jobs"`
+}
+
+// assetsFileJob is an official job override or a custom job, fields of an override which are not set are taken from the on-chain job
+type assetsFileJob struct {
+	URL          json.RawMessage `json:"URL"`
+	Name         *string         `json:"name"`
+	Selector     *string         `json:"selector"`
+	SelectorType *uint8          `json:"selectorType"`
+	Power        *int8           `json:"power"`
+	Weight       *uint8          `json:"weight"`
+}
+
+//This function initialises the ExecuteValidateAssets function
+func initialiseValidateAssets(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteValidateAssets(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the ValidateAssets function
+func (*UtilsStruct) ExecuteValidateAssets(flagSet *pflag.FlagSet) {
+	config, rpcParameters, _, _, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	output, err := flagSetUtils.GetStringOutput(flagSet)
+	utils.CheckError("Error in getting output format: ", err)
+
+	assetsFilePath, err := pathUtils.GetJobFilePath()
+	utils.CheckError("Error in getting assets file path: ", err)
+	log.Debug("ExecuteValidateAssets: Assets file path: ", assetsFilePath)
+
+	assetsFileData, err := os.ReadFile(assetsFilePath)
+	utils.CheckError("Error in reading assets file: ", err)
+
+	jobsCache, collectionsCache, _, err := cmdUtils.InitJobAndCollectionCache(rpcParameters)
+	utils.CheckError("Error in initializing asset cache: ", err)
+
+	commitParams := &types.CommitParams{
+		JobsCache:        jobsCache,
+		CollectionsCache: collectionsCache,
+		HttpClient:       &http.Client{Timeout: time.Duration(config.HTTPTimeout) * time.Second},
+	}
+
+	log.Debug("ExecuteValidateAssets: Calling ValidateAssets()")
+	results, issues, err := cmdUtils.ValidateAssets(rpcParameters, assetsFileData, commitParams)
+	utils.CheckError("Error in validating assets file: ", err)
+
+	err = printJobValidationResults(results, output)
+	utils.CheckError("Error in printing job results: ", err)
+	for _, issue := range issues {
+		log.Error(issue)
+	}
+	failedJobs := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failedJobs++
+		}
+	}
+	if len(issues) > 0 || failedJobs > 0 {
+		utils.CheckError("Invalid assets file: ", fmt.Errorf("%d problem(s) found and %d job(s) failed", len(issues), failedJobs))
+	}
+	log.Info("assets.json is valid")
+}
+
+//This function lints the assets file and fetches each of its overridden and custom jobs, it returns the results of the jobs and the problems found in the file
+func (*UtilsStruct) ValidateAssets(rpcParameters rpc.RPCParameters, assetsFileData []byte, commitParams *types.CommitParams) ([]types.JobValidationResult, []string, error) {
+	parsedAssetsFile, issues := parseAssetsFile(assetsFileData)
+	if parsedAssetsFile == nil {
+		return nil, issues, nil
+	}
+
+	epoch, err := razorUtils.GetEpoch(rpcParameters)
+	if err != nil {
+		log.Error("Error in getting epoch: ", err)
+		return nil, nil, err
+	}
+
+	collectionIds := getCollectionIdsByName(commitParams.CollectionsCache)
+	var results []types.JobValidationResult
+	for _, collectionName := range getSortedCollectionNames(parsedAssetsFile) {
+		collectionId, ok := collectionIds[collectionName]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("collection %s: not present on chain", collectionName))
+			continue
+		}
+		collection, _ := commitParams.CollectionsCache.GetCollection(collectionId)
+		if !collection.Active {
+			issues = append(issues, fmt.Sprintf("collection %s: collection is inactive, its jobs are never used", collectionName))
+		}
+		issues = append(issues, checkOfficialJobIds(collection, parsedAssetsFile.Assets.Collection[collectionName], commitParams.JobsCache)...)
+
+		if _, err := utils.GetAggregationPolicyFromJSONFile(collectionName, string(assetsFileData)); err != nil {
+			issues = append(issues, fmt.Sprintf("collection %s: %v", collectionName, err))
+		}
+
+		// The jobs are read the same way as while voting so that the results show what the node would commit
+		jobs, _ := razorUtils.HandleOfficialJobsFromJSONFile(collection, string(assetsFileData), commitParams)
+		jobs = append(jobs, utils.GetCustomJobsFromJSONFile(collectionName, string(assetsFileData))...)
+		if len(jobs) == 0 {
+			continue
+		}
+
+		previousValue, err := razorUtils.FetchPreviousValue(rpcParameters, epoch-1, collection.Id)
+		if err != nil {
+			log.Warnf("Error in fetching previous value of collection %s: %v", collectionName, err)
+			previousValue = nil
+		}
+		for _, job := range jobs {
+			results = append(results, validateJob(collectionName, job, previousValue, commitParams))
+		}
+	}
+	return results, issues, nil
+}
+
+//This function decodes the assets file strictly and returns the problems which would make the node skip or misread any part of it
+func parseAssetsFile(assetsFileData []byte) (*assetsFile, []string) {
+	var parsedAssetsFile assetsFile
+	decoder := json.NewDecoder(bytes.NewReader(assetsFileData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&parsedAssetsFile); err != nil {
+		return nil, []string{"invalid assets file: " + err.Error()}
+	}
+
+	var issues []string
+	for _, collectionName := range getSortedCollectionNames(&parsedAssetsFile) {
+		collection := parsedAssetsFile.Assets.Collection[collectionName]
+		var jobIds []string
+		for jobId := range collection.OfficialJobs {
+			jobIds = append(jobIds, jobId)
+		}
+		sort.Strings(jobIds)
+		for _, jobId := range jobIds {
+			job := collection.OfficialJobs[jobId]
+			if _, err := strconv.ParseUint(jobId, 10, 16); err != nil {
+				issues = append(issues, fmt.Sprintf("collection %s: official job id %s is not a valid job id", collectionName, jobId))
+			}
+			if job.Name != nil {
+				issues = append(issues, fmt.Sprintf("collection %s, official job %s: name cannot be overridden and is ignored", collectionName, jobId))
+			}
+			issues = append(issues, lintAssetsFileJob(job, fmt.Sprintf("collection %s, official job %s", collectionName, jobId), false)...)
+		}
+
+		customJobNames := make(map[string]bool)
+		for i, job := range collection.CustomJobs {
+			jobLabel := fmt.Sprintf("collection %s, custom job %d", collectionName, i)
+			if job.Name == nil || *job.Name == "" {
+				issues = append(issues, jobLabel+": name is required")
+			} else {
+				if customJobNames[*job.Name] {
+					issues = append(issues, jobLabel+": name "+*job.Name+" is used by another custom job of the collection")
+				}
+				customJobNames[*job.Name] = true
+			}
+			issues = append(issues, lintAssetsFileJob(job, jobLabel, true)...)
+		}
+	}
+	return &parsedAssetsFile, issues
+}
+
+//This function returns the problems of an official job override or custom job, a custom job needs all of URL, selector and weight
+func lintAssetsFileJob(job assetsFileJob, jobLabel string, isCustomJob bool) []string {
+	var issues []string
+	if job.URL == nil {
+		if isCustomJob {
+			issues = append(issues, jobLabel+": URL is required")
+		}
+	} else if err := validateJobURL(job.URL); err != nil {
+		issues = append(issues, jobLabel+": "+err.Error())
+	}
+	if job.Selector == nil || *job.Selector == "" {
+		if isCustomJob || job.Selector != nil {
+			issues = append(issues, jobLabel+": selector is required")
+		}
+	}
+	if job.SelectorType != nil && *job.SelectorType != 0 {
+		issues = append(issues, jobLabel+": only JSON selectors (selectorType 0) are supported in assets.json")
+	}
+	if job.Weight == nil {
+		if isCustomJob {
+			issues = append(issues, jobLabel+": weight is required")
+		}
+	} else if *job.Weight == 0 {
+		issues = append(issues, jobLabel+": weight should be greater than 0")
+	}
+	return issues
+}
+
+//This function checks that the URL of a job is either a http(s) URL or a data source object with a registered type
+func validateJobURL(rawURL json.RawMessage) error {
+	var urlString string
+	if err := json.Unmarshal(rawURL, &urlString); err == nil {
+		return validateHTTPURL(urlString, "http", "https")
+	}
+
+	var dataSourceURL types.DataSourceURL
+	decoder := json.NewDecoder(bytes.NewReader(rawURL))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&dataSourceURL); err != nil {
+		return errors.New("URL should be a string or a data source object: " + err.Error())
+	}
+	if _, err := utils.GetDataSourceProvider(dataSourceURL.Type); err != nil {
+		return fmt.Errorf("URL has invalid type %q", dataSourceURL.Type)
+	}
+	if strings.EqualFold(dataSourceURL.Type, utils.DataSourceTypeWebSocket) {
+		return validateHTTPURL(dataSourceURL.URL, "ws", "wss")
+	}
+	return validateHTTPURL(dataSourceURL.URL, "http", "https")
+}
+
+func validateHTTPURL(urlString string, schemes ...string) error {
+	parsedURL, err := url.Parse(urlString)
+	if err != nil || parsedURL.Host == "" {
+		return fmt.Errorf("URL %q is not a valid URL", urlString)
+	}
+	for _, scheme := range schemes {
+		if parsedURL.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("URL %q should have scheme %s", urlString, strings.Join(schemes, " or "))
+}
+
+//This function checks that the official jobs overridden in the assets file exist on chain and belong to the collection, as other overrides are ignored
+func checkOfficialJobIds(collection bindings.StructsCollection, collectionData assetsFileCollection, jobsCache *cache.JobsCache) []string {
+	var issues []string
+	var jobIds []string
+	for jobId := range collectionData.OfficialJobs {
+		jobIds = append(jobIds, jobId)
+	}
+	sort.Strings(jobIds)
+	for _, jobId := range jobIds {
+		id, err := strconv.ParseUint(jobId, 10, 16)
+		if err != nil {
+			continue
+		}
+		if _, ok := jobsCache.GetJob(uint16(id)); !ok {
+			issues = append(issues, fmt.Sprintf("collection %s: official job %s is not present on chain", collection.Name, jobId))
+			continue
+		}
+		if !utils.Contains(collection.JobIDs, uint16(id)) {
+			issues = append(issues, fmt.Sprintf("collection %s: official job %s is not a job of the collection and is ignored", collection.Name, jobId))
+		}
+	}
+	return issues
+}
+
+//This function fetches the job and returns its value, latency and percentage deviation from the previous value of the collection
+func validateJob(collectionName string, job bindings.StructsJob, previousValue *big.Int, commitParams *types.CommitParams) types.JobValidationResult {
+	result := types.JobValidationResult{
+		Collection:    collectionName,
+		JobId:         job.Id,
+		Name:          job.Name,
+		PreviousValue: previousValue,
+	}
+	// Every job gets an empty cache so that its latency isn't hidden by a response cached for another job
+	jobCommitParams := *commitParams
+	jobCommitParams.LocalCache = cache.NewLocalCache()
+
+	start := time.Now()
+	value, err := razorUtils.GetDataToCommitFromJob(job, &jobCommitParams)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Value = value
+	result.Deviation = calculateDeviation(value, previousValue)
+	return result
+}
+
+//This function returns the deviation of the value from the previous value in percent, it is nil if there is no previous value
+func calculateDeviation(value *big.Int, previousValue *big.Int) *float64 {
+	if value == nil || previousValue == nil || previousValue.Sign() == 0 {
+		return nil
+	}
+	difference := new(big.Float).SetInt(new(big.Int).Sub(value, previousValue))
+	deviation, _ := new(big.Float).Quo(difference.Mul(difference, big.NewFloat(100)), new(big.Float).SetInt(new(big.Int).Abs(previousValue))).Float64()
+	return &deviation
+}
+
+func getCollectionIdsByName(collectionsCache *cache.CollectionsCache) map[string]uint16 {
+	collectionsCache.Mu.RLock()
+	defer collectionsCache.Mu.RUnlock()
+	collectionIds := make(map[string]uint16)
+	for collectionId, collection := range collectionsCache.Collections {
+		collectionIds[collection.Name] = collectionId
+	}
+	return collectionIds
+}
+
+func getSortedCollectionNames(parsedAssetsFile *assetsFile) []string {
+	var collectionNames []string
+	for collectionName := range parsedAssetsFile.Assets.Collection {
+		collectionNames = append(collectionNames, collectionName)
+	}
+	sort.Strings(collectionNames)
+	return collectionNames
+}
+
+// jobValidationRecord is a job in the output of validateAssets
+type jobValidationRecord struct {
+	Collection    string `json:"collection" yaml:"collection"`
+	JobId         uint16 `json:"jobId" yaml:"jobId"`
+	Name          string `json:"name" yaml:"name"`
+	Value         string `json:"value" yaml:"value"`
+	LatencyMs     int64  `json:"latencyMs" yaml:"latencyMs"`
+	PreviousValue string `json:"previousValue" yaml:"previousValue"`
+	Deviation     string `json:"deviation" yaml:"deviation"`
+	Error         string `json:"error" yaml:"error"`
+}
+
+//This function prints the results of the jobs of the assets file in the given output format
+func printJobValidationResults(results []types.JobValidationResult, output string) error {
+	records := make([]jobValidationRecord, 0, len(results))
+	var rows [][]string
+	for _, result := range results {
+		record := jobValidationRecord{
+			Collection: result.Collection,
+			JobId:      result.JobId,
+			Name:       result.Name,
+			LatencyMs:  result.LatencyMs,
+			Error:      result.Error,
+		}
+		if result.Value != nil {
+			record.Value = result.Value.String()
+		}
+		if result.PreviousValue != nil {
+			record.PreviousValue = result.PreviousValue.String()
+		}
+		if result.Deviation != nil {
+			record.Deviation = strconv.FormatFloat(*result.Deviation, 'f', 2, 64)
+		}
+		records = append(records, record)
+		rows = append(rows, jobValidationResultRow(record))
+	}
+	return printOutput(output, outputData{
+		Header:  []string{"Collection", "Job Id", "Name", "Value", "Latency (ms)", "Previous Value", "Deviation (%)", "Error"},
+		Fields:  []string{"collection", "jobId", "name", "value", "latencyMs", "previousValue", "deviation", "error"},
+		Rows:    rows,
+		Records: records,
+	})
+}
+
+func jobValidationResultRow(record jobValidationRecord) []string {
+	jobId := ""
+	if record.JobId != 0 {
+		jobId = strconv.Itoa(int(record.JobId))
+	}
+	return []string{
+		record.Collection,
+		jobId,
+		record.Name,
+		record.Value,
+		strconv.FormatInt(record.LatencyMs, 10),
+		record.PreviousValue,
+		record.Deviation,
+		record.Error,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(validateAssetsCmd)
+
+	addOutputFlag(validateAssetsCmd)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"razor/cache"
+	"razor/pkg/bindings"
+	"reflect"
+	"testing"
+)
+
+func TestParseAssetsFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantParsed bool
+		wantIssues []string
+	}{
+		{
+			name:       "Test 1: When assets file is valid",
+			data:       `{"assets":{"collection":{"ETHUSD":{"power":2,"official jobs":{"1":{"URL":"https://api.gemini.com/v1/pubticker/ethusd","selector":"last","weight":2}},"custom jobs":[{"URL":{"type":"POST","url":"https://rpc.ankr.com/eth","body":{"method":"eth_blockNumber"}},"name":"eth_ankr","selector":"result","selectorType":0,"power":-4,"weight":1}]}}}}`,
+			wantParsed: true,
+		},
+		{
+			name:       "Test 2: When assets file has an unknown field",
+			data:       `{"assets":{"collection":{"ETHUSD":{"custom jobs":[{"URL":"https://api.gemini.com/v1/pubticker/ethusd","name":"eth_gemini","selectr":"last","weight":1}]}}}}`,
+			wantIssues: []string{`invalid assets file: json: unknown field "selectr"`},
+		},
+		{
+			name:       "Test 3: When assets file is not valid JSON",
+			data:       `{"assets":`,
+			wantIssues: []string{"invalid assets file: unexpected EOF"},
+		},
+		{
+			name:       "Test 4: When official job id is invalid and name is overridden",
+			data:       `{"assets":{"collection":{"ETHUSD":{"official jobs":{"one":{"name":"eth_gemini","selector":"last"}}}}}}`,
+			wantParsed: true,
+			wantIssues: []string{
+				"collection ETHUSD: official job id one is not a valid job id",
+				"collection ETHUSD, official job one: name cannot be overridden and is ignored",
+			},
+		},
+		{
+			name:       "Test 5: When custom jobs miss required fields",
+			data:       `{"assets":{"collection":{"ETHUSD":{"custom jobs":[{"name":"eth_gemini"},{"URL":"ftp://api.gemini.com","name":"eth_gemini","selector":"last","selectorType":1,"weight":0}]}}}}`,
+			wantParsed: true,
+			wantIssues: []string{
+				"collection ETHUSD, custom job 0: URL is required",
+				"collection ETHUSD, custom job 0: selector is required",
+				"collection ETHUSD, custom job 0: weight is required",
+				"collection ETHUSD, custom job 1: name eth_gemini is used by another custom job of the collection",
+				`collection ETHUSD, custom job 1: URL "ftp://api.gemini.com" should have scheme http or https`,
+				"collection ETHUSD, custom job 1: only JSON selectors (selectorType 0) are supported in assets.json",
+				"collection ETHUSD, custom job 1: weight should be greater than 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotIssues := parseAssetsFile([]byte(tt.data))
+			if (got != nil) != tt.wantParsed {
+				t.Errorf("parseAssetsFile() parsed = %v, want %v", got != nil, tt.wantParsed)
+			}
+			if !reflect.DeepEqual(gotIssues, tt.wantIssues) {
+				t.Errorf("parseAssetsFile() issues = %q, want %q", gotIssues, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestValidateJobURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When URL is a https URL",
+			url:  `"https://api.gemini.com/v1/pubticker/ethusd"`,
+		},
+		{
+			name:    "Test 2: When URL has no host",
+			url:     `"api.gemini.com/v1/pubticker/ethusd"`,
+			wantErr: true,
+		},
+		{
+			name: "Test 3: When URL is a websocket data source",
+			url:  `{"type":"websocket","url":"wss://ws.kraken.com","body":{"event":"subscribe"}}`,
+		},
+		{
+			name:    "Test 4: When websocket data source has a http URL",
+			url:     `{"type":"websocket","url":"https://ws.kraken.com"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test 5: When data source type is not registered",
+			url:     `{"type":"PUT","url":"https://api.gemini.com"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test 6: When data source has an unknown field",
+			url:     `{"type":"GET","url":"https://api.gemini.com","headers":{}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test 7: When URL is neither a string nor an object",
+			url:     `5`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJobURL(json.RawMessage(tt.url))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateJobURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckOfficialJobIds(t *testing.T) {
+	jobsCache := cache.NewJobsCache()
+	jobsCache.UpdateJob(1, bindings.StructsJob{Id: 1})
+	jobsCache.UpdateJob(2, bindings.StructsJob{Id: 2})
+	collection := bindings.StructsCollection{Id: 1, Name: "ETHUSD", JobIDs: []uint16{1}}
+
+	collectionData := assetsFileCollection{
+		OfficialJobs: map[string]assetsFileJob{"1": {}, "2": {}, "3": {}, "one": {}},
+	}
+	want := []string{
+		"collection ETHUSD: official job 2 is not a job of the collection and is ignored",
+		"collection ETHUSD: official job 3 is not present on chain",
+	}
+	if got := checkOfficialJobIds(collection, collectionData, jobsCache); !reflect.DeepEqual(got, want) {
+		t.Errorf("checkOfficialJobIds() = %q, want %q", got, want)
+	}
+}
+
+func TestCalculateDeviation(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         *big.Int
+		previousValue *big.Int
+		want          *float64
+	}{
+		{
+			name:          "Test 1: When value is higher than previous value",
+			value:         big.NewInt(1050),
+			previousValue: big.NewInt(1000),
+			want:          floatPointer(5),
+		},
+		{
+			name:          "Test 2: When value is lower than negative previous value",
+			value:         big.NewInt(-110),
+			previousValue: big.NewInt(-100),
+			want:          floatPointer(-10),
+		},
+		{
+			name:          "Test 3: When there is no previous value",
+			value:         big.NewInt(1050),
+			previousValue: nil,
+		},
+		{
+			name:          "Test 4: When previous value is 0",
+			value:         big.NewInt(1050),
+			previousValue: big.NewInt(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateDeviation(tt.value, tt.previousValue); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calculateDeviation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func floatPointer(value float64) *float64 {
+	return &value
+}
@@ -14,6 +14,9 @@ import (
 	"razor/cache"
 	"razor/core"
 	"razor/core/types"
+	"razor/journal"
+	"razor/metrics"
+	"razor/notifier"
 	"razor/pkg/bindings"
 	"razor/rpc"
 	"razor/utils"
@@ -40,6 +43,9 @@ Example:
 	Run: initializeVote,
 }
 
+// errStakerSlashed is returned by Vote once the staker is slashed, as a slashed staker can't vote anymore
+var errStakerSlashed = errors.New("staker is slashed")
+
 //This function initialises the ExecuteVote function
 func initializeVote(cmd *cobra.Command, args []string) {
 	cmdUtils.ExecuteVote(cmd.Flags())
@@ -74,6 +80,14 @@ func (*UtilsStruct) ExecuteVote(flagSet *pflag.FlagSet) {
 		log.Warn("YOU ARE RUNNING VOTE IN ROGUE MODE, THIS CAN INCUR PENALTIES!")
 	}
 
+	isDryRun, err := flagSetUtils.GetBoolDryRun(flagSet)
+	utils.CheckError("Error in getting dry run status: ", err)
+	log.Debug("ExecuteVote: IsDryRun: ", isDryRun)
+	utils.DryRun = isDryRun
+	if isDryRun {
+		log.Warn("Running vote in dry run mode, transactions will be simulated and logged but never broadcast")
+	}
+
 	httpClient := &http.Client{
 		Timeout: time.Duration(config.HTTPTimeout) * time.Second,
 		Transport: &http.Transport{
@@ -81,6 +95,7 @@ func (*UtilsStruct) ExecuteVote(flagSet *pflag.FlagSet) {
 			MaxIdleConnsPerHost: core.HTTPClientMaxIdleConnsPerHost,
 		},
 	}
+	startNotifier(httpClient)
 
 	stakerId, err := razorUtils.GetStakerId(rpcParameters, account.Address)
 	utils.CheckError("Error in getting staker id: ", err)
@@ -91,6 +106,48 @@ func (*UtilsStruct) ExecuteVote(flagSet *pflag.FlagSet) {
 
 	cmdUtils.HandleExit()
 
+	if razorUtils.IsFlagPassed("exposeMetrics") {
+		port, err := flagSetUtils.GetStringExposeMetrics(flagSet)
+		utils.CheckError("Error in getting metrics port: ", err)
+		certFile, err := flagSetUtils.GetStringCertFile(flagSet)
+		utils.CheckError("Error in getting cert file: ", err)
+		certKey, err := flagSetUtils.GetStringCertKey(flagSet)
+		utils.CheckError("Error in getting cert key: ", err)
+		startMetricsServer(port, certFile, certKey)
+	}
+
+	metrics.NodeStatusStore.Update(func(status *metrics.NodeStatus) {
+		status.Address = account.Address
+		status.StakerId = stakerId
+	})
+	metrics.NodeStatusStore.SetEndpointsSource(func() []metrics.EndpointStatus {
+		return getEndpointsStatus(rpcParameters.RPCManager, true)
+	})
+
+	sFuelBalanceGuard, err = getBalanceGuard(flagSet, config)
+	utils.CheckError("Error in setting up sFUEL balance guard: ", err)
+
+	autoCompoundingPolicy, err = getCompoundingPolicy(flagSet)
+	utils.CheckError("Error in setting up auto compounding: ", err)
+
+	utils.ResponseRecorder, err = getResponseRecorder(flagSet)
+	utils.CheckError("Error in setting up response recording: ", err)
+
+	leaseFilePath, err := flagSetUtils.GetStringLeaseFile(flagSet)
+	utils.CheckError("Error in getting lease file path: ", err)
+	if leaseFilePath != "" {
+		startLeaseElector(leaseFilePath)
+	}
+
+	if !isDryRun {
+		defaultStakerState.epochJournal, err = openEpochJournal(account.Address)
+		if err != nil {
+			log.Error("Error in opening epoch journal, recovery will only use data files: ", err)
+		}
+
+		startTransactionManager(rpcParameters, config)
+	}
+
 	jobsCache, collectionsCache, initCacheBlockNumber, err := cmdUtils.InitJobAndCollectionCache(rpcParameters)
 	utils.CheckError("Error in initializing asset cache: ", err)
 
@@ -102,13 +159,35 @@ func (*UtilsStruct) ExecuteVote(flagSet *pflag.FlagSet) {
 		FromBlockToCheckForEvents: initCacheBlockNumber,
 	}
 
+	err = startConfigReloader(config)
+	if err != nil {
+		log.Error("Error in watching config files, changes to them will be applied after a restart: ", err)
+	}
+
 	log.Debugf("Calling Vote() with arguments rogueData = %+v, account address = %s, backup node actions to ignore = %s", rogueData, account.Address, backupNodeActionsToIgnore)
 	if err := cmdUtils.Vote(rpcParameters, blockMonitor, config, account, stakerId, commitParams, rogueData, backupNodeActionsToIgnore); err != nil {
+		if errors.Is(err, errStakerSlashed) {
+			osUtils.Exit(0)
+		}
 		log.Errorf("%v\n", err)
 		osUtils.Exit(1)
 	}
 }
 
+//This function starts the metrics and status http server in the background if a port is given
+func startMetricsServer(port string, certFile string, certKey string) {
+	if port == "" {
+		log.Debug("No port given for metrics server, not exposing metrics and status")
+		return
+	}
+	go func() {
+		err := metrics.Run(port, certFile, certKey)
+		if err != nil {
+			log.Error("Failed to start metrics http server: ", err)
+		}
+	}()
+}
+
 //This function handles the exit and listens for CTRL+C
 func (*UtilsStruct) HandleExit() {
 	// listen for CTRL+C
@@ -137,35 +216,43 @@ func (*UtilsStruct) HandleExit() {
 func (*UtilsStruct) Vote(rpcParameters rpc.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, account types.Account, stakerId uint32, commitParams *types.CommitParams, rogueData types.Rogue, backupNodeActionsToIgnore []string) error {
 	header, err := clientUtils.GetLatestBlockWithRetry(rpcParameters)
 	utils.CheckError("Error in getting block: ", err)
+	// The block monitor pushes every new latest block, a block which arrives while a block is handled replaces the older pending one
+	headers := blockMonitor.Subscribe()
+	defer blockMonitor.Unsubscribe(headers)
+	wasStandby := false
 	for {
 		select {
 		case <-rpcParameters.Ctx.Done():
 			return nil
-		default:
+		case latestHeader := <-headers:
 			log.Debugf("Vote: Header value: %d", header.Number)
-			latestHeader := blockMonitor.GetLatestBlock()
-			if latestHeader == nil {
-				log.Error("Block monitor returned nil header")
-				continue
-			}
 			log.Debugf("Vote: Latest header value: %d", latestHeader.Number)
 			if latestHeader.Number.Cmp(header.Number) != 0 {
 				header = latestHeader
-				cmdUtils.HandleBlock(rpcParameters, account, stakerId, latestHeader, config, commitParams, rogueData, backupNodeActionsToIgnore)
+				if reloader != nil {
+					// Changed config files are applied between blocks, never while a state is being handled
+					config = reloader.reload(rpcParameters, commitParams)
+				}
+				if isStandby() {
+					// The leader sends the transactions, the standby takes over once the leader stops renewing the lease
+					logStandby()
+					wasStandby = true
+				} else {
+					if wasStandby {
+						getStakerState(rpcParameters.Ctx).reloadAfterTakeover(account.Address)
+						wasStandby = false
+					}
+					processPendingTransactions(rpcParameters, latestHeader)
+					cmdUtils.HandleBlock(rpcParameters, account, stakerId, latestHeader, config, commitParams, rogueData, backupNodeActionsToIgnore)
+					if getStakerState(rpcParameters.Ctx).isSlashed {
+						return errStakerSlashed
+					}
+				}
 			}
-			time.Sleep(time.Second * time.Duration(core.BlockNumberInterval))
 		}
 	}
 }
 
-var (
-	globalCommitDataStruct types.CommitFileData
-	lastVerification       uint32
-	blockConfirmed         uint32
-	disputeData            types.DisputeFileData
-	lastRPCRefreshEpoch    uint32
-)
-
 //This function handles the block
 func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.Account, stakerId uint32, latestHeader *Types.Header, config types.Configurations, commitParams *types.CommitParams, rogueData types.Rogue, backupNodeActionsToIgnore []string) {
 	stateBuffer, err := razorUtils.GetStateBuffer(rpcParameters)
@@ -182,7 +269,9 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(remainingTimeOfTheCurrentState)*time.Second)
+	// The context of the staker carries its voting state, so it is carried over to the replaced context
+	voteState := getStakerState(rpcParameters.Ctx)
+	ctx, cancel := context.WithTimeout(withStakerState(context.Background(), voteState), time.Duration(remainingTimeOfTheCurrentState)*time.Second)
 	defer cancel()
 
 	// Replacing context with the context which timeouts after remainingTimeOfTheCurrentState seconds
@@ -198,6 +287,7 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 		log.Error("Error in getting epoch: ", err)
 		return
 	}
+	voteState.trimEpochJournal(epoch)
 
 	staker, err := razorUtils.GetStaker(rpcParameters, stakerId)
 	if err != nil {
@@ -215,7 +305,9 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 	// Warning the staker if sFUEL balance is less than 0.001 sFUEL
 	if ethBalance.Cmp(big.NewInt(1e15)) == -1 {
 		log.Warn("sFUEL balance is lower than 0.001 sFUEL, kindly add more sFUEL to be safe for executing transactions successfully")
+		eventNotifier.Notify(notifier.EventLowSFuelBalance, account.Address, epoch, "sFUEL balance is lower than 0.001 sFUEL, kindly add more sFUEL to be safe for executing transactions successfully")
 	}
+	isLowOnFunds := guardSFuelBalance(rpcParameters, config, account, epoch, ethBalance)
 
 	actualStake, err := utils.ConvertWeiToEth(stakedAmount)
 	if err != nil {
@@ -247,63 +339,108 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 
 	log.Infof("State: %s Staker ID: %d Stake: %f sRZR Balance: %f sFUEL Balance: %f", utils.GetStateName(state), stakerId, actualStake, sRZRInEth, actualBalance)
 
+	bestEndpointURL, _ := rpcParameters.RPCManager.GetBestEndpointURL()
+	if bestEndpointURL != "" {
+		// The status is served over HTTP, so the URL which may hold an API key is redacted
+		bestEndpointURL = metrics.EndpointLabel(bestEndpointURL)
+	}
+	voteState.statusStore().Update(func(status *metrics.NodeStatus) {
+		status.StakerId = stakerId
+		status.Epoch = epoch
+		status.State = state
+		status.StateName = utils.GetStateName(state)
+		status.BlockNumber = latestHeader.Number.String()
+		status.BestRPCEndpoint = bestEndpointURL
+	})
+	voteState.statusStore().SetBalances(stakedAmount, sRZRBalance, ethBalance)
+	metrics.CurrentEpochMetric.Set(float64(epoch))
+	defer updateVoteStatus(voteState)
+
 	if staker.IsSlashed {
 		log.Error("Staker is slashed.... cannot continue to vote!")
-		osUtils.Exit(0)
+		eventNotifier.Notify(notifier.EventStakerSlashed, account.Address, epoch, "Staker is slashed, cannot continue to vote")
+		// Waiting for the notification to be sent before stopping
+		eventNotifier.Wait()
+		// Only the voting of this staker is stopped, the supervisor keeps voting for its other stakers
+		voteState.isSlashed = true
+		return
 	}
 
 	switch state {
 	case 0:
 		log.Debugf("Starting commit...")
 		err := cmdUtils.InitiateCommit(rpcParameters, config, account, epoch, stakerId, latestHeader, commitParams, stateBuffer, rogueData)
+		metrics.ObserveVoteAction("commit", epoch, err)
 		if err != nil {
 			log.Error(err)
+			eventNotifier.Notify(notifier.EventCommitFailed, account.Address, epoch, err.Error())
+			voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 			break
 		}
 	case 1:
 		log.Debugf("Starting reveal...")
 		err := cmdUtils.InitiateReveal(rpcParameters, config, account, epoch, staker, latestHeader, stateBuffer, rogueData)
+		metrics.ObserveVoteAction("reveal", epoch, err)
 		if err != nil {
 			log.Error(err)
+			eventNotifier.Notify(notifier.EventRevealFailed, account.Address, epoch, err.Error())
+			voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 			break
 		}
 	case 2:
 		log.Debugf("Starting propose...")
 		err := cmdUtils.InitiatePropose(rpcParameters, config, account, epoch, staker, latestHeader, stateBuffer, rogueData)
+		metrics.ObserveVoteAction("propose", epoch, err)
 		if err != nil {
 			log.Error(err)
+			voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 			break
 		}
 	case 3:
-		log.Debugf("Last verification: %d", lastVerification)
-		if lastVerification >= epoch {
-			log.Debugf("Last verification (%d) is greater or equal to current epoch (%d)", lastVerification, epoch)
+		log.Debugf("Last verification: %d", voteState.lastVerification)
+		if voteState.lastVerification >= epoch {
+			log.Debugf("Last verification (%d) is greater or equal to current epoch (%d)", voteState.lastVerification, epoch)
 			log.Debugf("Won't dispute now")
 			break
 		}
 
 		err := cmdUtils.HandleDispute(rpcParameters, config, account, epoch, latestHeader.Number, rogueData, backupNodeActionsToIgnore)
+		metrics.ObserveVoteAction("dispute", epoch, err)
 		if err != nil {
 			log.Error(err)
+			voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 			break
 		}
 
-		lastVerification = epoch
+		voteState.lastVerification = epoch
 
 		if razorUtils.IsFlagPassed("autoClaimBounty") {
+			if isLowOnFunds {
+				log.Warn("Not claiming bounty as sFUEL balance is too low")
+				break
+			}
 			log.Debugf("Automatically claiming bounty")
 			err = cmdUtils.HandleClaimBounty(rpcParameters, config, account)
 			if err != nil {
 				log.Error(err)
+				voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 				break
 			}
 		}
 
 	case 4:
-		log.Debugf("Last verification: %d", lastVerification)
-		log.Debugf("Block confirmed: %d", blockConfirmed)
+		log.Debugf("Last verification: %d", voteState.lastVerification)
+		log.Debugf("Block confirmed: %d", voteState.blockConfirmed)
+
+		if voteState.lastDisputeCheck < epoch {
+			if _, err := checkProposedBlockDisputed(rpcParameters, account, stakerId, epoch); err != nil {
+				log.Error("Error in checking whether the proposed block was disputed: ", err)
+			} else {
+				voteState.lastDisputeCheck = epoch
+			}
+		}
 
-		if blockConfirmed >= epoch {
+		if voteState.blockConfirmed >= epoch {
 			log.Debug("Block is already confirmed for this epoch!")
 			break
 		}
@@ -311,15 +448,16 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 		confirmedBlock, err := razorUtils.GetConfirmedBlocks(rpcParameters, epoch)
 		if err != nil {
 			log.Error(err)
+			voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 			break
 		}
 
 		if confirmedBlock.ProposerId != 0 {
-			log.Infof("Block is already confirmed, setting blockConfirmed (%d) to current epoch (%d)", blockConfirmed, epoch)
-			blockConfirmed = epoch
+			log.Infof("Block is already confirmed, setting blockConfirmed (%d) to current epoch (%d)", voteState.blockConfirmed, epoch)
+			voteState.blockConfirmed = epoch
 			break
 		}
-		if (lastVerification == epoch || lastVerification == 0) && blockConfirmed < epoch {
+		if (voteState.lastVerification == epoch || voteState.lastVerification == 0) && voteState.blockConfirmed < epoch {
 			txn, err := cmdUtils.ClaimBlockReward(rpcParameters, types.TransactionOptions{
 				ChainId:         core.ChainId,
 				Config:          config,
@@ -328,16 +466,18 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 				ABI:             bindings.BlockManagerMetaData.ABI,
 				Account:         account,
 			})
+			metrics.ObserveVoteAction("claimBlockReward", epoch, err)
 
 			if err != nil {
 				log.Error("ClaimBlockReward error: ", err)
+				voteState.statusStore().SetError(utils.GetStateName(state), epoch, err)
 				break
 			}
 			if txn != core.NilHash {
 				log.Info("Confirm Transaction Hash: ", txn)
 			}
 
-			if lastRPCRefreshEpoch < epoch {
+			if voteState.lastRPCRefreshEpoch < epoch {
 				err = rpcParameters.RPCManager.RefreshEndpoints()
 				if err != nil {
 					log.Error("Error in refreshing RPC endpoints: ", err)
@@ -350,7 +490,7 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 				}
 				log.Info("Current best RPC endpoint URL: ", bestEndpointURL)
 
-				lastRPCRefreshEpoch = epoch
+				voteState.lastRPCRefreshEpoch = epoch
 			}
 		}
 	case -1:
@@ -359,6 +499,10 @@ func (*UtilsStruct) HandleBlock(rpcParameters rpc.RPCParameters, account types.A
 			return
 		}
 	}
+	if state == 4 && !isLowOnFunds {
+		// Compounding in the confirm state, after the block reward of the epoch is claimed
+		compoundRewards(rpcParameters, config, account, stakerId, epoch)
+	}
 	razorUtils.WaitTillNextNSecs(config.WaitTime)
 	fmt.Println()
 }
@@ -370,6 +514,8 @@ func (*UtilsStruct) InitiateCommit(rpcParameters rpc.RPCParameters, config types
 		return errors.New("Error in fetching last commit: " + err.Error())
 	}
 	log.Debug("InitiateCommit: Epoch last committed: ", lastCommit)
+	getStakerState(rpcParameters.Ctx).statusStore().Update(func(status *metrics.NodeStatus) { status.LastCommittedEpoch = lastCommit })
+	metrics.StakerLastActionEpochMetric.WithLabelValues("commit").Set(float64(lastCommit))
 
 	if lastCommit >= epoch {
 		// Clearing up the cache storing API results as the staker has already committed successfully
@@ -398,6 +544,7 @@ func (*UtilsStruct) InitiateCommit(rpcParameters rpc.RPCParameters, config types
 	log.Debug("InitiateCommit: Minimum stake amount: ", minStakeAmount)
 	if stakedAmount.Cmp(minStakeAmount) < 0 {
 		log.Error("Stake is below minimum required. Kindly add stake to continue voting.")
+		eventNotifier.Notify(notifier.EventStakeBelowMinimum, account.Address, epoch, "Stake is below minimum required, kindly add stake to continue voting")
 		return nil
 	}
 	razorPath, err := pathUtils.GetDefaultPath()
@@ -426,28 +573,52 @@ func (*UtilsStruct) InitiateCommit(rpcParameters rpc.RPCParameters, config types
 		return err
 	}
 
+	if utils.Lease != nil {
+		// The commit of another node of the staker which held the lease before this node could have been mined while the commitment was calculated
+		lastCommit, err = razorUtils.GetEpochLastCommitted(rpcParameters, stakerId)
+		if err != nil {
+			return errors.New("Error in fetching last commit: " + err.Error())
+		}
+		if lastCommit >= epoch {
+			log.Infof("Staker has already committed in epoch %d from another node, won't commit again", epoch)
+			return nil
+		}
+	}
+
 	commitTxn, err := cmdUtils.Commit(rpcParameters, config, account, epoch, latestHeader, stateBuffer, commitmentToSend)
 	if err != nil {
 		return errors.New("Error in committing data: " + err.Error())
 	}
 	log.Info("InitiateCommit: Commit Transaction Hash: ", commitTxn)
 	if commitTxn != core.NilHash {
-		log.Debug("Saving committed data for recovery...")
-		fileName, err := pathUtils.GetCommitDataFileName(account.Address)
-		if err != nil {
-			return errors.New("Error in getting file name to save committed data: " + err.Error())
-		}
-		log.Debug("InitiateCommit: Commit data file path: ", fileName)
+		log.Debug("Recording committed data in epoch journal...")
+		state := getStakerState(rpcParameters.Ctx)
+		state.recordInEpochJournal(epoch, journal.KindCommit, types.CommitFileData{
+			Epoch:                  epoch,
+			AssignedCollections:    commitData.AssignedCollections,
+			SeqAllottedCollections: commitData.SeqAllottedCollections,
+			Leaves:                 commitData.Leaves,
+			Commitment:             commitmentToSend,
+		})
+
+		if !utils.DryRun {
+			log.Debug("Saving committed data for recovery...")
+			fileName, err := pathUtils.GetCommitDataFileName(account.Address)
+			if err != nil {
+				return errors.New("Error in getting file name to save committed data: " + err.Error())
+			}
+			log.Debug("InitiateCommit: Commit data file path: ", fileName)
 
-		err = fileUtils.SaveDataToCommitJsonFile(fileName, epoch, commitData, commitmentToSend)
-		if err != nil {
-			return errors.New("Error in saving data to file" + fileName + ": " + err.Error())
+			err = fileUtils.SaveDataToCommitJsonFile(fileName, epoch, commitData, commitmentToSend)
+			if err != nil {
+				return errors.New("Error in saving data to file" + fileName + ": " + err.Error())
+			}
+			log.Debug("Data saved!")
 		}
-		log.Debug("Data saved!")
 
 		log.Debug("Updating GlobalCommitDataStruct with latest commitData and epoch...")
-		updateGlobalCommitDataStruct(commitData, commitmentToSend, epoch)
-		log.Debugf("InitiateCommit: Global commit data struct: %+v", globalCommitDataStruct)
+		state.updateCommitData(commitData, commitmentToSend, epoch)
+		log.Debugf("InitiateCommit: Global commit data struct: %+v", state.commitData)
 	}
 	return nil
 }
@@ -464,6 +635,7 @@ func (*UtilsStruct) InitiateReveal(rpcParameters rpc.RPCParameters, config types
 	log.Debug("InitiateReveal: Minimum Stake Amount: ", minStakeAmount)
 	if stakedAmount.Cmp(minStakeAmount) < 0 {
 		log.Error("Stake is below minimum required. Kindly add stake to continue voting.")
+		eventNotifier.Notify(notifier.EventStakeBelowMinimum, account.Address, epoch, "Stake is below minimum required, kindly add stake to continue voting")
 		return nil
 	}
 	lastReveal, err := razorUtils.GetEpochLastRevealed(rpcParameters, staker.Id)
@@ -471,6 +643,8 @@ func (*UtilsStruct) InitiateReveal(rpcParameters rpc.RPCParameters, config types
 		return errors.New("Error in fetching last reveal: " + err.Error())
 	}
 	log.Debug("InitiateReveal: Last reveal was at epoch ", lastReveal)
+	getStakerState(rpcParameters.Ctx).statusStore().Update(func(status *metrics.NodeStatus) { status.LastRevealedEpoch = lastReveal })
+	metrics.StakerLastActionEpochMetric.WithLabelValues("reveal").Set(float64(lastReveal))
 
 	if lastReveal >= epoch {
 		log.Debugf("Since last reveal was at epoch: %d, won't reveal again in epoch: %d", lastReveal, epoch)
@@ -518,6 +692,14 @@ func (*UtilsStruct) InitiateReveal(rpcParameters rpc.RPCParameters, config types
 		return errors.New("Reveal error: " + err.Error())
 	}
 	log.Info("InitiateReveal: Reveal Transaction Hash: ", revealTxn)
+	if revealTxn != core.NilHash {
+		getStakerState(rpcParameters.Ctx).recordInEpochJournal(epoch, journal.KindReveal, types.RevealJournalData{
+			RevealedValues:         commitDataToSend.Leaves,
+			AssignedCollections:    commitDataToSend.AssignedCollections,
+			SeqAllottedCollections: commitDataToSend.SeqAllottedCollections,
+			TxnHash:                revealTxn.Hex(),
+		})
+	}
 	return nil
 }
 
@@ -533,6 +715,7 @@ func (*UtilsStruct) InitiatePropose(rpcParameters rpc.RPCParameters, config type
 	log.Debug("InitiatePropose: Minimum Stake Amount: ", minStakeAmount)
 	if stakedAmount.Cmp(minStakeAmount) < 0 {
 		log.Error("Stake is below minimum required. Kindly add stake to continue voting.")
+		eventNotifier.Notify(notifier.EventStakeBelowMinimum, account.Address, epoch, "Stake is below minimum required, kindly add stake to continue voting")
 		return nil
 	}
 	lastProposal, err := razorUtils.GetEpochLastProposed(rpcParameters, staker.Id)
@@ -540,6 +723,8 @@ func (*UtilsStruct) InitiatePropose(rpcParameters rpc.RPCParameters, config type
 		return errors.New("Error in fetching last proposal: " + err.Error())
 	}
 	log.Debug("InitiatePropose: Last propose was in epoch ", lastProposal)
+	getStakerState(rpcParameters.Ctx).statusStore().Update(func(status *metrics.NodeStatus) { status.LastProposedEpoch = lastProposal })
+	metrics.StakerLastActionEpochMetric.WithLabelValues("propose").Set(float64(lastProposal))
 	if lastProposal >= epoch {
 		log.Debugf("Since last propose was at epoch: %d, won't propose again in epoch: %d", epoch, lastProposal)
 		return nil
@@ -569,10 +754,8 @@ func (*UtilsStruct) CalculateSecret(account types.Account, epoch uint32, keystor
 	}
 	hash := solsha3.SoliditySHA3([]string{"address", "uint32", "uint256", "string"}, []interface{}{common.HexToAddress(account.Address), epoch, chainId, "razororacle"})
 	log.Debug("CalculateSecret: Hash: ", hash)
-	ethHash := utils.SignHash(hash)
 	log.Debug("Hash generated for secret")
-	log.Debug("CalculateSecret: Ethereum signed hash: ", ethHash)
-	signedData, err := account.AccountManager.SignData(ethHash, account.Address, account.Password)
+	signedData, err := account.AccountManager.SignMessage(hash, account.Address, account.Password)
 	if err != nil {
 		return nil, nil, errors.New("Error in signing the data: " + err.Error())
 	}
@@ -596,13 +779,12 @@ func (*UtilsStruct) CalculateSecret(account types.Account, epoch uint32, keystor
 	return signedData, secret, nil
 }
 
-func updateGlobalCommitDataStruct(commitData types.CommitData, commitment [32]byte, epoch uint32) types.CommitFileData {
-	globalCommitDataStruct.Leaves = commitData.Leaves
-	globalCommitDataStruct.AssignedCollections = commitData.AssignedCollections
-	globalCommitDataStruct.SeqAllottedCollections = commitData.SeqAllottedCollections
-	globalCommitDataStruct.Epoch = epoch
-	globalCommitDataStruct.Commitment = commitment
-	return globalCommitDataStruct
+//This function updates the node status with the verification and confirmation epochs tracked by the vote loop
+func updateVoteStatus(state *stakerState) {
+	state.statusStore().Update(func(status *metrics.NodeStatus) {
+		status.LastVerification = state.lastVerification
+		status.BlockConfirmed = state.blockConfirmed
+	})
 }
 
 func init() {
@@ -615,6 +797,19 @@ func init() {
 		Password        string
 		AutoClaimBounty bool
 		BackupNode      []string
+		ExposeMetrics   string
+		CertFile        string
+		CertKey         string
+		DryRun          bool
+		LeaseFile       string
+		FundingAccount  string
+		FundingPassword string
+		MinSFuelBalance string
+		TopUpAmount     string
+		AutoCompound    bool
+		CompoundReserve string
+		MaxCompound     string
+		RecordResponses bool
 	)
 
 	voteCmd.Flags().StringVarP(&Address, "address", "a", "", "address of the staker")
@@ -623,6 +818,19 @@ func init() {
 	voteCmd.Flags().StringVarP(&Password, "password", "", "", "password path of the staker to protect the keystore")
 	voteCmd.Flags().BoolVarP(&AutoClaimBounty, "autoClaimBounty", "", false, "auto claim bounty")
 	voteCmd.Flags().StringSliceVarP(&BackupNode, "backupNode", "", []string{}, "actions that backup node will ignore")
+	voteCmd.Flags().StringVarP(&ExposeMetrics, "exposeMetrics", "", "", "port number to expose metrics and node status")
+	voteCmd.Flags().StringVarP(&CertFile, "certFile", "", "", "ssl certificate path")
+	voteCmd.Flags().StringVarP(&CertKey, "certKey", "", "", "ssl certificate key path")
+	voteCmd.Flags().BoolVarP(&DryRun, "dryRun", "", false, "simulate and log transactions without broadcasting them")
+	voteCmd.Flags().StringVarP(&LeaseFile, "leaseFile", "", "", "path of the lease file on storage shared by the redundant vote nodes of the staker")
+	voteCmd.Flags().StringVarP(&FundingAccount, "fundingAccount", "", "", "address of the account which tops up the sFUEL balance of the staker")
+	voteCmd.Flags().StringVarP(&FundingPassword, "fundingPassword", "", "", "password path of the funding account")
+	voteCmd.Flags().StringVarP(&MinSFuelBalance, "minSFuelBalance", "", "", "sFUEL balance below which the balance is topped up and non-essential transactions are paused")
+	voteCmd.Flags().StringVarP(&TopUpAmount, "topUpAmount", "", "", "sFUEL transferred from the funding account in a top up, defaults to minSFuelBalance")
+	voteCmd.Flags().BoolVarP(&AutoCompound, "autoCompound", "", false, "claim the commission and stake the RZR balance above the reserve in every epoch")
+	voteCmd.Flags().StringVarP(&CompoundReserve, "compoundReserve", "", "", "RZR balance which is kept and not staked by auto compounding")
+	voteCmd.Flags().StringVarP(&MaxCompound, "maxCompoundAmount", "", "", "maximum RZR staked by auto compounding in an epoch")
+	voteCmd.Flags().BoolVarP(&RecordResponses, "recordResponses", "", false, "record the raw responses of the job data sources of every epoch in the data directory to replay them later")
 
 	addrErr := voteCmd.MarkFlagRequired("address")
 	utils.CheckError("Address error: ", addrErr)
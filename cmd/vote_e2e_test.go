@@ -0,0 +1,182 @@
+//go:build e2e
+
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"razor/cache"
+	"razor/core"
+	"razor/core/types"
+	"razor/e2e"
+	"razor/rpc"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestVoteEndToEnd stakes the test accounts on a devnet and runs the voting of both stakers through every state of
+// several epochs, checking that each of them commits and reveals in every epoch. In the last epoch the second staker
+// proposes in rogue mode, its block has to be disputed by the first staker and the block of the first staker confirmed.
+func TestVoteEndToEnd(t *testing.T) {
+	const epochs = 4
+
+	stakers := e2e.TestAccounts(t)
+	stakerAddresses := make([]common.Address, len(stakers))
+	for i, staker := range stakers {
+		stakerAddresses[i] = common.HexToAddress(staker.Address)
+	}
+
+	devnet := e2e.NewDevnet(t, stakerAddresses...)
+	devnet.SetContractAddresses(t)
+	// Data files and epoch journals are written to the home directory
+	t.Setenv("HOME", t.TempDir())
+	InitializeInterfaces()
+
+	rpcManager := &rpc.RPCManager{Endpoints: []*rpc.RPCEndpoint{{URL: devnet.URL}}}
+	if err := rpcManager.RefreshEndpoints(); err != nil {
+		t.Fatalf("Error in refreshing endpoints: %v", err)
+	}
+	e2eRPCParameters := rpc.RPCParameters{Ctx: context.Background(), RPCManager: rpcManager}
+	config := types.Configurations{
+		GasMultiplier:      core.DefaultGasMultiplier,
+		BufferPercent:      core.DefaultBufferPercent,
+		GasLimitMultiplier: core.DefaultGasLimit,
+		GasLimitOverride:   core.DefaultGasLimitOverride,
+		RPCTimeout:         core.DefaultRPCTimeout,
+		HTTPTimeout:        core.DefaultHTTPTimeout,
+	}
+
+	jobServer := e2e.NewJobServer(t)
+	devnet.CreateJob(t, "ethusd", jobServer.SetValue("/ethusd", 2000), "value")
+	devnet.CreateJob(t, "btcusd", jobServer.SetValue("/btcusd", 60000), "value")
+	devnet.AdvanceToState(t, 4)
+	devnet.CreateCollection(t, "ethCollection", []uint16{1})
+	devnet.CreateCollection(t, "btcCollection", []uint16{2})
+
+	stakeAmount := new(big.Int).Mul(big.NewInt(1e6), big.NewInt(1e18))
+	devnet.FundRazor(t, stakeAmount, stakerAddresses...)
+	stakerIds := make([]uint32, len(stakers))
+	for i, staker := range stakers {
+		stakerIds[i] = stakeOnDevnet(t, e2eRPCParameters, config, staker, stakeAmount)
+	}
+
+	jobsCache, collectionsCache, initCacheBlockNumber, err := cmdUtils.InitJobAndCollectionCache(e2eRPCParameters)
+	if err != nil {
+		t.Fatalf("Error in initializing asset cache: %v", err)
+	}
+
+	// As in the supervisor, every staker has its own voting state while the caches are shared
+	stakerRPCParameters := make([]rpc.RPCParameters, len(stakers))
+	stakerCommitParams := make([]*types.CommitParams, len(stakers))
+	for i := range stakers {
+		stakerRPCParameters[i] = e2eRPCParameters
+		stakerRPCParameters[i].Ctx = withStakerState(context.Background(), &stakerState{})
+		stakerCommitParams[i] = &types.CommitParams{
+			LocalCache:                cache.NewLocalCache(),
+			JobsCache:                 jobsCache,
+			CollectionsCache:          collectionsCache,
+			HttpClient:                &http.Client{Timeout: time.Duration(config.HTTPTimeout) * time.Second},
+			FromBlockToCheckForEvents: initCacheBlockNumber,
+		}
+	}
+
+	var lastEpoch uint32
+	for i := 0; i < epochs; i++ {
+		rogueData := make([]types.Rogue, len(stakers))
+		if i == epochs-1 {
+			rogueData[1] = types.Rogue{IsRogue: true, RogueMode: []string{"medians"}}
+		}
+		for state := int64(0); state < int64(core.NumberOfStates); state++ {
+			header := devnet.AdvanceToState(t, state)
+			for j, staker := range stakers {
+				cmdUtils.HandleBlock(stakerRPCParameters[j], staker, stakerIds[j], header, config, stakerCommitParams[j], rogueData[j], nil)
+			}
+		}
+		lastEpoch, err = razorUtils.GetEpoch(e2eRPCParameters)
+		if err != nil {
+			t.Fatalf("Error in getting epoch: %v", err)
+		}
+
+		for j, stakerId := range stakerIds {
+			lastCommitted, err := razorUtils.GetEpochLastCommitted(e2eRPCParameters, stakerId)
+			if err != nil {
+				t.Fatalf("Error in getting last committed epoch of staker %d: %v", stakerId, err)
+			}
+			lastRevealed, err := razorUtils.GetEpochLastRevealed(e2eRPCParameters, stakerId)
+			if err != nil {
+				t.Fatalf("Error in getting last revealed epoch of staker %d: %v", stakerId, err)
+			}
+			if lastCommitted != lastEpoch || lastRevealed != lastEpoch {
+				t.Errorf("Staker %d (%s) committed in epoch %d and revealed in epoch %d, expected both in epoch %d", stakerId, stakers[j].Address, lastCommitted, lastRevealed, lastEpoch)
+			}
+		}
+	}
+
+	numProposedBlocks, err := razorUtils.GetNumberOfProposedBlocks(e2eRPCParameters, lastEpoch)
+	if err != nil {
+		t.Fatalf("Error in getting number of proposed blocks: %v", err)
+	}
+	var isRogueBlockProposed bool
+	for blockId := uint32(0); blockId < uint32(numProposedBlocks); blockId++ {
+		proposedBlock, err := razorUtils.GetProposedBlock(e2eRPCParameters, lastEpoch, blockId)
+		if err != nil {
+			t.Fatalf("Error in getting proposed block %d: %v", blockId, err)
+		}
+		if proposedBlock.ProposerId != stakerIds[1] {
+			continue
+		}
+		isRogueBlockProposed = true
+		if proposedBlock.Valid {
+			t.Errorf("Expected block %d proposed in rogue mode by staker %d in epoch %d to be disputed", blockId, stakerIds[1], lastEpoch)
+		}
+	}
+	if !isRogueBlockProposed {
+		t.Errorf("Expected staker %d to propose a block in rogue mode in epoch %d", stakerIds[1], lastEpoch)
+	}
+
+	confirmedBlock, err := razorUtils.GetConfirmedBlocks(e2eRPCParameters, lastEpoch)
+	if err != nil {
+		t.Fatalf("Error in getting confirmed block: %v", err)
+	}
+	if confirmedBlock.ProposerId != stakerIds[0] {
+		t.Errorf("Expected the block of epoch %d proposed by staker %d to be confirmed, got proposer %d", lastEpoch, stakerIds[0], confirmedBlock.ProposerId)
+	}
+}
+
+//This function stakes the amount for the account as the addStake command does and returns the id of the staker
+func stakeOnDevnet(t *testing.T, rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, amount *big.Int) uint32 {
+	t.Helper()
+	txnArgs := types.TransactionOptions{
+		Amount:  amount,
+		ChainId: core.ChainId,
+		Config:  config,
+		Account: account,
+	}
+
+	approveTxnHash, err := cmdUtils.Approve(rpcParameters, txnArgs)
+	if err != nil {
+		t.Fatalf("Error in approving stake of %s: %v", account.Address, err)
+	}
+	if approveTxnHash != core.NilHash {
+		if err := razorUtils.WaitForBlockCompletion(rpcParameters, approveTxnHash.Hex()); err != nil {
+			t.Fatalf("Error in approving stake of %s: %v", account.Address, err)
+		}
+	}
+
+	stakeTxnHash, err := cmdUtils.StakeCoins(rpcParameters, txnArgs)
+	if err != nil {
+		t.Fatalf("Error in staking for %s: %v", account.Address, err)
+	}
+	if err := razorUtils.WaitForBlockCompletion(rpcParameters, stakeTxnHash.Hex()); err != nil {
+		t.Fatalf("Error in staking for %s: %v", account.Address, err)
+	}
+
+	stakerId, err := razorUtils.GetStakerId(rpcParameters, account.Address)
+	if err != nil || stakerId == 0 {
+		t.Fatalf("Error in getting staker id of %s: %v", account.Address, err)
+	}
+	return stakerId
+}
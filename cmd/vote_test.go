@@ -13,6 +13,7 @@ import (
 	"razor/block"
 	"razor/cache"
 	"razor/core/types"
+	"razor/lease"
 	"razor/pkg/bindings"
 	"razor/rpc"
 	"razor/utils"
@@ -43,6 +44,8 @@ func TestExecuteVote(t *testing.T) {
 		stakerId     uint32
 		stakerIdErr  error
 		voteErr      error
+		dryRun       bool
+		dryRunErr    error
 	}
 	tests := []struct {
 		name          string
@@ -149,6 +152,28 @@ func TestExecuteVote(t *testing.T) {
 			},
 			expectedFatal: true,
 		},
+		{
+			name: "Test 9: When ExecuteVote() executes successfully in dry run mode",
+			args: args{
+				config:   config,
+				password: "test",
+				address:  "0x000000000000000000000000000000000000dea1",
+				stakerId: 1,
+				dryRun:   true,
+			},
+			expectedFatal: false,
+		},
+		{
+			name: "Test 10: When there is an error in getting dry run status",
+			args: args{
+				config:    config,
+				password:  "test",
+				address:   "0x000000000000000000000000000000000000dea1",
+				stakerId:  1,
+				dryRunErr: errors.New("dry run status error"),
+			},
+			expectedFatal: true,
+		},
 	}
 
 	defer func() { log.LogrusInstance.ExitFunc = nil }()
@@ -169,14 +194,27 @@ func TestExecuteVote(t *testing.T) {
 			utilsMock.On("AccountManagerForKeystore").Return(&accounts.AccountManager{}, nil)
 			flagSetMock.On("GetStringAddress", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.address, tt.args.addressErr)
 			flagSetMock.On("GetStringSliceBackupNode", mock.Anything).Return([]string{}, nil)
+			flagSetMock.On("GetStringExposeMetrics", mock.Anything).Return("", nil)
+			flagSetMock.On("GetStringCertFile", mock.Anything).Return("", nil)
+			flagSetMock.On("GetStringCertKey", mock.Anything).Return("", nil)
+			pathMock.On("GetEpochJournalFileName", mock.Anything).Return("", errors.New("journal path error"))
 			utilsMock.On("ConnectToClient", mock.AnythingOfType("string")).Return(client)
 			flagSetMock.On("GetBoolRogue", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.rogueStatus, tt.args.rogueErr)
 			utilsMock.On("GetStakerId", mock.Anything, mock.Anything).Return(tt.args.stakerId, tt.args.stakerIdErr)
 			flagSetMock.On("GetStringSliceRogueMode", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.rogueMode, tt.args.rogueModeErr)
+			flagSetMock.On("GetBoolDryRun", mock.AnythingOfType("*pflag.FlagSet")).Return(tt.args.dryRun, tt.args.dryRunErr)
+			flagSetMock.On("GetStringLeaseFile", mock.AnythingOfType("*pflag.FlagSet")).Return("", nil)
+			flagSetMock.On("GetStringMinSFuelBalance", mock.AnythingOfType("*pflag.FlagSet")).Return("", nil)
+			flagSetMock.On("GetStringFundingAccount", mock.AnythingOfType("*pflag.FlagSet")).Return("", nil)
+			flagSetMock.On("GetBoolAutoCompound", mock.AnythingOfType("*pflag.FlagSet")).Return(false, nil)
+			flagSetMock.On("GetBoolRecordResponses", mock.AnythingOfType("*pflag.FlagSet")).Return(false, nil)
+			flagSetMock.On("GetStringCompoundReserve", mock.AnythingOfType("*pflag.FlagSet")).Return("", nil)
+			flagSetMock.On("GetStringMaxCompoundAmount", mock.AnythingOfType("*pflag.FlagSet")).Return("", nil)
 			cmdUtilsMock.On("InitJobAndCollectionCache", mock.Anything).Return(&cache.JobsCache{}, &cache.CollectionsCache{}, big.NewInt(100), nil)
 			cmdUtilsMock.On("HandleExit").Return()
 			cmdUtilsMock.On("Vote", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.voteErr)
 			osMock.On("Exit", mock.AnythingOfType("int")).Return()
+			t.Cleanup(func() { utils.DryRun = false })
 
 			utils := &UtilsStruct{}
 			fatal = false
@@ -375,6 +413,8 @@ func TestInitiateCommit(t *testing.T) {
 		fileName          string
 		fileNameErr       error
 		saveErr           error
+		holdLease         bool
+		leaderCommit      uint32
 	}
 	tests := []struct {
 		name    string
@@ -546,6 +586,26 @@ func TestInitiateCommit(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Test 14: When the lease is held and the staker has committed from another node while calculating the commitment",
+			args: args{
+				staker:         bindings.StructsStaker{Id: 1, Stake: big.NewInt(10000)},
+				minStakeAmount: big.NewInt(100),
+				epoch:          5,
+				lastCommit:     2,
+				signature:      []byte{2},
+				secret:         []byte{1},
+				salt:           [32]byte{},
+				commitData: types.CommitData{
+					AssignedCollections:    nil,
+					SeqAllottedCollections: nil,
+					Leaves:                 []*big.Int{big.NewInt(100)},
+				},
+				holdLease:    true,
+				leaderCommit: 5,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -556,7 +616,18 @@ func TestInitiateCommit(t *testing.T) {
 
 			utilsMock.On("GetStaker", mock.Anything, mock.Anything).Return(tt.args.staker, tt.args.stakerErr)
 			utilsMock.On("GetMinStakeAmount", mock.Anything).Return(tt.args.minStakeAmount, tt.args.minStakeAmountErr)
-			utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.Anything).Return(tt.args.lastCommit, tt.args.lastCommitErr)
+			if tt.args.holdLease {
+				elector := lease.NewElector(lease.NewFileBackend(filepath.Join(t.TempDir(), "vote.lease")), "node1", lease.DefaultConfig())
+				if _, err := elector.Renew(); err != nil {
+					t.Fatal(err)
+				}
+				utils.Lease = elector
+				defer func() { utils.Lease = nil }()
+				utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.Anything).Return(tt.args.lastCommit, tt.args.lastCommitErr).Once()
+				utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.Anything).Return(tt.args.leaderCommit, nil)
+			} else {
+				utilsMock.On("GetEpochLastCommitted", mock.Anything, mock.Anything).Return(tt.args.lastCommit, tt.args.lastCommitErr)
+			}
 			cmdUtilsMock.On("CalculateSecret", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.signature, tt.args.secret, tt.args.secretErr)
 			cmdUtilsMock.On("GetSalt", mock.Anything, mock.Anything).Return(tt.args.salt, tt.args.saltErr)
 			cmdUtilsMock.On("HandleCommitState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.args.commitData, tt.args.commitDataErr)
@@ -570,6 +641,9 @@ func TestInitiateCommit(t *testing.T) {
 			if err := ut.InitiateCommit(rpcParameters, config, account, tt.args.epoch, stakerId, latestHeader, commitParams, stateBuffer, rogueData); (err != nil) != tt.wantErr {
 				t.Errorf("InitiateCommit() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.args.holdLease {
+				cmdUtilsMock.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -587,9 +661,9 @@ func TestInitiateReveal(t *testing.T) {
 	copy(decodedCommitment32[:], decodedCommitment)
 
 	randomNum := big.NewInt(1111)
-	globalCommitDataStruct.Epoch = 5
-	globalCommitDataStruct.Leaves = []*big.Int{big.NewInt(100), big.NewInt(101)}
-	globalCommitDataStruct.Commitment = decodedCommitment32
+	defaultStakerState.commitData.Epoch = 5
+	defaultStakerState.commitData.Leaves = []*big.Int{big.NewInt(100), big.NewInt(101)}
+	defaultStakerState.commitData.Commitment = decodedCommitment32
 
 	type args struct {
 		staker                   bindings.StructsStaker
@@ -1281,12 +1355,17 @@ func TestHandleBlock(t *testing.T) {
 			utilsMock.On("IsFlagPassed", mock.AnythingOfType("string")).Return(tt.args.isFlagPassed)
 			cmdUtilsMock.On("HandleClaimBounty", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.handleClaimBountyErr)
 			utilsMock.On("GetConfirmedBlocks", mock.Anything, mock.Anything).Return(tt.args.confirmedBlock, tt.args.confirmedBlockErr)
+			utilsMock.On("GetEpochLastProposed", mock.Anything, mock.Anything).Return(uint32(0), nil)
 			cmdUtilsMock.On("ClaimBlockReward", mock.Anything, mock.Anything).Return(tt.args.claimBlockRewardTxn, tt.args.claimBlockRewardErr)
 			timeMock.On("Sleep", mock.Anything).Return()
 			utilsMock.On("WaitTillNextNSecs", mock.AnythingOfType("int32")).Return()
-			lastVerification = tt.args.lastVerification
+			defaultStakerState.lastVerification = tt.args.lastVerification
 			ut := &UtilsStruct{}
 			ut.HandleBlock(rpcParameters, account, stakerId, latestHeader, tt.args.config, commitParams, rogueData, backupNodeActionsToIgnore)
+			if defaultStakerState.isSlashed != tt.args.staker.IsSlashed {
+				t.Errorf("HandleBlock() isSlashed = %v, want %v", defaultStakerState.isSlashed, tt.args.staker.IsSlashed)
+			}
+			defaultStakerState.isSlashed = false
 		})
 	}
 }
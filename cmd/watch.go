@@ -0,0 +1,258 @@
+//Package cmd provides all functions related to command line
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"razor/block"
+	"razor/core/types"
+	"razor/rpc"
+	"razor/utils"
+	"time"
+
+	Types "github.com/ethereum/go-ethereum/core/types"
+	solsha3 "github.com/miguelmota/go-solidity-sha3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Following are the disputes which can be raised against a proposed block, named after the contract functions which raise them
+const (
+	DisputeBiggestStakeProposed        = "disputeBiggestStakeProposed"
+	DisputeOnOrderOfIds                = "disputeOnOrderOfIds"
+	DisputeCollectionIdShouldBePresent = "disputeCollectionIdShouldBePresent"
+	DisputeCollectionIdShouldBeAbsent  = "disputeCollectionIdShouldBeAbsent"
+	DisputeMedians                     = "finalizeDispute"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "audit every proposed block without staking",
+	Long: `Recalculates the medians of every epoch from the revealed votes in the dispute state and reports every proposed block which can be disputed.
+No staker is needed to watch. If an address is given, disputes are raised from that account, which acts as a bounty hunter.
+
+Example:
+  ./razor watch
+  ./razor watch --address 0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c --autoClaimBounty`,
+	Run: initialiseWatch,
+}
+
+// watcher keeps the state of the watch command between blocks
+type watcher struct {
+	// lastAuditedEpoch is the last epoch whose disputable blocks were all reported and, if an account is given, disputed
+	lastAuditedEpoch uint32
+}
+
+//This function initialises the ExecuteWatch function
+func initialiseWatch(cmd *cobra.Command, args []string) {
+	cmdUtils.ExecuteWatch(cmd.Flags())
+}
+
+//This function sets the flags appropriately and executes the Watch function
+func (*UtilsStruct) ExecuteWatch(flagSet *pflag.FlagSet) {
+	config, rpcParameters, blockMonitor, account, err := InitializeCommandDependencies(flagSet)
+	utils.CheckError("Error in initialising command dependencies: ", err)
+
+	if account.Address == "" {
+		log.Info("No address given, disputable blocks will only be reported")
+	} else {
+		log.Infof("Disputable blocks will be disputed from bounty hunter account %s", account.Address)
+	}
+
+	log.Debugf("Calling Watch() with arguments account address = %s", account.Address)
+	if err := cmdUtils.Watch(rpcParameters, blockMonitor, config, account); err != nil {
+		log.Errorf("%v\n", err)
+		osUtils.Exit(1)
+	}
+}
+
+//This function audits the proposed blocks of every epoch until the context is cancelled
+func (*UtilsStruct) Watch(rpcParameters rpc.RPCParameters, blockMonitor *block.BlockMonitor, config types.Configurations, account types.Account) error {
+	header, err := clientUtils.GetLatestBlockWithRetry(rpcParameters)
+	utils.CheckError("Error in getting block: ", err)
+	headers := blockMonitor.Subscribe()
+	defer blockMonitor.Unsubscribe(headers)
+	watcher := &watcher{}
+	for {
+		select {
+		case <-rpcParameters.Ctx.Done():
+			return nil
+		case latestHeader := <-headers:
+			if latestHeader.Number.Cmp(header.Number) != 0 {
+				header = latestHeader
+				watcher.handleBlock(rpcParameters, config, account, latestHeader)
+			}
+		}
+	}
+}
+
+//This function audits the proposed blocks once per epoch in the dispute state and disputes them if an account is given.
+//If disputing fails, the blocks are audited again in the next block of the dispute state.
+func (w *watcher) handleBlock(rpcParameters rpc.RPCParameters, config types.Configurations, account types.Account, latestHeader *Types.Header) {
+	stateBuffer, err := razorUtils.GetStateBuffer(rpcParameters)
+	if err != nil {
+		log.Error("Error in getting state buffer: ", err)
+		return
+	}
+	state, err := razorUtils.GetBufferedState(latestHeader, stateBuffer, config.BufferPercent)
+	if err != nil {
+		log.Error("Error in getting state: ", err)
+		return
+	}
+	if state != 3 {
+		return
+	}
+	epoch, err := razorUtils.GetEpoch(rpcParameters)
+	if err != nil {
+		log.Error("Error in getting epoch: ", err)
+		return
+	}
+	if w.lastAuditedEpoch >= epoch {
+		log.Debugf("Proposed blocks of epoch %d are already audited", epoch)
+		return
+	}
+
+	remainingTimeOfTheCurrentState, err := razorUtils.GetRemainingTimeOfCurrentState(latestHeader, stateBuffer, config.BufferPercent)
+	if err != nil {
+		log.Error("Error in getting remaining time of the current state: ", err)
+		return
+	}
+	if remainingTimeOfTheCurrentState <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(remainingTimeOfTheCurrentState)*time.Second)
+	defer cancel()
+	rpcParameters.Ctx = ctx
+
+	log.Infof("Auditing proposed blocks of epoch %d...", epoch)
+	disputableBlocks, localMediansData, err := cmdUtils.AuditProposedBlocks(rpcParameters, epoch, latestHeader.Number)
+	if err != nil {
+		log.Error("Error in auditing proposed blocks: ", err)
+		return
+	}
+	if len(disputableBlocks) == 0 {
+		log.Infof("All proposed blocks of epoch %d match local calculations", epoch)
+		w.lastAuditedEpoch = epoch
+		return
+	}
+	for _, disputableBlock := range disputableBlocks {
+		log.Warnf("DISPUTABLE BLOCK: Epoch: %d, Block Id: %d, Block Index: %d, Proposer Id: %d, Dispute: %s", disputableBlock.Epoch, disputableBlock.BlockId, disputableBlock.BlockIndex, disputableBlock.ProposerId, disputableBlock.Dispute)
+	}
+	if account.Address == "" {
+		w.lastAuditedEpoch = epoch
+		return
+	}
+
+	// HandleDispute reads the local medians from the global propose data, so the medians of the audit are not calculated again
+	getStakerState(rpcParameters.Ctx).updateProposedData(localMediansData)
+	err = cmdUtils.HandleDispute(rpcParameters, config, account, epoch, latestHeader.Number, types.Rogue{IsRogue: false}, nil)
+	if err != nil {
+		log.Error("Error in disputing: ", err)
+		return
+	}
+	w.lastAuditedEpoch = epoch
+	if razorUtils.IsFlagPassed("autoClaimBounty") {
+		log.Debugf("Automatically claiming bounty")
+		err = cmdUtils.HandleClaimBounty(rpcParameters, config, account)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+//This function calculates the medians of the epoch from the revealed votes and returns the proposed blocks which can be disputed along with the calculated medians
+func (*UtilsStruct) AuditProposedBlocks(rpcParameters rpc.RPCParameters, epoch uint32, blockNumber *big.Int) ([]types.DisputableBlock, types.ProposeFileData, error) {
+	sortedProposedBlockIds, err := razorUtils.GetSortedProposedBlockIds(rpcParameters, epoch)
+	if err != nil {
+		log.Error("Error in fetching sorted proposed block id: ", err)
+		return nil, types.ProposeFileData{}, err
+	}
+	log.Debug("AuditProposedBlocks: SortedProposedBlockIds: ", sortedProposedBlockIds)
+
+	biggestStake, biggestStakerId, err := cmdUtils.GetBiggestStakeAndId(rpcParameters, epoch)
+	if err != nil {
+		return nil, types.ProposeFileData{}, err
+	}
+	log.Debugf("AuditProposedBlocks: Biggest stake: %s, Biggest staker Id: %d", biggestStake, biggestStakerId)
+
+	log.Debugf("AuditProposedBlocks: Calling MakeBlock() with arguments blockNumber = %s, epoch = %d", blockNumber, epoch)
+	medians, revealedCollectionIds, revealedDataMaps, err := cmdUtils.MakeBlock(rpcParameters, blockNumber, epoch, types.Rogue{IsRogue: false})
+	if err != nil {
+		log.Error("Error in calculating block medians: ", err)
+		return nil, types.ProposeFileData{}, err
+	}
+	localMediansData := types.ProposeFileData{
+		Epoch:                 epoch,
+		MediansData:           medians,
+		RevealedCollectionIds: revealedCollectionIds,
+		RevealedDataMaps:      revealedDataMaps,
+	}
+	log.Debugf("AuditProposedBlocks: Locally calculated medians: %s, revealed collection ids: %v", medians, revealedCollectionIds)
+
+	var disputableBlocks []types.DisputableBlock
+	for blockIndex, blockId := range sortedProposedBlockIds {
+		proposedBlock, err := razorUtils.GetProposedBlock(rpcParameters, epoch, blockId)
+		if err != nil {
+			log.Error("Error in getting proposed block: ", err)
+			return nil, types.ProposeFileData{}, err
+		}
+		if !proposedBlock.Valid {
+			log.Debugf("AuditProposedBlocks: Block %d is already disputed", blockId)
+			continue
+		}
+
+		var dispute string
+		if proposedBlock.BiggestStake.Cmp(biggestStake) != 0 {
+			dispute = DisputeBiggestStakeProposed
+		} else if idsDispute := getIdsDispute(proposedBlock.Ids, revealedCollectionIds); idsDispute != "" {
+			dispute = idsDispute
+		} else if isEqual, _ := utils.IsBigIntArrayEqual(proposedBlock.Medians, medians); !isEqual && len(proposedBlock.Ids) != 0 && len(proposedBlock.Medians) != 0 {
+			dispute = DisputeMedians
+		}
+		if dispute == "" {
+			log.Debugf("AuditProposedBlocks: Block %d matches local calculations", blockId)
+			continue
+		}
+		disputableBlocks = append(disputableBlocks, types.DisputableBlock{
+			Epoch:      epoch,
+			BlockId:    blockId,
+			BlockIndex: blockIndex,
+			ProposerId: proposedBlock.ProposerId,
+			Dispute:    dispute,
+		})
+	}
+	return disputableBlocks, localMediansData, nil
+}
+
+//This function returns the dispute which CheckDisputeForIds would raise for the ids of a proposed block, it is empty if the ids are correct
+func getIdsDispute(idsInProposedBlock []uint16, revealedCollectionIds []uint16) string {
+	hashIdsInProposedBlock := solsha3.SoliditySHA3([]string{"uint16[]"}, []interface{}{idsInProposedBlock})
+	hashRevealedCollectionIds := solsha3.SoliditySHA3([]string{"uint16[]"}, []interface{}{revealedCollectionIds})
+	if isEqual, _ := utils.IsByteArrayEqual(hashIdsInProposedBlock, hashRevealedCollectionIds); isEqual {
+		return ""
+	}
+	if isSorted, _, _ := utils.IsSorted(idsInProposedBlock); !isSorted {
+		return DisputeOnOrderOfIds
+	}
+	if isValueMissing, _, _ := utils.CheckValueMissingInArray(revealedCollectionIds, idsInProposedBlock); isValueMissing {
+		return DisputeCollectionIdShouldBePresent
+	}
+	if isValuePresent, _, _ := utils.CheckValueMissingInArray(idsInProposedBlock, revealedCollectionIds); isValuePresent {
+		return DisputeCollectionIdShouldBeAbsent
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	var (
+		Address         string
+		Password        string
+		AutoClaimBounty bool
+	)
+
+	watchCmd.Flags().StringVarP(&Address, "address", "a", "", "address of the bounty hunter account which raises disputes (optional)")
+	watchCmd.Flags().StringVarP(&Password, "password", "", "", "password path of the bounty hunter to protect the keystore")
+	watchCmd.Flags().BoolVarP(&AutoClaimBounty, "autoClaimBounty", "", false, "auto claim bounty")
+}
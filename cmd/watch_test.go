@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"errors"
+	"math/big"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"reflect"
+	"testing"
+
+	Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditProposedBlocks(t *testing.T) {
+	var epoch uint32 = 10
+	var blockNumber *big.Int
+	biggestStake := big.NewInt(1).Mul(big.NewInt(5356), big.NewInt(1e18))
+	medians := []*big.Int{big.NewInt(6901548), big.NewInt(498307)}
+
+	type args struct {
+		sortedProposedBlockIds    []uint32
+		sortedProposedBlockIdsErr error
+		biggestStakeErr           error
+		revealedCollectionIds     []uint16
+		makeBlockErr              error
+		proposedBlock             bindings.StructsBlock
+		proposedBlockErr          error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.DisputableBlock
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When proposed blocks match local calculations",
+			args: args{
+				sortedProposedBlockIds: []uint32{3, 1},
+				revealedCollectionIds:  []uint16{1, 2},
+				proposedBlock: bindings.StructsBlock{
+					Valid:        true,
+					ProposerId:   2,
+					Ids:          []uint16{1, 2},
+					Medians:      medians,
+					BiggestStake: biggestStake,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Test 2: When biggest stake of proposed blocks is wrong",
+			args: args{
+				sortedProposedBlockIds: []uint32{3, 1},
+				revealedCollectionIds:  []uint16{1, 2},
+				proposedBlock: bindings.StructsBlock{
+					Valid:        true,
+					ProposerId:   2,
+					Ids:          []uint16{1, 2},
+					Medians:      medians,
+					BiggestStake: big.NewInt(1).Mul(big.NewInt(4356), big.NewInt(1e18)),
+				},
+			},
+			want: []types.DisputableBlock{
+				{Epoch: epoch, BlockId: 3, BlockIndex: 0, ProposerId: 2, Dispute: DisputeBiggestStakeProposed},
+				{Epoch: epoch, BlockId: 1, BlockIndex: 1, ProposerId: 2, Dispute: DisputeBiggestStakeProposed},
+			},
+		},
+		{
+			name: "Test 3: When proposed block misses a revealed collection id",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				revealedCollectionIds:  []uint16{1, 2},
+				proposedBlock: bindings.StructsBlock{
+					Valid:        true,
+					ProposerId:   4,
+					Ids:          []uint16{1},
+					Medians:      []*big.Int{big.NewInt(6901548)},
+					BiggestStake: biggestStake,
+				},
+			},
+			want: []types.DisputableBlock{
+				{Epoch: epoch, BlockId: 3, BlockIndex: 0, ProposerId: 4, Dispute: DisputeCollectionIdShouldBePresent},
+			},
+		},
+		{
+			name: "Test 4: When medians of proposed block are wrong",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				revealedCollectionIds:  []uint16{1, 2},
+				proposedBlock: bindings.StructsBlock{
+					Valid:        true,
+					ProposerId:   4,
+					Ids:          []uint16{1, 2},
+					Medians:      []*big.Int{big.NewInt(6901548), big.NewInt(500000)},
+					BiggestStake: biggestStake,
+				},
+			},
+			want: []types.DisputableBlock{
+				{Epoch: epoch, BlockId: 3, BlockIndex: 0, ProposerId: 4, Dispute: DisputeMedians},
+			},
+		},
+		{
+			name: "Test 5: When proposed block is already disputed",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				revealedCollectionIds:  []uint16{1, 2},
+				proposedBlock: bindings.StructsBlock{
+					Valid:        false,
+					ProposerId:   4,
+					Ids:          []uint16{1, 2},
+					Medians:      []*big.Int{big.NewInt(6901548), big.NewInt(500000)},
+					BiggestStake: biggestStake,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Test 6: When there is an error in getting sortedProposedBlockIds",
+			args: args{
+				sortedProposedBlockIdsErr: errors.New("sortedProposedBlockIds error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 7: When there is an error in getting biggest stake",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				biggestStakeErr:        errors.New("biggestStake error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 8: When there is an error in calculating medians",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				makeBlockErr:           errors.New("makeBlock error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test 9: When there is an error in getting proposed block",
+			args: args{
+				sortedProposedBlockIds: []uint32{3},
+				proposedBlockErr:       errors.New("proposedBlock error"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetSortedProposedBlockIds", mock.Anything, mock.Anything).Return(tt.args.sortedProposedBlockIds, tt.args.sortedProposedBlockIdsErr)
+			cmdUtilsMock.On("GetBiggestStakeAndId", mock.Anything, mock.Anything).Return(biggestStake, uint32(2), tt.args.biggestStakeErr)
+			cmdUtilsMock.On("MakeBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(medians, tt.args.revealedCollectionIds, &types.RevealedDataMaps{}, tt.args.makeBlockErr)
+			utilsMock.On("GetProposedBlock", mock.Anything, mock.Anything, mock.Anything).Return(tt.args.proposedBlock, tt.args.proposedBlockErr)
+
+			utils := &UtilsStruct{}
+			got, localMediansData, err := utils.AuditProposedBlocks(rpcParameters, epoch, blockNumber)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Error for AuditProposedBlocks function, got = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Disputable blocks from AuditProposedBlocks function, got = %+v, want = %+v", got, tt.want)
+			}
+			if !tt.wantErr && (localMediansData.Epoch != epoch || !reflect.DeepEqual(localMediansData.MediansData, medians)) {
+				t.Errorf("Local medians data from AuditProposedBlocks function, got = %+v", localMediansData)
+			}
+		})
+	}
+}
+
+func TestWatcherHandleBlock(t *testing.T) {
+	var epoch uint32 = 10
+	latestHeader := &Types.Header{Number: big.NewInt(1000)}
+	disputableBlocks := []types.DisputableBlock{{Epoch: epoch, BlockId: 1, ProposerId: 2, Dispute: DisputeMedians}}
+
+	tests := []struct {
+		name                 string
+		address              string
+		disputableBlocks     []types.DisputableBlock
+		auditErr             error
+		handleDisputeErr     error
+		wantLastAuditedEpoch uint32
+	}{
+		{
+			name:                 "Test 1: When there is no block to dispute",
+			address:              "0x000000000000000000000000000000000000dead",
+			wantLastAuditedEpoch: epoch,
+		},
+		{
+			name:                 "Test 2: When disputable blocks are only reported",
+			disputableBlocks:     disputableBlocks,
+			wantLastAuditedEpoch: epoch,
+		},
+		{
+			name:                 "Test 3: When disputable blocks are disputed",
+			address:              "0x000000000000000000000000000000000000dead",
+			disputableBlocks:     disputableBlocks,
+			wantLastAuditedEpoch: epoch,
+		},
+		{
+			name:                 "Test 4: When disputing fails, the epoch is audited again",
+			address:              "0x000000000000000000000000000000000000dead",
+			disputableBlocks:     disputableBlocks,
+			handleDisputeErr:     errors.New("dispute error"),
+			wantLastAuditedEpoch: 0,
+		},
+		{
+			name:                 "Test 5: When auditing fails",
+			auditErr:             errors.New("audit error"),
+			wantLastAuditedEpoch: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpMockInterfaces()
+
+			utilsMock.On("GetStateBuffer", mock.Anything).Return(uint64(5), nil)
+			utilsMock.On("GetBufferedState", mock.Anything, mock.Anything, mock.Anything).Return(int64(3), nil)
+			utilsMock.On("GetEpoch", mock.Anything).Return(epoch, nil)
+			utilsMock.On("GetRemainingTimeOfCurrentState", mock.Anything, mock.Anything, mock.Anything).Return(int64(10), nil)
+			cmdUtilsMock.On("AuditProposedBlocks", mock.Anything, epoch, latestHeader.Number).Return(tt.disputableBlocks, types.ProposeFileData{Epoch: epoch}, tt.auditErr)
+			cmdUtilsMock.On("HandleDispute", mock.Anything, mock.Anything, mock.Anything, epoch, mock.Anything, mock.Anything, mock.Anything).Return(tt.handleDisputeErr)
+			utilsMock.On("IsFlagPassed", mock.AnythingOfType("string")).Return(false)
+
+			watcher := &watcher{}
+			watcher.handleBlock(rpcParameters, types.Configurations{}, types.Account{Address: tt.address}, latestHeader)
+			if watcher.lastAuditedEpoch != tt.wantLastAuditedEpoch {
+				t.Errorf("handleBlock() lastAuditedEpoch = %d, want %d", watcher.lastAuditedEpoch, tt.wantLastAuditedEpoch)
+			}
+		})
+	}
+}
+
+func TestGetIdsDispute(t *testing.T) {
+	tests := []struct {
+		name                  string
+		idsInProposedBlock    []uint16
+		revealedCollectionIds []uint16
+		want                  string
+	}{
+		{
+			name:                  "Test 1: When ids are correct",
+			idsInProposedBlock:    []uint16{1, 2, 3},
+			revealedCollectionIds: []uint16{1, 2, 3},
+			want:                  "",
+		},
+		{
+			name:                  "Test 2: When ids are not sorted",
+			idsInProposedBlock:    []uint16{1, 3, 2},
+			revealedCollectionIds: []uint16{1, 2, 3},
+			want:                  DisputeOnOrderOfIds,
+		},
+		{
+			name:                  "Test 3: When a revealed collection id is missing",
+			idsInProposedBlock:    []uint16{1, 3},
+			revealedCollectionIds: []uint16{1, 2, 3},
+			want:                  DisputeCollectionIdShouldBePresent,
+		},
+		{
+			name:                  "Test 4: When a collection id which is not revealed is present",
+			idsInProposedBlock:    []uint16{1, 2, 3, 4},
+			revealedCollectionIds: []uint16{1, 2, 3},
+			want:                  DisputeCollectionIdShouldBeAbsent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getIdsDispute(tt.idsInProposedBlock, tt.revealedCollectionIds); got != tt.want {
+				t.Errorf("getIdsDispute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,27 @@ const (
 	BlockCompletionAttempts          = 4
 	BlockCompletionAttemptRetryDelay = 2
 	BlockCompletionTimeout           = 15
+
+	// Pending transactions are resubmitted with gas price increased by TransactionGasBumpPercent if they are not mined within TransactionStuckTimeout seconds
+	TransactionStuckTimeout   = 20
+	TransactionGasBumpPercent = 20
+	TransactionMaxGasBumps    = 5
+	TransactionCancelGasLimit = 21000
+	// Pending transactions are checked every TransactionCheckInterval seconds, also while a transaction is waited for
+	TransactionCheckInterval = 5
+
+	// The leader of redundant vote nodes renews its lease every LeaseRenewInterval seconds for LeaseTTL seconds.
+	// It stops sending transactions LeaseRenewInterval seconds before the lease expires, so clocks of the nodes should differ by less than that.
+	LeaseTTL           = 15
+	LeaseRenewInterval = 5
+	// A lock file of the lease file which is older than LeaseStaleLockTimeout seconds was left by a node which crashed while holding it
+	LeaseStaleLockTimeout = 10
+
+	// A notification for the same event of a staker is sent at most once every NotificationRateLimit seconds unless configured otherwise
+	NotificationRateLimit = 600
+
+	// SFuelReserveGasLimit is the gas kept in reserve for the commit and reveal transactions of an epoch, non-essential transactions are paused below it
+	SFuelReserveGasLimit = 2000000
 )
 
 //Following are the default config values for all the config parameters
@@ -41,6 +62,7 @@ const (
 	DefaultRPCTimeout       int64   = 5
 	DefaultHTTPTimeout      int64   = 5
 	DefaultLogLevel                 = ""
+	DefaultSignerURL                = ""
 )
 
 //BufferStateSleepTime is the sleeping time whenever buffer state hits
@@ -53,20 +75,36 @@ const (
 	DefaultLogFileMaxAge     = 60
 )
 
+//Following are the parameters of the log sinks which ship the logs over the network
+const (
+	// Entries logged while LogSinkQueueSize entries are waiting to be shipped to a sink are dropped for that sink
+	LogSinkQueueSize = 1000
+	// A sink whose connection failed is reconnected after LogSinkRetryInterval seconds, entries logged in between are dropped for it
+	LogSinkRetryInterval = 10
+	LogSinkDialTimeout   = 5
+	LogSinkWriteTimeout  = 5
+	// Entries still waiting to be shipped when the node exits are flushed for at most LogSinkFlushTimeout seconds
+	LogSinkFlushTimeout = 3
+)
+
 //DisputeGasMultiplier is a constant gasLimitMultiplier to increase gas Limit for function `disputeCollectionIdShouldBeAbsent` and `disputeCollectionIdShouldBePresent`
 const DisputeGasMultiplier float32 = 5.5
 
 // Following are the constants which will be used to derive different file paths
 
 const (
-	DataFileDirectory = "data_files"
-	CommitDataFile    = "_commitData.json"
-	ProposeDataFile   = "_proposeData.json"
-	DisputeDataFile   = "_disputeData.json"
-	AssetsDataFile    = "assets.json"
-	ConfigFile        = "razor.yaml"
-	LogFileDirectory  = "logs"
-	DefaultPathName   = ".razor"
+	DataFileDirectory   = "data_files"
+	CommitDataFile      = "_commitData.json"
+	ProposeDataFile     = "_proposeData.json"
+	DisputeDataFile     = "_disputeData.json"
+	EpochJournalFile    = "_epochJournal.jsonl"
+	RecordingsDirectory = "recordings"
+	RecordingFile       = ".jsonl"
+	AssetsDataFile      = "assets.json"
+	ConfigFile          = "razor.yaml"
+	EndpointsFile       = "endpoints.json"
+	LogFileDirectory    = "logs"
+	DefaultPathName     = ".razor"
 )
 
 const (
@@ -76,11 +114,34 @@ const (
 	// StaleBlockNumberCheckInterval specifies the duration in seconds after which the BlockMonitor
 	// switches to an alternate endpoint if the block number remains unchanged, indicating a potential stale endpoint.
 	StaleBlockNumberCheckInterval = 15
+
+	// BlockSubscriptionRetryInterval is the interval in seconds after which the BlockMonitor tries to subscribe
+	// to new heads again over the websocket endpoints, while it falls back to polling.
+	BlockSubscriptionRetryInterval = 30
 )
 
 //EndpointsContextTimeout defines the maximum duration in seconds to wait for establishing a connection for an endpoint
 const EndpointsContextTimeout = 5
 
+// Following are the defaults of the scoring of the RPC endpoints, which can be overridden in the scoring section of endpoints.json
+
+const (
+	// RPCScoreSmoothing is the weight of the latest sample in the exponentially weighted latency and error rate of an endpoint
+	RPCScoreSmoothing = 0.3
+	// RPCScoreLatencyPenalty is the penalty to the score of an endpoint per second of its average latency
+	RPCScoreLatencyPenalty = 1.0
+	// RPCScoreErrorPenalty is the penalty to the score of an endpoint when all of its calls fail
+	RPCScoreErrorPenalty = 10.0
+	// RPCScoreBlockLagPenalty is the penalty to the score of an endpoint per block it is behind the most up to date endpoint
+	RPCScoreBlockLagPenalty = 1.0
+	// RPCQuarantineErrors is the number of consecutive failed calls after which an endpoint is quarantined
+	RPCQuarantineErrors = 3
+	// RPCQuarantineDuration is the duration in seconds of the first quarantine of an endpoint, it doubles with every quarantine in a row
+	RPCQuarantineDuration = 30
+	// RPCMaxQuarantineDuration is the maximum duration in seconds of a quarantine
+	RPCMaxQuarantineDuration = 600
+)
+
 //APIKeyRegex will be used as a regular expression to be matched in job Urls
 const APIKeyRegex = `\$\{(.+?)\}`
 
@@ -90,6 +151,14 @@ const (
 	ProcessRequestRetryDelay    int64 = 2
 )
 
+// Following are the constants used by the websocket and eth_call data sources of jobs
+const (
+	// WebSocketDataSourceTimeout is the time in seconds to wait for a websocket data source to send a message containing the data of the job
+	WebSocketDataSourceTimeout int64 = 10
+	// EthCallDataSourceTimeout is the time in seconds to wait for an eth_call data source to respond
+	EthCallDataSourceTimeout int64 = 10
+)
+
 const (
 	// HexReturnType is the ReturnType for a job if that job returns a hex value
 	HexReturnType = "hex"
@@ -124,3 +193,37 @@ const (
 	CollectionUpdatedEvent        = "CollectionUpdated"
 	CollectionActivityStatusEvent = "CollectionActivityStatus"
 )
+
+// Following are the event names that are scanned to build the epoch history of a staker
+const (
+	CommittedEvent   = "Committed"
+	RevealedEvent    = "Revealed"
+	StakeChangeEvent = "StakeChange"
+)
+
+// Following are the reasons of a StakeChange event, in the order of the StakeChanged enum of the contracts
+const (
+	StakeChangeBlockReward uint8 = iota
+	StakeChangeInactivityPenalty
+	StakeChangeSlashed
+)
+
+// HistoryDefaultEpochs is the number of epochs shown by the history command if no epoch range is given
+const HistoryDefaultEpochs = 10
+
+// HistoryEventsBlockRange is the maximum number of blocks queried in a single filter logs call by the history command
+const HistoryEventsBlockRange = 5000
+
+// EpochJournalRetainedEpochs is the number of epochs before the current epoch whose epoch journal entries are kept in memory while voting
+const EpochJournalRetainedEpochs = 2
+
+// ProposerOddsDefaultSimulations is the number of random salts simulated by the proposerOdds command if no number of simulations is given
+const ProposerOddsDefaultSimulations = 1000
+
+// ProposerOddsActiveEpochs is the number of epochs before the current epoch a staker has to have committed in to be counted by the proposerOdds command
+// when the current stakes are used, as a staker which doesn't commit has no stake snapshot and is never elected
+const ProposerOddsActiveEpochs = 2
+
+// ConfigReloadSettleTime is the time in seconds since the last change of razor.yaml, assets.json or endpoints.json after which vote reloads the file,
+// so that a file which is still being written is not reloaded
+const ConfigReloadSettleTime = 2
@@ -3,6 +3,8 @@ package types
 import (
 	"crypto/ecdsa"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"math/big"
 )
 
 //go:generate mockery --name=AccountManagerInterface --output=../../accounts/mocks --case=underscore
@@ -13,9 +15,18 @@ type Account struct {
 	AccountManager AccountManagerInterface
 }
 
+// SupervisedStaker is a staker whose voting is run by the supervisor along with other stakers
+type SupervisedStaker struct {
+	Account  Account
+	StakerId uint32
+}
+
 type AccountManagerInterface interface {
 	CreateAccount(keystorePath, password string) accounts.Account
 	GetPrivateKey(address, password string) (*ecdsa.PrivateKey, error)
 	SignData(hash []byte, address string, password string) ([]byte, error)
+	SignMessage(message []byte, address string, password string) ([]byte, error)
+	NewTransactor(address string, password string, chainId *big.Int) (*bind.TransactOpts, error)
+	VerifyAccount(address string, password string) error
 	NewAccount(passphrase string) (accounts.Account, error)
 }
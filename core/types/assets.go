@@ -57,6 +57,16 @@ type CustomJob struct {
 	Weight   uint8  `json:"weight"`
 }
 
+// AggregationPolicy decides which job results of a collection are aggregated and whether there are enough of them
+type AggregationPolicy struct {
+	MinJobs          int     `json:"minJobs"`
+	MinWeight        uint    `json:"minWeight"`
+	OutlierMethod    string  `json:"outlierMethod"`
+	OutlierThreshold float64 `json:"outlierThreshold"`
+	Method           string  `json:"method"`
+	TrimPercent      float64 `json:"trimPercent"`
+}
+
 type DataSourceURL struct {
 	Type       string                 `json:"type"`
 	URL        string                 `json:"url"`
@@ -69,3 +79,15 @@ type CollectionResult struct {
 	Index int
 	Leaf  *big.Int
 }
+
+// JobValidationResult is the result of fetching an overridden or custom job of the assets.json file
+type JobValidationResult struct {
+	Collection    string   `json:"collection"`
+	JobId         uint16   `json:"jobId"`
+	Name          string   `json:"name"`
+	Value         *big.Int `json:"value"`
+	LatencyMs     int64    `json:"latencyMs"`
+	PreviousValue *big.Int `json:"previousValue"`
+	Deviation     *float64 `json:"deviation"`
+	Error         string   `json:"error,omitempty"`
+}
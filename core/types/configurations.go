@@ -14,6 +14,7 @@ type Configurations struct {
 	LogFileMaxSize     int
 	LogFileMaxBackups  int
 	LogFileMaxAge      int
+	SignerURL          string
 }
 
 type ConfigDetail struct {
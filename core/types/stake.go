@@ -24,3 +24,38 @@ type BountyLock struct {
 	BountyHunter common.Address
 	Amount       *big.Int
 }
+
+type EpochHistory struct {
+	Epoch      uint32
+	Committed  bool
+	Revealed   bool
+	Proposed   bool
+	Confirmed  bool
+	Disputed   bool
+	Penalized  bool
+	StakeDelta *big.Int
+}
+
+type ProposerOdds struct {
+	Epoch           uint32
+	StakerId        uint32
+	Stake           *big.Int
+	BiggestStake    *big.Int
+	NumberOfStakers uint32
+	IsSnapshot      bool
+	IsSaltKnown     bool
+	Iteration       int
+	StakersAhead    []uint32
+	Simulations     uint32
+	MaxAltBlocks    uint8
+	StakeOdds       []ProposerStakeOdds
+}
+
+type ProposerStakeOdds struct {
+	AdditionalStake       *big.Int
+	Stake                 *big.Int
+	Probability           float64
+	ConfirmedBlocksPerDay float64
+	ProposalProbability   float64
+	ProposalsPerDay       float64
+}
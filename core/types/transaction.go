@@ -14,4 +14,11 @@ type TransactionOptions struct {
 	Parameters      []interface{}
 	ABI             string
 	Account         Account
+	StateWindow     *StateWindow
+}
+
+// StateWindow is the epoch and state in which a transaction has to be mined to be useful
+type StateWindow struct {
+	Epoch uint32
+	State int64
 }
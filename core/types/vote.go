@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"net/http"
 	"razor/cache"
+	"time"
 )
 
 type ElectedProposer struct {
@@ -53,6 +54,20 @@ type ProposeData struct {
 	RevealedDataMaps      *RevealedDataMaps
 }
 
+// RevealJournalData is the data of a reveal recorded in the epoch journal, with the hash of the reveal transaction
+type RevealJournalData struct {
+	RevealedValues         []*big.Int
+	AssignedCollections    map[int]bool
+	SeqAllottedCollections []*big.Int
+	TxnHash                string
+}
+
+// DisputeJournalData is the data of a dispute recorded in the epoch journal, with the bounty id of the dispute and the bounty ids which are yet to be claimed
+type DisputeJournalData struct {
+	BountyId      uint32
+	BountyIdQueue []uint32
+}
+
 type CommitFileData struct {
 	Epoch                  uint32
 	AssignedCollections    map[int]bool
@@ -75,3 +90,36 @@ type CommitParams struct {
 	HttpClient                *http.Client
 	FromBlockToCheckForEvents *big.Int
 }
+
+// DisputableBlock is a proposed block which doesn't match the local calculations along with the dispute which can be raised against it
+type DisputableBlock struct {
+	Epoch      uint32
+	BlockId    uint32
+	BlockIndex int
+	ProposerId uint32
+	Dispute    string
+}
+
+// ReplayedJob is the value of a job recomputed from the response of its data source recorded in an epoch
+type ReplayedJob struct {
+	JobId         uint16
+	JobName       string
+	URL           string
+	BodyHash      string
+	Timestamp     time.Time
+	RecordedValue string
+	ReplayedValue string
+	Error         string
+}
+
+// ReplayedCollection is the leaf of a collection recomputed from the recorded responses of its jobs.
+// CommittedLeaf is nil if the leaf committed in the epoch isn't known.
+type ReplayedCollection struct {
+	LeafIndex      int
+	CollectionId   uint16
+	CollectionName string
+	Jobs           []ReplayedJob
+	ReplayedLeaf   *big.Int
+	CommittedLeaf  *big.Int
+	Error          string
+}
@@ -0,0 +1,40 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"path/filepath"
+	"razor/accounts"
+	"razor/core/types"
+	"runtime"
+	"testing"
+)
+
+// testAccountsPassword is the password of the keystores in utils/test_accounts
+const testAccountsPassword = "Test@123"
+
+// testAccountAddresses are the addresses of the keystores in utils/test_accounts
+var testAccountAddresses = []string{
+	"0x57Baf83BAD5bee0F7F44d84669A50C35c57E3576",
+	"0xbd3e0a1d11163934df10501c9e1a18fbaa9ecaf4",
+}
+
+// TestAccounts returns the accounts of the keystores in utils/test_accounts, which sign with the keystore
+func TestAccounts(t testing.TB) []types.Account {
+	t.Helper()
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("Error in locating test accounts")
+	}
+	accountManager := accounts.NewAccountManager(filepath.Join(filepath.Dir(file), "..", "utils", "test_accounts"))
+
+	testAccounts := make([]types.Account, len(testAccountAddresses))
+	for i, address := range testAccountAddresses {
+		testAccounts[i] = types.Account{
+			Address:        address,
+			Password:       testAccountsPassword,
+			AccountManager: accountManager,
+		}
+	}
+	return testAccounts
+}
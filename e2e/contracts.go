@@ -0,0 +1,306 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"razor/core"
+	"razor/pkg/bindings"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ArtifactsEnv is the environment variable with the directory of the compiled Razor contracts.
+// The directory holds a hardhat artifact, a JSON file with the abi and bytecode, for every contract in razorContracts.
+const ArtifactsEnv = "RAZOR_E2E_ARTIFACTS"
+
+// razorContracts are the Razor contracts in the order in which they are deployed
+var razorContracts = []string{
+	"RAZOR",
+	"StakedTokenFactory",
+	"RandomNoManager",
+	"RewardManager",
+	"StakeManager",
+	"VoteManager",
+	"BlockManager",
+	"CollectionManager",
+	"Delegator",
+}
+
+// razorSupply is the RAZOR minted to the deployer
+var razorSupply = new(big.Int).Mul(big.NewInt(1e9), big.NewInt(1e18))
+
+// transactionTimeout is the maximum duration to wait for a transaction of the devnet to be mined
+const transactionTimeout = 30 * time.Second
+
+type artifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode string          `json:"bytecode"`
+}
+
+type deployedContract struct {
+	abi      abi.ABI
+	contract *bind.BoundContract
+}
+
+//This function returns the directory of the compiled contracts and fails the test if it isn't set,
+//as the end to end tests are only built with the e2e tag and shouldn't pass without running
+func artifactsDirectory(t testing.TB) string {
+	t.Helper()
+	artifactsDir := os.Getenv(ArtifactsEnv)
+	if artifactsDir == "" {
+		t.Fatalf("%s is not set, it has to be the directory of the compiled contracts to run the end to end tests", ArtifactsEnv)
+	}
+	return artifactsDir
+}
+
+//This function deploys and initialises the Razor contracts, grants every role to the deployer and the contracts and
+//sets the epoch length of the contracts to the epoch length of the node
+func (d *Devnet) deployContracts(artifactsDir string) error {
+	deployed := make(map[string]deployedContract)
+	for _, name := range razorContracts {
+		contractArtifact, err := readArtifact(artifactsDir, name)
+		if err != nil {
+			return err
+		}
+		parsed, err := abi.JSON(strings.NewReader(string(contractArtifact.ABI)))
+		if err != nil {
+			return fmt.Errorf("failed to parse abi of %s: %w", name, err)
+		}
+		args, err := d.argumentsFor(parsed.Constructor.Inputs)
+		if err != nil {
+			return fmt.Errorf("constructor of %s: %w", name, err)
+		}
+		address, txn, contract, err := bind.DeployContract(d.Deployer, parsed, common.FromHex(contractArtifact.Bytecode), d.Client, args...)
+		if err != nil {
+			return fmt.Errorf("failed to deploy %s: %w", name, err)
+		}
+		if err := d.waitMined(txn); err != nil {
+			return fmt.Errorf("failed to deploy %s: %w", name, err)
+		}
+		d.Contracts[name] = address
+		deployed[name] = deployedContract{abi: parsed, contract: contract}
+	}
+
+	// The contracts are initialised once all of them are deployed, as they refer to each other
+	for _, name := range razorContracts {
+		contract := deployed[name]
+		initialize, ok := contract.abi.Methods["initialize"]
+		if !ok {
+			continue
+		}
+		args, err := d.argumentsFor(initialize.Inputs)
+		if err != nil {
+			return fmt.Errorf("initialize of %s: %w", name, err)
+		}
+		if err := d.transact(contract.contract, "initialize", args...); err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", name, err)
+		}
+	}
+
+	for _, name := range razorContracts {
+		if err := d.grantRoles(deployed[name]); err != nil {
+			return fmt.Errorf("failed to grant roles of %s: %w", name, err)
+		}
+		if err := d.setEpochLength(deployed[name]); err != nil {
+			return fmt.Errorf("failed to set epoch length of %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+//This function returns the arguments of a constructor or initializer.
+//Address arguments are named after a contract, e.g. stakeManagerAddress, and are set to the address of that contract.
+func (d *Devnet) argumentsFor(inputs abi.Arguments) ([]interface{}, error) {
+	args := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		switch {
+		case input.Type.T == abi.AddressTy:
+			address, ok := d.contractAddressFor(input.Name)
+			if !ok {
+				return nil, fmt.Errorf("no contract deployed for argument %s", input.Name)
+			}
+			args[i] = address
+		case input.Type.T == abi.UintTy && strings.EqualFold(strings.TrimPrefix(input.Name, "_"), "initialSupply"):
+			args[i] = razorSupply
+		default:
+			return nil, fmt.Errorf("unknown argument %s of type %s", input.Name, input.Type)
+		}
+	}
+	return args, nil
+}
+
+//This function returns the address of the contract an address argument is named after
+func (d *Devnet) contractAddressFor(argumentName string) (common.Address, bool) {
+	name := strings.ToLower(strings.TrimPrefix(argumentName, "_"))
+	name = strings.TrimSuffix(name, "address")
+	for contract, address := range d.Contracts {
+		contractName := strings.ToLower(contract)
+		// Some arguments are in plural, e.g. voteManagersAddress
+		if name == contractName || name == contractName+"s" {
+			return address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+//This function grants every role of a contract, read from its *_ROLE getters, to the deployer and every contract
+func (d *Devnet) grantRoles(contract deployedContract) error {
+	if _, ok := contract.abi.Methods["grantRole"]; !ok {
+		return nil
+	}
+	grantees := []common.Address{d.Deployer.From}
+	for _, address := range d.Contracts {
+		grantees = append(grantees, address)
+	}
+	for name, method := range contract.abi.Methods {
+		if !strings.HasSuffix(name, "_ROLE") || len(method.Inputs) != 0 || len(method.Outputs) != 1 {
+			continue
+		}
+		var output []interface{}
+		if err := contract.contract.Call(&bind.CallOpts{}, &output, name); err != nil {
+			return fmt.Errorf("failed to get %s: %w", name, err)
+		}
+		role, ok := output[0].([32]byte)
+		if !ok {
+			continue
+		}
+		for _, grantee := range grantees {
+			if err := d.transact(contract.contract, "grantRole", role, grantee); err != nil {
+				return fmt.Errorf("failed to grant %s to %s: %w", name, grantee.Hex(), err)
+			}
+		}
+	}
+	return nil
+}
+
+//This function sets the epoch length of a contract which has one to the epoch length the node expects
+func (d *Devnet) setEpochLength(contract deployedContract) error {
+	method, ok := contract.abi.Methods["setEpochLength"]
+	if !ok || len(method.Inputs) != 1 {
+		return nil
+	}
+	epochLength := reflect.ValueOf(core.EpochLength).Convert(method.Inputs[0].Type.GetType()).Interface()
+	return d.transact(contract.contract, "setEpochLength", epochLength)
+}
+
+//This function sends a transaction from the deployer and waits for it to be mined
+func (d *Devnet) transact(contract *bind.BoundContract, method string, args ...interface{}) error {
+	txn, err := contract.Transact(d.Deployer, method, args...)
+	if err != nil {
+		return err
+	}
+	return d.waitMined(txn)
+}
+
+//This function waits for a transaction to be mined and returns an error if it reverted
+func (d *Devnet) waitMined(txn *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), transactionTimeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(ctx, d.Client, txn)
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", txn.Hash().Hex())
+	}
+	return nil
+}
+
+//This function finds the artifact of a contract, hardhat nests them in a directory per source file
+func readArtifact(artifactsDir string, name string) (artifact, error) {
+	var artifactPath string
+	err := filepath.WalkDir(artifactsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && entry.Name() == name+".json" {
+			artifactPath = path
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return artifact{}, err
+	}
+	if artifactPath == "" {
+		return artifact{}, fmt.Errorf("no artifact for %s in %s", name, artifactsDir)
+	}
+
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return artifact{}, err
+	}
+	var contractArtifact artifact
+	if err := json.Unmarshal(data, &contractArtifact); err != nil {
+		return artifact{}, fmt.Errorf("failed to parse artifact of %s: %w", name, err)
+	}
+	if contractArtifact.Bytecode == "" || contractArtifact.Bytecode == "0x" {
+		return artifact{}, errors.New("artifact of " + name + " has no bytecode")
+	}
+	return contractArtifact, nil
+}
+
+// FundRazor transfers RAZOR from the deployer to the given accounts
+func (d *Devnet) FundRazor(t testing.TB, amount *big.Int, accounts ...common.Address) {
+	t.Helper()
+	razor, err := bindings.NewRAZOR(d.Contracts["RAZOR"], d.Client)
+	if err != nil {
+		t.Fatalf("Error in binding RAZOR: %v", err)
+	}
+	for _, account := range accounts {
+		txn, err := razor.Transfer(d.Deployer, account, amount)
+		if err == nil {
+			err = d.waitMined(txn)
+		}
+		if err != nil {
+			t.Fatalf("Error in transferring RAZOR to %s: %v", account.Hex(), err)
+		}
+	}
+}
+
+// CreateJob creates a JSON job fetching the value at the selector from the url
+func (d *Devnet) CreateJob(t testing.TB, name string, url string, selector string) {
+	t.Helper()
+	collectionManager, err := bindings.NewCollectionManager(d.Contracts["CollectionManager"], d.Client)
+	if err != nil {
+		t.Fatalf("Error in binding CollectionManager: %v", err)
+	}
+	txn, err := collectionManager.CreateJob(d.Deployer, 100, 0, 0, name, selector, url)
+	if err == nil {
+		err = d.waitMined(txn)
+	}
+	if err != nil {
+		t.Fatalf("Error in creating job %s: %v", name, err)
+	}
+}
+
+// CreateCollection creates a collection of the given jobs aggregated with the median, collections can only be created in the confirm state
+func (d *Devnet) CreateCollection(t testing.TB, name string, jobIds []uint16) {
+	t.Helper()
+	collectionManager, err := bindings.NewCollectionManager(d.Contracts["CollectionManager"], d.Client)
+	if err != nil {
+		t.Fatalf("Error in binding CollectionManager: %v", err)
+	}
+	txn, err := collectionManager.CreateCollection(d.Deployer, 0, 0, 1, jobIds, name)
+	if err == nil {
+		err = d.waitMined(txn)
+	}
+	if err != nil {
+		t.Fatalf("Error in creating collection %s: %v", name, err)
+	}
+}
@@ -0,0 +1,196 @@
+//go:build e2e
+
+// Package e2e runs the Razor contracts on a simulated chain, so that stakers can vote end to end without a live chain.
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"razor/core"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainId is the chain id of the simulated chain
+var ChainId = params.AllDevChainProtocolChanges.ChainID
+
+const (
+	// miningInterval is the interval at which pending transactions are mined
+	miningInterval = 100 * time.Millisecond
+	// stateOffset is how far into a state AdvanceToState moves the chain, it is beyond the buffer of the state
+	stateOffset = core.StateLength / 4
+)
+
+// sFuelAllocation is the sFUEL every account of the devnet starts with
+var sFuelAllocation = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+// Devnet is a simulated chain served over HTTP on which the Razor contracts are deployed.
+// Pending transactions are mined right away, and the time of the chain only moves forward with AdvanceToState.
+type Devnet struct {
+	// URL is the HTTP endpoint of the devnet
+	URL    string
+	Client *ethclient.Client
+	// Deployer deploys the contracts and holds every role of the contracts
+	Deployer *bind.TransactOpts
+	// Contracts are the addresses of the deployed contracts by contract name
+	Contracts map[string]common.Address
+
+	backend *simulated.Backend
+	// mu serializes mining and time adjustments, the time can only be adjusted on an empty block
+	mu sync.Mutex
+}
+
+// NewDevnet starts a devnet funding the given accounts with sFUEL and deploys the Razor contracts from the compiled
+// contracts in the directory set by ArtifactsEnv. The test fails if the directory isn't set.
+func NewDevnet(t testing.TB, accounts ...common.Address) *Devnet {
+	t.Helper()
+
+	artifactsDir := artifactsDirectory(t)
+
+	deployerKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Error in generating deployer key: %v", err)
+	}
+	deployer := crypto.PubkeyToAddress(deployerKey.PublicKey)
+
+	alloc := types.GenesisAlloc{deployer: {Balance: sFuelAllocation}}
+	for _, account := range accounts {
+		alloc[account] = types.Account{Balance: sFuelAllocation}
+	}
+
+	port := freePort(t)
+	backend := simulated.NewBackend(alloc, simulated.WithBlockGasLimit(core.DefaultGasLimitOverride), func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		nodeConf.HTTPHost = "127.0.0.1"
+		nodeConf.HTTPPort = port
+		nodeConf.HTTPModules = []string{"eth", "net", "web3"}
+		nodeConf.HTTPVirtualHosts = []string{"*"}
+	})
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+
+	devnet := &Devnet{
+		URL:       fmt.Sprintf("http://127.0.0.1:%d", port),
+		Contracts: make(map[string]common.Address),
+		backend:   backend,
+	}
+	devnet.Client, err = ethclient.Dial(devnet.URL)
+	if err != nil {
+		t.Fatalf("Error in connecting to devnet: %v", err)
+	}
+	t.Cleanup(devnet.Client.Close)
+
+	devnet.Deployer, err = bind.NewKeyedTransactorWithChainID(deployerKey, ChainId)
+	if err != nil {
+		t.Fatalf("Error in getting deployer transactor: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go devnet.mine(ctx)
+
+	if err := devnet.deployContracts(artifactsDir); err != nil {
+		t.Fatalf("Error in deploying Razor contracts: %v", err)
+	}
+	return devnet
+}
+
+// SetContractAddresses points the node to the contracts deployed on the devnet until the test ends
+func (d *Devnet) SetContractAddresses(t testing.TB) {
+	addresses := map[*string]string{
+		&core.RAZORAddress:             "RAZOR",
+		&core.StakeManagerAddress:      "StakeManager",
+		&core.VoteManagerAddress:       "VoteManager",
+		&core.BlockManagerAddress:      "BlockManager",
+		&core.CollectionManagerAddress: "CollectionManager",
+	}
+	for address, contract := range addresses {
+		previous := *address
+		*address = d.Contracts[contract].Hex()
+		t.Cleanup(func() { *address = previous })
+	}
+
+	previousChainId := core.ChainId
+	core.ChainId = ChainId
+	t.Cleanup(func() { core.ChainId = previousChainId })
+}
+
+// LatestHeader returns the header of the latest block of the devnet
+func (d *Devnet) LatestHeader(t testing.TB) *types.Header {
+	t.Helper()
+	header, err := d.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Error in getting latest header: %v", err)
+	}
+	return header
+}
+
+// AdvanceToState moves the time of the chain forward into the given state, in the current epoch if the state is still
+// ahead and in the next epoch otherwise, and returns the header of the block at that time
+func (d *Devnet) AdvanceToState(t testing.TB, state int64) *types.Header {
+	t.Helper()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.commitPending()
+	latest := d.LatestHeader(t)
+	epochStart := latest.Time - latest.Time%core.EpochLength
+	target := epochStart + uint64(state)*core.StateLength + stateOffset
+	if target <= latest.Time {
+		target += core.EpochLength
+	}
+	if err := d.backend.AdjustTime(time.Duration(target-latest.Time) * time.Second); err != nil {
+		t.Fatalf("Error in advancing devnet time: %v", err)
+	}
+	return d.LatestHeader(t)
+}
+
+// mine mines the pending transactions until the context is cancelled
+func (d *Devnet) mine(ctx context.Context) {
+	ticker := time.NewTicker(miningInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.mu.Lock()
+			d.commitPending()
+			d.mu.Unlock()
+		}
+	}
+}
+
+// commitPending mines a block if there are pending transactions, the caller must hold the lock
+func (d *Devnet) commitPending() {
+	pending, err := d.Client.PendingTransactionCount(context.Background())
+	if err != nil || pending == 0 {
+		return
+	}
+	d.backend.Commit()
+}
+
+// freePort returns a local port which isn't in use
+func freePort(t testing.TB) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error in finding a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,46 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// JobServer serves the values fetched by the jobs of the devnet, each job fetches the value at the "value" selector of its path
+type JobServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewJobServer starts a job server which is closed when the test ends
+func NewJobServer(t testing.TB) *JobServer {
+	jobServer := &JobServer{values: make(map[string]float64)}
+	jobServer.Server = httptest.NewServer(http.HandlerFunc(jobServer.serve))
+	t.Cleanup(jobServer.Close)
+	return jobServer
+}
+
+// SetValue sets the value served at the path and returns the URL of the path
+func (s *JobServer) SetValue(path string, value float64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[path] = value
+	return s.URL + path
+}
+
+func (s *JobServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	value, ok := s.values[r.URL.Path]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]float64{"value": value})
+}
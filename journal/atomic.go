@@ -0,0 +1,89 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomically writes data to a temporary file in the same directory, syncs it and renames it
+// over filePath, so that a crash never leaves filePath partially written.
+// If filePath is a symlink, the file it points to is replaced and the symlink is kept.
+func WriteFileAtomically(filePath string, data []byte, perm os.FileMode) error {
+	filePath, err := resolveSymlink(filePath)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(filePath)
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempFilePath := tempFile.Name()
+	// The temporary file is removed if anything fails before it is renamed
+	defer os.Remove(tempFilePath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tempFile.Chmod(perm); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to set permissions of temporary file: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+	return syncDir(dir)
+}
+
+// resolveSymlink returns the path of the file filePath points to if it is a symlink and filePath otherwise.
+// A file which doesn't exist yet is created at filePath.
+func resolveSymlink(filePath string) (string, error) {
+	info, err := os.Lstat(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return filePath, nil
+		}
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return filePath, nil
+	}
+	resolvedPath, err := filepath.EvalSymlinks(filePath)
+	if err == nil {
+		return resolvedPath, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to resolve symlink: %w", err)
+	}
+	// The symlink points to a file which doesn't exist yet, it is created where the symlink points to
+	target, err := os.Readlink(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read symlink: %w", err)
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(filePath), target)
+	}
+	return target, nil
+}
+
+// syncDir makes the rename durable by syncing the directory containing the file.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	// Syncing a directory is not supported on all platforms, the rename has already happened at this point
+	_ = d.Sync()
+	return nil
+}
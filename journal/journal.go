@@ -0,0 +1,278 @@
+// Package journal provides a crash-safe, append-only store of the data a staker used and produced in every epoch
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Kind is the type of action recorded in a journal entry.
+type Kind string
+
+const (
+	KindCommit  Kind = "commit"
+	KindReveal  Kind = "reveal"
+	KindPropose Kind = "propose"
+	KindDispute Kind = "dispute"
+)
+
+// Entry is a single record in the journal. Data holds the JSON encoded payload of the entry
+// and Checksum is the CRC32 checksum of Data which is verified while loading the journal.
+type Entry struct {
+	Epoch     uint32          `json:"epoch"`
+	Kind      Kind            `json:"kind"`
+	Timestamp time.Time       `json:"timestamp"`
+	Migrated  bool            `json:"migrated,omitempty"`
+	Checksum  uint32          `json:"checksum"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the payload of the entry into the value pointed to by v.
+func (e Entry) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Journal is an append-only file of newline separated JSON entries.
+// Every append is synced to disk before it is acknowledged, and a partially written
+// entry left behind by a crash is truncated the next time the journal is opened.
+type Journal struct {
+	mu       sync.RWMutex
+	filePath string
+	file     *os.File
+	entries  []Entry
+}
+
+// Open opens the journal at the given path, creating it if it doesn't exist, and loads all its entries.
+func Open(filePath string) (*Journal, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+
+	entries, validLength, err := load(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat journal: %w", err)
+	}
+	if info.Size() > validLength {
+		logrus.Warnf("Journal %s has a partially written entry at the end, truncating %d bytes", filePath, info.Size()-validLength)
+		if err := file.Truncate(validLength); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to truncate journal: %w", err)
+		}
+		if err := file.Sync(); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to sync journal: %w", err)
+		}
+	}
+	if _, err := file.Seek(validLength, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek journal: %w", err)
+	}
+
+	return &Journal{
+		filePath: filePath,
+		file:     file,
+		entries:  entries,
+	}, nil
+}
+
+// load reads all the entries from the file and returns them along with the length of the file
+// up to the end of the last complete entry. Corrupted entries in between are skipped.
+func load(file *os.File) ([]Entry, int64, error) {
+	var (
+		entries     []Entry
+		offset      int64
+		validLength int64
+	)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			offset += int64(len(line))
+			// A line without a trailing newline is an entry which was not completely written
+			if line[len(line)-1] != '\n' {
+				break
+			}
+			validLength = offset
+
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			var entry Entry
+			if unmarshalErr := json.Unmarshal(line, &entry); unmarshalErr != nil {
+				logrus.Warnf("Skipping corrupted journal entry at offset %d: %v", offset-int64(len(line)), unmarshalErr)
+				continue
+			}
+			if crc32.ChecksumIEEE(entry.Data) != entry.Checksum {
+				logrus.Warnf("Skipping journal entry of epoch %d with invalid checksum", entry.Epoch)
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, 0, fmt.Errorf("failed to read journal: %w", err)
+		}
+	}
+	return entries, validLength, nil
+}
+
+// Append records data for the given epoch and kind. The entry is synced to disk before Append returns.
+func (j *Journal) Append(epoch uint32, kind Kind, data interface{}) error {
+	return j.append(epoch, kind, data, false)
+}
+
+// AppendMigrated records data imported from an older storage format. Such entries are marked as migrated.
+func (j *Journal) AppendMigrated(epoch uint32, kind Kind, data interface{}) error {
+	return j.append(epoch, kind, data, true)
+}
+
+func (j *Journal) append(epoch uint32, kind Kind, data interface{}, migrated bool) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal data: %w", err)
+	}
+	entry := Entry{
+		Epoch:     epoch,
+		Kind:      kind,
+		Timestamp: time.Now().UTC(),
+		Migrated:  migrated,
+		Checksum:  crc32.ChecksumIEEE(payload),
+		Data:      payload,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return errors.New("journal is closed")
+	}
+	if _, err := j.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+// IsEmpty returns true if no entries have been recorded in the journal.
+func (j *Journal) IsEmpty() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return len(j.entries) == 0
+}
+
+// Entries returns all the entries of the journal in the order they were recorded.
+func (j *Journal) Entries() []Entry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	entries := make([]Entry, len(j.entries))
+	copy(entries, j.entries)
+	return entries
+}
+
+// EntriesForEpoch returns all the entries recorded for the given epoch.
+func (j *Journal) EntriesForEpoch(epoch uint32) []Entry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	var entries []Entry
+	for _, entry := range j.entries {
+		if entry.Epoch == epoch {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Latest returns the most recent entry of the given kind.
+func (j *Journal) Latest(kind Kind) (Entry, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		if j.entries[i].Kind == kind {
+			return j.entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// LatestForEpoch returns the most recent entry of the given kind recorded for the given epoch.
+func (j *Journal) LatestForEpoch(kind Kind, epoch uint32) (Entry, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		if j.entries[i].Kind == kind && j.entries[i].Epoch == epoch {
+			return j.entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// Trim drops the entries recorded before the given epoch from memory so that a long running staker doesn't keep
+// every entry it ever recorded. The entries stay in the journal file and are loaded again the next time it is opened.
+func (j *Journal) Trim(oldestEpoch uint32) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	retained := j.entries[:0]
+	for _, entry := range j.entries {
+		if entry.Epoch >= oldestEpoch {
+			retained = append(retained, entry)
+		}
+	}
+	// Clearing the dropped entries so that the memory of their payloads can be reclaimed
+	for i := len(retained); i < len(j.entries); i++ {
+		j.entries[i] = Entry{}
+	}
+	j.entries = retained
+}
+
+// Path returns the path of the journal file.
+func (j *Journal) Path() string {
+	return j.filePath
+}
+
+// Close closes the underlying journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
@@ -0,0 +1,249 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testData struct {
+	Values []uint32
+}
+
+func TestAppendAndReopen(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !j.IsEmpty() {
+		t.Fatal("expected new journal to be empty")
+	}
+	if err := j.Append(10, KindCommit, testData{Values: []uint32{1, 2}}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := j.Append(10, KindReveal, testData{Values: []uint32{3}}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := j.Append(11, KindCommit, testData{Values: []uint32{4}}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	if got := len(reopened.Entries()); got != 3 {
+		t.Fatalf("expected 3 entries, got %d", got)
+	}
+	if got := len(reopened.EntriesForEpoch(10)); got != 2 {
+		t.Errorf("expected 2 entries for epoch 10, got %d", got)
+	}
+	latestCommit, found := reopened.Latest(KindCommit)
+	if !found || latestCommit.Epoch != 11 {
+		t.Errorf("expected latest commit to be of epoch 11, got %+v", latestCommit)
+	}
+	commit, found := reopened.LatestForEpoch(KindCommit, 10)
+	if !found {
+		t.Fatal("expected commit entry for epoch 10")
+	}
+	var data testData
+	if err := commit.Decode(&data); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(data.Values, []uint32{1, 2}) {
+		t.Errorf("expected decoded values [1 2], got %v", data.Values)
+	}
+	if _, found := reopened.LatestForEpoch(KindPropose, 10); found {
+		t.Error("expected no propose entry for epoch 10")
+	}
+}
+
+func TestOpenTruncatesPartialEntry(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := j.Append(5, KindCommit, testData{Values: []uint32{1}}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	j.Close()
+
+	validContent, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a crash in the middle of writing an entry
+	if err := os.WriteFile(filePath, append(validContent, []byte(`{"epoch":6,"kind":"comm`)...), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got := len(reopened.Entries()); got != 1 {
+		t.Fatalf("expected 1 entry, got %d", got)
+	}
+	if err := reopened.Append(6, KindCommit, testData{Values: []uint32{2}}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	reopened.Close()
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], `{"epoch":6,"kind":"commit"`) {
+		t.Errorf("expected partial entry to be replaced by the new entry, got %s", content)
+	}
+}
+
+func TestOpenSkipsCorruptedEntry(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "journal.jsonl")
+	content := `{"epoch":1,"kind":"commit","checksum":1,"data":{"Values":[1]}}` + "\n" + "not json\n"
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	j, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer j.Close()
+	if !j.IsEmpty() {
+		t.Errorf("expected corrupted entries to be skipped, got %+v", j.Entries())
+	}
+}
+
+func TestAppendMigrated(t *testing.T) {
+	j, err := Open(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer j.Close()
+	if err := j.AppendMigrated(3, KindPropose, testData{}); err != nil {
+		t.Fatalf("AppendMigrated() error = %v", err)
+	}
+	entry, found := j.Latest(KindPropose)
+	if !found || !entry.Migrated {
+		t.Errorf("expected migrated propose entry, got %+v", entry)
+	}
+}
+
+func TestWriteFileAtomically(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "data.json")
+	if err := os.WriteFile(filePath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomically(filePath, []byte("new"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new" {
+		t.Errorf("expected file content to be replaced, got %s", content)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected temporary file to be removed, found %d files", len(files))
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected permissions 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestTrim(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer j.Close()
+	for epoch := uint32(1); epoch <= 4; epoch++ {
+		if err := j.Append(epoch, KindCommit, testData{Values: []uint32{epoch}}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	j.Trim(3)
+	entries := j.Entries()
+	if len(entries) != 2 || entries[0].Epoch != 3 || entries[1].Epoch != 4 {
+		t.Errorf("expected only the entries of epochs 3 and 4 to be kept, got %+v", entries)
+	}
+	if err := j.Append(5, KindCommit, testData{}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if _, found := j.LatestForEpoch(KindCommit, 5); !found {
+		t.Error("expected entry appended after trimming to be found")
+	}
+
+	reopened, err := Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+	if got := len(reopened.Entries()); got != 5 {
+		t.Errorf("expected trimmed entries to stay in the journal file, got %d entries", got)
+	}
+}
+
+func TestWriteFileAtomicallyKeepsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	targetDir := t.TempDir()
+	targetPath := filepath.Join(targetDir, "data.json")
+	if err := os.WriteFile(targetPath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	linkPath := filepath.Join(dir, "data.json")
+	if err := os.Symlink(targetPath, linkPath); err != nil {
+		t.Skipf("symlinks are not supported: %v", err)
+	}
+
+	if err := WriteFileAtomically(linkPath, []byte("new"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+	info, err := os.Lstat(linkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("expected the symlink to be kept")
+	}
+	content, err := os.ReadFile(targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new" {
+		t.Errorf("expected the file the symlink points to to be replaced, got %s", content)
+	}
+
+	danglingPath := filepath.Join(dir, "dangling.json")
+	if err := os.Symlink("missing.json", danglingPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomically(danglingPath, []byte("new"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "missing.json")); err != nil || string(content) != "new" {
+		t.Errorf("expected the file to be created where the dangling symlink points to, got %s, error %v", content, err)
+	}
+}
@@ -0,0 +1,122 @@
+package lease
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"razor/core"
+	"razor/journal"
+)
+
+// FileBackend keeps the lease in a file, which has to be on storage shared by all the nodes.
+// A lock file created exclusively next to the lease file guards reading and writing the lease,
+// as exclusive creation works on every platform and on network file systems.
+type FileBackend struct {
+	filePath       string
+	staleLockAfter time.Duration
+}
+
+// NewFileBackend creates a backend which keeps the lease in the file at filePath.
+func NewFileBackend(filePath string) *FileBackend {
+	return &FileBackend{
+		filePath:       filePath,
+		staleLockAfter: core.LeaseStaleLockTimeout * time.Second,
+	}
+}
+
+// TryAcquire grants the lease to holder until now+ttl if the lease is free, expired or already held by holder.
+func (b *FileBackend) TryAcquire(holder string, now time.Time, ttl time.Duration) (Record, bool, error) {
+	unlock, err := b.lock()
+	if err != nil {
+		return Record{}, false, err
+	}
+	defer unlock()
+
+	record, err := b.read()
+	if err != nil {
+		return Record{}, false, err
+	}
+	if record.Holder != "" && record.Holder != holder && now.Before(record.ExpiresAt) {
+		return record, false, nil
+	}
+
+	record = Record{
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := b.write(record); err != nil {
+		return Record{}, false, err
+	}
+	return record, true, nil
+}
+
+// Release frees the lease if it is held by holder.
+func (b *FileBackend) Release(holder string) error {
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	record, err := b.read()
+	if err != nil {
+		return err
+	}
+	if record.Holder != holder {
+		return nil
+	}
+	return b.write(Record{})
+}
+
+// lock creates the lock file of the lease and returns the function which removes it.
+// A lock file older than the stale lock timeout is left by a node which crashed while holding it and is removed.
+func (b *FileBackend) lock() (func(), error) {
+	lockFilePath := b.filePath + ".lock"
+	for attempt := 0; attempt < 2; attempt++ {
+		lockFile, err := os.OpenFile(lockFilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			lockFile.Close()
+			return func() { os.Remove(lockFilePath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lease lock file: %w", err)
+		}
+		info, statErr := os.Stat(lockFilePath)
+		if statErr != nil || time.Since(info.ModTime()) < b.staleLockAfter {
+			break
+		}
+		if err := os.Remove(lockFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale lease lock file: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("lease file %s is locked by another node", b.filePath)
+}
+
+func (b *FileBackend) read() (Record, error) {
+	data, err := os.ReadFile(b.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Record{}, nil
+	}
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to read lease file: %w", err)
+	}
+	var record Record
+	if len(data) == 0 {
+		return record, nil
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return Record{}, fmt.Errorf("failed to decode lease file: %w", err)
+	}
+	return record, nil
+}
+
+func (b *FileBackend) write(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return journal.WriteFileAtomically(b.filePath, data, 0600)
+}
@@ -0,0 +1,146 @@
+// Package lease provides leader election between redundant vote nodes of a staker.
+// The nodes compete for a lease kept by a shared backend and only the node holding the lease, the leader, sends transactions.
+// A standby node takes over once the leader stops renewing the lease and it expires.
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"razor/core"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNotLeader is returned when a node which doesn't hold the lease tries to act as the leader.
+var ErrNotLeader = errors.New("lease is not held by this node")
+
+// Record is the lease as kept by a backend.
+type Record struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Backend keeps the lease for all the nodes. TryAcquire must be atomic across all the nodes using the backend.
+type Backend interface {
+	// TryAcquire grants the lease to holder until now+ttl if the lease is free, expired or already held by holder.
+	// It returns the lease after the attempt and whether holder holds it.
+	TryAcquire(holder string, now time.Time, ttl time.Duration) (Record, bool, error)
+	// Release frees the lease if it is held by holder.
+	Release(holder string) error
+}
+
+// Config holds the durations used to hold the lease.
+type Config struct {
+	TTL           time.Duration
+	RenewInterval time.Duration
+}
+
+// DefaultConfig returns the config built from the lease constants in core.
+func DefaultConfig() Config {
+	return Config{
+		TTL:           core.LeaseTTL * time.Second,
+		RenewInterval: core.LeaseRenewInterval * time.Second,
+	}
+}
+
+// Elector acquires and renews the lease for one node.
+type Elector struct {
+	mu          sync.Mutex
+	backend     Backend
+	holder      string
+	config      Config
+	leaderUntil time.Time
+	current     Record
+	now         func() time.Time
+}
+
+// NewElector creates an elector which competes for the lease of backend as holder.
+func NewElector(backend Backend, holder string, config Config) *Elector {
+	return &Elector{
+		backend: backend,
+		holder:  holder,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// Holder returns the name with which the node competes for the lease.
+func (e *Elector) Holder() string {
+	return e.holder
+}
+
+// Renew acquires the lease if it is free or renews it if the node already holds it, and returns whether the node is the leader.
+// If the backend can't be reached the node stays the leader until its last renewed lease is about to expire.
+func (e *Elector) Renew() (bool, error) {
+	now := e.now()
+	record, acquired, err := e.backend.TryAcquire(e.holder, now, e.config.TTL)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		return now.Before(e.leaderUntil), err
+	}
+	e.current = record
+	if !acquired {
+		e.leaderUntil = time.Time{}
+		return false, nil
+	}
+	// The node stops acting as the leader one renew interval before the lease expires, so that it never overlaps with the next leader
+	e.leaderUntil = record.ExpiresAt.Add(-e.config.RenewInterval)
+	return now.Before(e.leaderUntil), nil
+}
+
+// IsLeader returns whether the node holds the lease and it is not about to expire.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.now().Before(e.leaderUntil)
+}
+
+// Current returns the lease as seen in the last renewal.
+func (e *Elector) Current() Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.current
+}
+
+// Release gives up the lease if the node holds it, so that a standby can take over without waiting for the lease to expire.
+func (e *Elector) Release() error {
+	e.mu.Lock()
+	e.leaderUntil = time.Time{}
+	e.mu.Unlock()
+	return e.backend.Release(e.holder)
+}
+
+// Run renews the lease every renew interval until ctx is done and then releases it.
+// onChange is called whenever the node becomes the leader or stops being the leader.
+func (e *Elector) Run(ctx context.Context, onChange func(isLeader bool)) {
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+
+	wasLeader := false
+	for {
+		isLeader, err := e.Renew()
+		if err != nil {
+			logrus.Errorf("Error in renewing lease of %s: %v", e.holder, err)
+		}
+		if isLeader != wasLeader {
+			wasLeader = isLeader
+			if onChange != nil {
+				onChange(isLeader)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := e.Release(); err != nil {
+				logrus.Errorf("Error in releasing lease of %s: %v", e.holder, err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileBackendTryAcquire(t *testing.T) {
+	backend := NewFileBackend(filepath.Join(t.TempDir(), "vote.lease"))
+	now := time.Unix(1700000000, 0)
+	ttl := 15 * time.Second
+
+	steps := []struct {
+		name       string
+		holder     string
+		at         time.Time
+		wantHolder string
+		wantOk     bool
+	}{
+		{name: "free lease is acquired", holder: "node1", at: now, wantHolder: "node1", wantOk: true},
+		{name: "held lease is not acquired by another node", holder: "node2", at: now.Add(5 * time.Second), wantHolder: "node1", wantOk: false},
+		{name: "held lease is renewed by its holder", holder: "node1", at: now.Add(10 * time.Second), wantHolder: "node1", wantOk: true},
+		{name: "renewed lease is not acquired before it expires", holder: "node2", at: now.Add(20 * time.Second), wantHolder: "node1", wantOk: false},
+		{name: "expired lease is acquired by another node", holder: "node2", at: now.Add(25 * time.Second), wantHolder: "node2", wantOk: true},
+	}
+	for _, step := range steps {
+		record, ok, err := backend.TryAcquire(step.holder, step.at, ttl)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", step.name, err)
+		}
+		if ok != step.wantOk || record.Holder != step.wantHolder {
+			t.Errorf("%s: got holder %s acquired %v, want holder %s acquired %v", step.name, record.Holder, ok, step.wantHolder, step.wantOk)
+		}
+	}
+
+	if err := backend.Release("node1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := backend.TryAcquire("node1", now.Add(26*time.Second), ttl); ok {
+		t.Error("Expected release by a node which doesn't hold the lease to keep the lease")
+	}
+	if err := backend.Release("node2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := backend.TryAcquire("node1", now.Add(27*time.Second), ttl); !ok {
+		t.Error("Expected released lease to be acquired")
+	}
+}
+
+func TestFileBackendLock(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "vote.lease")
+	backend := NewFileBackend(filePath)
+	lockFilePath := filePath + ".lock"
+
+	if err := os.WriteFile(lockFilePath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := backend.TryAcquire("node1", time.Now(), time.Minute); err == nil {
+		t.Error("Expected an error when the lease file is locked by another node")
+	}
+
+	staleTime := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(lockFilePath, staleTime, staleTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := backend.TryAcquire("node1", time.Now(), time.Minute); err != nil || !ok {
+		t.Errorf("Expected stale lock to be removed and lease to be acquired, got acquired %v error %v", ok, err)
+	}
+	if _, err := os.Stat(lockFilePath); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected lock file to be removed after acquiring the lease")
+	}
+}
+
+type failingBackend struct {
+	err error
+}
+
+func (b *failingBackend) TryAcquire(holder string, now time.Time, ttl time.Duration) (Record, bool, error) {
+	if b.err != nil {
+		return Record{}, false, b.err
+	}
+	return Record{Holder: holder, ExpiresAt: now.Add(ttl)}, true, nil
+}
+
+func (b *failingBackend) Release(holder string) error {
+	return nil
+}
+
+func TestElector(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	config := Config{TTL: 15 * time.Second, RenewInterval: 5 * time.Second}
+	backend := NewFileBackend(filepath.Join(t.TempDir(), "vote.lease"))
+
+	leader := NewElector(backend, "node1", config)
+	standby := NewElector(backend, "node2", config)
+	leader.now = func() time.Time { return now }
+	standby.now = func() time.Time { return now }
+
+	if isLeader, err := leader.Renew(); err != nil || !isLeader {
+		t.Fatalf("Expected first node to become the leader, got %v %v", isLeader, err)
+	}
+	if isLeader, err := standby.Renew(); err != nil || isLeader {
+		t.Fatalf("Expected second node to stay standby, got %v %v", isLeader, err)
+	}
+	if standby.Current().Holder != "node1" {
+		t.Errorf("Expected standby to see the lease held by node1, got %s", standby.Current().Holder)
+	}
+
+	// The leader stops acting as the leader one renew interval before its lease expires
+	now = now.Add(9 * time.Second)
+	if !leader.IsLeader() {
+		t.Error("Expected node to be the leader before its lease is about to expire")
+	}
+	now = now.Add(time.Second)
+	if leader.IsLeader() {
+		t.Error("Expected node to stop being the leader when its lease is about to expire")
+	}
+	if isLeader, _ := standby.Renew(); isLeader {
+		t.Error("Expected standby not to take over before the lease expires")
+	}
+
+	now = now.Add(5 * time.Second)
+	if isLeader, err := standby.Renew(); err != nil || !isLeader {
+		t.Fatalf("Expected standby to take over once the lease expired, got %v %v", isLeader, err)
+	}
+	if isLeader, _ := leader.Renew(); isLeader {
+		t.Error("Expected previous leader to become standby")
+	}
+}
+
+func TestElectorRenewWithBackendError(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	backend := &failingBackend{}
+	elector := NewElector(backend, "node1", Config{TTL: 15 * time.Second, RenewInterval: 5 * time.Second})
+	elector.now = func() time.Time { return now }
+
+	if isLeader, err := elector.Renew(); err != nil || !isLeader {
+		t.Fatalf("Expected node to become the leader, got %v %v", isLeader, err)
+	}
+
+	backend.err = errors.New("backend error")
+	now = now.Add(5 * time.Second)
+	if isLeader, err := elector.Renew(); err == nil || !isLeader {
+		t.Errorf("Expected node to stay the leader until its lease is about to expire, got %v %v", isLeader, err)
+	}
+	now = now.Add(5 * time.Second)
+	if isLeader, err := elector.Renew(); err == nil || isLeader {
+		t.Errorf("Expected node to stop being the leader when it can't renew its lease, got %v %v", isLeader, err)
+	}
+}
+
+func TestElectorRun(t *testing.T) {
+	backend := NewFileBackend(filepath.Join(t.TempDir(), "vote.lease"))
+	elector := NewElector(backend, "node1", Config{TTL: time.Minute, RenewInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan bool, 1)
+	done := make(chan struct{})
+	go func() {
+		elector.Run(ctx, func(isLeader bool) { changes <- isLeader })
+		close(done)
+	}()
+
+	select {
+	case isLeader := <-changes:
+		if !isLeader {
+			t.Error("Expected node to become the leader")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected node to become the leader within a second")
+	}
+
+	cancel()
+	<-done
+	if elector.IsLeader() {
+		t.Error("Expected node to stop being the leader after Run returns")
+	}
+	if _, ok, err := backend.TryAcquire("node2", time.Now(), time.Minute); err != nil || !ok {
+		t.Errorf("Expected lease to be released after Run returns, got acquired %v error %v", ok, err)
+	}
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ModuleField is the field of a log entry which holds the module the entry was logged from
+const ModuleField = "module"
+
+// moduleLevel is the log level of the modules under a prefix
+type moduleLevel struct {
+	prefix string
+	level  logrus.Level
+}
+
+// logLevels holds the default log level and the levels overridden per module.
+// A module is the package path in the repo followed by the file name without extension, e.g. cmd/dispute for cmd/dispute.go,
+// and a level configured for cmd applies to every file of the cmd package unless a more specific module is configured.
+type logLevels struct {
+	defaultLevel logrus.Level
+	// modules are sorted by the length of their prefix, so that the most specific prefix matches first
+	modules []moduleLevel
+	// maxLevel is the most verbose of the default and the module levels, entries above it are not logged by any module
+	maxLevel logrus.Level
+}
+
+// currentLevels holds the log levels in use, it is replaced as a whole when the levels change
+var currentLevels = func() *atomic.Pointer[logLevels] {
+	levels := &atomic.Pointer[logLevels]{}
+	levels.Store(&logLevels{defaultLevel: logrus.InfoLevel, maxLevel: logrus.InfoLevel})
+	return levels
+}()
+
+//This function returns the log levels with the given module levels, the names of the levels are parsed with logrus.ParseLevel
+func newLogLevels(defaultLevel logrus.Level, moduleLevels map[string]string) (*logLevels, error) {
+	levels := &logLevels{defaultLevel: defaultLevel}
+	for module, levelName := range moduleLevels {
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of module %s: %w", module, err)
+		}
+		// Keys of maps in razor.yaml are lower cased when the config is read, so modules are matched case insensitively
+		prefix := strings.Trim(strings.ToLower(filepath.ToSlash(module)), "/")
+		if prefix == "" {
+			return nil, fmt.Errorf("invalid module %q", module)
+		}
+		levels.modules = append(levels.modules, moduleLevel{prefix: prefix, level: level})
+	}
+	sort.Slice(levels.modules, func(i, j int) bool {
+		return len(levels.modules[i].prefix) > len(levels.modules[j].prefix)
+	})
+	levels.maxLevel = levels.getMaxLevel()
+	return levels, nil
+}
+
+//This function returns the log level of the module
+func (levels *logLevels) level(module string) logrus.Level {
+	module = strings.ToLower(module)
+	for _, moduleLevel := range levels.modules {
+		if module == moduleLevel.prefix || strings.HasPrefix(module, moduleLevel.prefix+"/") {
+			return moduleLevel.level
+		}
+	}
+	return levels.defaultLevel
+}
+
+//This function returns the most verbose of the default and the module levels
+func (levels *logLevels) getMaxLevel() logrus.Level {
+	maxLevel := levels.defaultLevel
+	for _, moduleLevel := range levels.modules {
+		if moduleLevel.level > maxLevel {
+			maxLevel = moduleLevel.level
+		}
+	}
+	return maxLevel
+}
+
+//This function returns the module of the function which called the logger, skip is the number of stack frames to skip as in runtime.Caller
+func callerModule(skip int) string {
+	pc, file, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	function := runtime.FuncForPC(pc)
+	if function == nil {
+		return ""
+	}
+	return moduleOf(function.Name(), file)
+}
+
+//This function returns the module of the function with the given fully qualified name defined in the given file, e.g. cmd/dispute
+//for razor/cmd.(*UtilsStruct).HandleDispute in cmd/dispute.go
+func moduleOf(function string, file string) string {
+	if function == "" {
+		return ""
+	}
+	packagePath := function
+	lastSlash := strings.LastIndex(packagePath, "/")
+	if dot := strings.Index(packagePath[lastSlash+1:], "."); dot >= 0 {
+		packagePath = packagePath[:lastSlash+1+dot]
+	}
+	packagePath = strings.TrimPrefix(packagePath, "razor/")
+	fileName := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if fileName == "" || fileName == "." {
+		return packagePath
+	}
+	return packagePath + "/" + fileName
+}
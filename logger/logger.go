@@ -84,134 +84,153 @@ func InitializeLogger(fileName string, config types.Configurations) {
 			MaxAge:     config.LogFileMaxAge,
 		}
 
-		mw := io.MultiWriter(os.Stderr, lumberJackLogger)
-		globalLogger.LogrusInstance.SetOutput(mw)
+		logRouter.setFile(newFileSink(lumberJackLogger))
 	}
-	globalLogger.LogrusInstance.Formatter = &logrus.JSONFormatter{}
+	routeToSinks(globalLogger.LogrusInstance)
+	// The packages which don't use the Logger log with the logrus standard logger, so their entries are routed to the sinks as well
+	routeToSinks(logrus.StandardLogger())
+	logrus.StandardLogger().SetReportCaller(true)
+	applyLogLevels(currentLevels.Load())
 }
 
-// Error logs a simple error message.
-func (l *Logger) Error(args ...interface{}) {
+//This function makes the logrus logger write its entries only through the router to the sinks
+func routeToSinks(logrusLogger *logrus.Logger) {
+	logrusLogger.SetOutput(io.Discard)
+	logrusLogger.SetFormatter(discardFormatter{})
+	hooks := make(logrus.LevelHooks)
+	hooks.Add(logRouter)
+	logrusLogger.ReplaceHooks(hooks)
+	logrusLogger.ExitFunc = func(code int) {
+		// Entries which are still being shipped, including the fatal entry, are flushed before exiting
+		logRouter.close()
+		os.Exit(code)
+	}
+}
+
+// Configure sets the format of the logs written to stderr, the log levels of the modules and the additional sinks from the logging section of razor.yaml.
+// It can be called again to apply a changed config, the sinks configured before are flushed and closed.
+func Configure(config Config) error {
+	consoleFormatter, err := newConsoleFormatter(config.Format)
+	if err != nil {
+		return err
+	}
+	levels, err := newLogLevels(currentLevels.Load().defaultLevel, config.Levels)
+	if err != nil {
+		return err
+	}
+	sinks := make([]*sink, 0, len(config.Sinks))
+	for i, sinkConfig := range config.Sinks {
+		sink, err := newSink(sinkConfig)
+		if err != nil {
+			closeSinks(sinks)
+			return fmt.Errorf("invalid log sink %d: %w", i+1, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	logRouter.configure(newConsoleSink(consoleFormatter), sinks)
+	applyLogLevels(levels)
+	return nil
+}
+
+// Close flushes the entries which are still being shipped to the sinks and closes the log file and the sinks.
+func Close() {
+	logRouter.close()
+}
+
+// fields returns the fields which are added to every entry.
+func (l *Logger) fields(module string) logrus.Fields {
 	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
+	return logrus.Fields{
 		"address":     l.address,
 		"epoch":       epoch,
 		"blockNumber": blockNumber,
 		"version":     core.VersionWithMeta,
+		ModuleField:   module,
+	}
+}
+
+// entry returns the entry to log at the level, or nil if the level isn't enabled for the module of the caller.
+func (l *Logger) entry(level logrus.Level) *logrus.Entry {
+	levels := currentLevels.Load()
+	if level > levels.maxLevel {
+		return nil
+	}
+	// The caller of entry is a method of the Logger, whose caller is the module which logs
+	module := callerModule(2)
+	if level > levels.level(module) {
+		return nil
+	}
+	return l.LogrusInstance.WithFields(l.fields(module))
+}
+
+// Error logs a simple error message.
+func (l *Logger) Error(args ...interface{}) {
+	if entry := l.entry(logrus.ErrorLevel); entry != nil {
+		entry.Errorln(args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Errorln(args...)
 }
 
 // Info logs a simple informational message.
 func (l *Logger) Info(args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.InfoLevel); entry != nil {
+		entry.Infoln(args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Infoln(args...)
 }
 
 // Debug logs a simple debug message.
 func (l *Logger) Debug(args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.DebugLevel); entry != nil {
+		entry.Debugln(args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Debugln(args...)
 }
 
 // Fatal logs a fatal error message and exits the application.
 func (l *Logger) Fatal(args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
-	}
 	errMsg := joinString(args)
 	err := errors.New(errMsg)
-	l.LogrusInstance.WithFields(logFields).Fatalln(err)
+	l.LogrusInstance.WithFields(l.fields(callerModule(1))).Fatalln(err)
 }
 
 // Warn logs a simple warning message.
 func (l *Logger) Warn(args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.WarnLevel); entry != nil {
+		entry.Warnln(args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Warnln(args...)
 }
 
 // Errorf logs a formatted error message.
 func (l *Logger) Errorf(format string, args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.ErrorLevel); entry != nil {
+		entry.Errorf(format, args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Errorf(format, args...)
 }
 
 // Infof logs a formatted informational message.
 func (l *Logger) Infof(format string, args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.InfoLevel); entry != nil {
+		entry.Infof(format, args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Infof(format, args...)
 }
 
 // Debugf logs a formatted debug message.
 func (l *Logger) Debugf(format string, args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.DebugLevel); entry != nil {
+		entry.Debugf(format, args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Debugf(format, args...)
 }
 
 // Fatalf logs a formatted fatal error message and exits the application.
 func (l *Logger) Fatalf(format string, args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
-	}
 	errMsg := joinString(args...)
 	err := errors.New(errMsg)
-	l.LogrusInstance.WithFields(logFields).Fatalf(format, err)
+	l.LogrusInstance.WithFields(l.fields(callerModule(1))).Fatalf(format, err)
 }
 
 // Warnf logs a formatted warning message.
 func (l *Logger) Warnf(format string, args ...interface{}) {
-	epoch, blockNumber := l.updateBlockInfo()
-	logFields := logrus.Fields{
-		"address":     l.address,
-		"epoch":       epoch,
-		"blockNumber": blockNumber,
-		"version":     core.VersionWithMeta,
+	if entry := l.entry(logrus.WarnLevel); entry != nil {
+		entry.Warnf(format, args...)
 	}
-	l.LogrusInstance.WithFields(logFields).Warnf(format, args...)
 }
 
 // joinString concatenates multiple arguments into a single string.
@@ -224,9 +243,19 @@ func joinString(args ...interface{}) string {
 	return str
 }
 
-// SetLogLevel sets the log level for the logger instance.
+// SetLogLevel sets the log level of the modules whose level isn't configured in the logging section of razor.yaml.
 func (l *Logger) SetLogLevel(level logrus.Level) {
-	l.LogrusInstance.SetLevel(level)
+	levels := *currentLevels.Load()
+	levels.defaultLevel = level
+	levels.maxLevel = levels.getMaxLevel()
+	applyLogLevels(&levels)
+}
+
+//This function switches to the log levels, the logrus loggers are set to the most verbose level so that entries are filtered by module in the Logger and the router
+func applyLogLevels(levels *logLevels) {
+	currentLevels.Store(levels)
+	globalLogger.LogrusInstance.SetLevel(levels.maxLevel)
+	logrus.SetLevel(levels.maxLevel)
 }
 
 // updateBlockInfo fetches block info from the BlockMonitor.
@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNewLogLevels(t *testing.T) {
+	levels, err := newLogLevels(logrus.InfoLevel, map[string]string{"cmd": "warn", "CMD/Dispute": "debug", "rpc/": "error"})
+	if err != nil {
+		t.Fatalf("newLogLevels() error = %v", err)
+	}
+	tests := []struct {
+		module string
+		want   logrus.Level
+	}{
+		{module: "cmd/dispute", want: logrus.DebugLevel},
+		{module: "cmd/vote", want: logrus.WarnLevel},
+		{module: "cmd/disputes", want: logrus.WarnLevel},
+		{module: "cmd", want: logrus.WarnLevel},
+		{module: "cmdx/vote", want: logrus.InfoLevel},
+		{module: "rpc/rpc", want: logrus.ErrorLevel},
+		{module: "block/block", want: logrus.InfoLevel},
+		{module: "", want: logrus.InfoLevel},
+	}
+	for _, tt := range tests {
+		if got := levels.level(tt.module); got != tt.want {
+			t.Errorf("level(%q) = %v, want %v", tt.module, got, tt.want)
+		}
+	}
+	if levels.maxLevel != logrus.DebugLevel {
+		t.Errorf("newLogLevels() max level = %v, want %v", levels.maxLevel, logrus.DebugLevel)
+	}
+
+	if _, err := newLogLevels(logrus.InfoLevel, map[string]string{"cmd": "verbose"}); err == nil {
+		t.Error("newLogLevels() with an invalid level, expected an error")
+	}
+	if _, err := newLogLevels(logrus.InfoLevel, map[string]string{"/": "debug"}); err == nil {
+		t.Error("newLogLevels() with an empty module, expected an error")
+	}
+}
+
+func TestModuleOf(t *testing.T) {
+	tests := []struct {
+		function string
+		file     string
+		want     string
+	}{
+		{function: "razor/cmd.(*UtilsStruct).HandleDispute", file: "/home/razor/cmd/dispute.go", want: "cmd/dispute"},
+		{function: "razor/core/types.(*Configurations).String", file: "/home/razor/core/types/configurations.go", want: "core/types/configurations"},
+		{function: "razor/block.(*BlockMonitor).Start.func1", file: "/home/razor/block/block.go", want: "block/block"},
+		{function: "main.main", file: "/home/razor/main.go", want: "main/main"},
+		{function: "razor/rpc.init", file: "", want: "rpc"},
+		{function: "", file: "/home/razor/main.go", want: ""},
+	}
+	for _, tt := range tests {
+		if got := moduleOf(tt.function, tt.file); got != tt.want {
+			t.Errorf("moduleOf(%q, %q) = %q, want %q", tt.function, tt.file, got, tt.want)
+		}
+	}
+	if got := callerModule(0); got != "logger/logger_test" {
+		t.Errorf("callerModule() = %q, want logger/logger_test", got)
+	}
+}
+
+func TestRouterFire(t *testing.T) {
+	defer currentLevels.Store(currentLevels.Load())
+	levels, err := newLogLevels(logrus.InfoLevel, map[string]string{"cmd/dispute": "debug"})
+	if err != nil {
+		t.Fatalf("newLogLevels() error = %v", err)
+	}
+	currentLevels.Store(levels)
+
+	var console, shipped bytes.Buffer
+	r := &router{
+		console: &sink{name: "console", level: logrus.TraceLevel, format: (&logrus.TextFormatter{DisableColors: true}).Format, output: &writerOutput{writer: &console}},
+		sinks:   []*sink{{name: "test", level: logrus.WarnLevel, format: (&logrus.JSONFormatter{}).Format, output: &writerOutput{writer: &shipped}}},
+	}
+	newEntry := func(level logrus.Level, message string, data logrus.Fields) *logrus.Entry {
+		return &logrus.Entry{Logger: logrus.New(), Level: level, Message: message, Data: data, Time: time.Now()}
+	}
+
+	// Entries of the Logger are already filtered by module and carry their module
+	if err := r.Fire(newEntry(logrus.InfoLevel, "info of logger", logrus.Fields{ModuleField: "cmd/vote"})); err != nil {
+		t.Fatalf("Fire() error = %v", err)
+	}
+	if err := r.Fire(newEntry(logrus.ErrorLevel, "error of logger", logrus.Fields{ModuleField: "cmd/vote"})); err != nil {
+		t.Fatalf("Fire() error = %v", err)
+	}
+	// Entries of the standard logger are filtered by the module of their caller
+	if err := r.Fire(newEntry(logrus.DebugLevel, "debug of standard logger", logrus.Fields{})); err != nil {
+		t.Fatalf("Fire() error = %v", err)
+	}
+	debugEntry := newEntry(logrus.DebugLevel, "debug of dispute", logrus.Fields{})
+	debugEntry.Caller = &runtime.Frame{Function: "razor/cmd.(*UtilsStruct).HandleDispute", File: "/home/razor/cmd/dispute.go"}
+	debugEntry.Logger.SetReportCaller(true)
+	if err := r.Fire(debugEntry); err != nil {
+		t.Fatalf("Fire() error = %v", err)
+	}
+
+	for _, message := range []string{"info of logger", "error of logger", "debug of dispute", "module=cmd/dispute"} {
+		if !strings.Contains(console.String(), message) {
+			t.Errorf("Console output %q doesn't contain %q", console.String(), message)
+		}
+	}
+	if strings.Contains(console.String(), "debug of standard logger") {
+		t.Errorf("Console output %q contains an entry below the level of its module", console.String())
+	}
+	if got := strings.Count(shipped.String(), "\n"); got != 1 || !strings.Contains(shipped.String(), "error of logger") {
+		t.Errorf("Sink output = %q, want only the entry at its level", shipped.String())
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer func() {
+		if err := Configure(Config{}); err != nil {
+			t.Errorf("Configure() of the default config error = %v", err)
+		}
+	}()
+
+	invalidConfigs := []Config{
+		{Format: "xml"},
+		{Levels: map[string]string{"cmd": "loud"}},
+		{Sinks: []SinkConfig{{Type: "kafka", Address: "127.0.0.1:9092"}}},
+		{Sinks: []SinkConfig{{Type: SinkTCP}}},
+		{Sinks: []SinkConfig{{Type: SinkUDP, Address: "127.0.0.1:5170", Level: "loud"}}},
+		{Sinks: []SinkConfig{{Type: SinkSyslog, Network: "http", Address: "127.0.0.1:514"}}},
+	}
+	for _, config := range invalidConfigs {
+		if err := Configure(config); err == nil {
+			t.Errorf("Configure(%+v), expected an error", config)
+		}
+	}
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error in listening on udp: %v", err)
+	}
+	defer listener.Close()
+
+	err = Configure(Config{Format: FormatText, Levels: map[string]string{"logger": "debug"}, Sinks: []SinkConfig{{Type: SinkUDP, Address: listener.LocalAddr().String()}}})
+	if err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	GetLogger().Debug("shipped debug entry")
+
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, 4096)
+	n, _, err := listener.ReadFrom(buffer)
+	if err != nil {
+		t.Fatalf("Error in reading shipped entry: %v", err)
+	}
+	for _, want := range []string{`"msg":"shipped debug entry"`, `"level":"debug"`, `"module":"logger/logger_test"`} {
+		if !strings.Contains(string(buffer[:n]), want) {
+			t.Errorf("Shipped entry %q doesn't contain %s", buffer[:n], want)
+		}
+	}
+}
+
+func TestSyslogFormatter(t *testing.T) {
+	formatter := &syslogFormatter{hostname: "node", tag: DefaultTag, formatter: &logrus.JSONFormatter{}}
+	entry := &logrus.Entry{Level: logrus.ErrorLevel, Message: "commit failed", Data: logrus.Fields{"epoch": 100}, Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	got, err := formatter.Format(entry)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if !strings.HasPrefix(string(got), "<11>1 2024-01-02T03:04:05Z node razor ") {
+		t.Errorf("Format() = %q, want a syslog header with the severity of errors", got)
+	}
+	if !strings.Contains(string(got), `"msg":"commit failed"`) || !strings.Contains(string(got), `"epoch":100`) {
+		t.Errorf("Format() = %q, want the entry in json", got)
+	}
+}
+
+func TestJournaldFormatter(t *testing.T) {
+	formatter := &journaldFormatter{tag: "razor-staker"}
+	entry := &logrus.Entry{Level: logrus.WarnLevel, Message: "first line\nsecond line", Data: logrus.Fields{
+		"blockNumber": 12,
+		"error":       errors.New("rpc timeout"),
+		"_PID":        1,
+		"msg":         "field",
+	}}
+	got, err := formatter.Format(entry)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE\n")
+	_ = binary.Write(&want, binary.LittleEndian, uint64(len("first line\nsecond line")))
+	want.WriteString("first line\nsecond line\n")
+	want.WriteString("PRIORITY=4\nSYSLOG_IDENTIFIER=razor-staker\nPID=1\nBLOCKNUMBER=12\nERROR=rpc timeout\nMSG=field\n")
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("Format() = %q, want %q", got, want.Bytes())
+	}
+}
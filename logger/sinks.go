@@ -0,0 +1,507 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"razor/core"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Following are the formats of the logs written to stderr
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Following are the types of the log sinks which can be configured in razor.yaml
+const (
+	SinkSyslog   = "syslog"
+	SinkTCP      = "tcp"
+	SinkUDP      = "udp"
+	SinkJournald = "journald"
+)
+
+// DefaultTag is the app name of the syslog messages and the syslog identifier of the journal entries unless a tag is configured
+const DefaultTag = "razor"
+
+// defaultJournaldSocket is the socket of the native protocol of systemd-journald
+const defaultJournaldSocket = "/run/systemd/journal/socket"
+
+// localSyslogSockets are the sockets of the local syslog daemon on linux, macOS and BSD
+var localSyslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SinkConfig configures an additional destination of the logs in razor.yaml.
+type SinkConfig struct {
+	// Type is one of syslog, tcp, udp or journald
+	Type string `mapstructure:"type"`
+	// Network is the network of the syslog server, udp, tcp or unixgram. The local syslog daemon is used if it is not set.
+	Network string `mapstructure:"network"`
+	// Address is the address of the syslog server, of the log collector for tcp and udp, or of the journald socket
+	Address string `mapstructure:"address"`
+	// Tag is the app name in syslog and the syslog identifier in the journal
+	Tag string `mapstructure:"tag"`
+	// Level is the most verbose level shipped to the sink, every logged entry is shipped by default
+	Level string `mapstructure:"level"`
+}
+
+// Config is the logging section of razor.yaml.
+type Config struct {
+	// Format is the format of the logs written to stderr, json or text. The log file is always written in json.
+	Format string `mapstructure:"format"`
+	// Levels overrides the log level per module, e.g. cmd/dispute for the file cmd/dispute.go or cmd for the whole cmd package
+	Levels map[string]string `mapstructure:"levels"`
+	Sinks  []SinkConfig      `mapstructure:"sinks"`
+}
+
+// output writes the formatted log entries to the destination of a sink
+type output interface {
+	write(data []byte) error
+	close() error
+}
+
+// sink formats the log entries up to its level and writes them to its output
+type sink struct {
+	name   string
+	level  logrus.Level
+	format func(entry *logrus.Entry) ([]byte, error)
+	output output
+}
+
+// router is the logrus hook through which every log entry reaches the sinks.
+// The logrus loggers themselves write nothing, so that every sink can have its own format.
+type router struct {
+	mu      sync.RWMutex
+	console *sink
+	file    *sink
+	sinks   []*sink
+}
+
+var logRouter = &router{
+	console: newConsoleSink(&logrus.JSONFormatter{CallerPrettyfier: omitCaller}),
+}
+
+func (r *router) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *router) Fire(entry *logrus.Entry) error {
+	if _, ok := entry.Data[ModuleField]; !ok {
+		// Entries of the logrus standard logger, which the packages without the Logger log with, are filtered by the level of their module here
+		var module string
+		if entry.HasCaller() {
+			module = moduleOf(entry.Caller.Function, entry.Caller.File)
+		}
+		if entry.Level > currentLevels.Load().level(module) {
+			return nil
+		}
+		// The block info isn't added, as the block monitor logs with the standard logger while it holds the lock of the latest block
+		entry.Data[ModuleField] = module
+		entry.Data["address"] = globalLogger.address
+		entry.Data["version"] = core.VersionWithMeta
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, sink := range r.all() {
+		if entry.Level > sink.level {
+			continue
+		}
+		data, err := sink.format(entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error in formatting log entry for %s sink: %v\n", sink.name, err)
+			continue
+		}
+		if err := sink.output.write(data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error in writing log entry to %s sink: %v\n", sink.name, err)
+		}
+	}
+	return nil
+}
+
+//This function returns all the sinks of the router, it is called with the lock held
+func (r *router) all() []*sink {
+	sinks := make([]*sink, 0, len(r.sinks)+2)
+	if r.console != nil {
+		sinks = append(sinks, r.console)
+	}
+	if r.file != nil {
+		sinks = append(sinks, r.file)
+	}
+	return append(sinks, r.sinks...)
+}
+
+//This function sets the format of the console and replaces the additional sinks, the replaced sinks are flushed and closed
+func (r *router) configure(console *sink, sinks []*sink) {
+	r.mu.Lock()
+	replacedSinks := r.sinks
+	r.console = console
+	r.sinks = sinks
+	r.mu.Unlock()
+	closeSinks(replacedSinks)
+}
+
+//This function replaces the log file sink, the replaced file is closed
+func (r *router) setFile(file *sink) {
+	r.mu.Lock()
+	replacedFile := r.file
+	r.file = file
+	r.mu.Unlock()
+	if replacedFile != nil {
+		closeSinks([]*sink{replacedFile})
+	}
+}
+
+//This function flushes and closes the log file and the additional sinks
+func (r *router) close() {
+	r.mu.Lock()
+	sinks := r.sinks
+	if r.file != nil {
+		sinks = append(sinks, r.file)
+	}
+	r.sinks = nil
+	r.file = nil
+	r.mu.Unlock()
+	closeSinks(sinks)
+}
+
+//This function closes the outputs of the sinks
+func closeSinks(sinks []*sink) {
+	for _, sink := range sinks {
+		if err := sink.output.close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error in closing %s log sink: %v\n", sink.name, err)
+		}
+	}
+}
+
+// discardFormatter is the formatter of the logrus loggers, whose output is discarded as the router writes the entries to the sinks
+type discardFormatter struct{}
+
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
+
+//This function omits the function and file of the caller from the formatted entries, the module field already tells where an entry was logged from
+func omitCaller(*runtime.Frame) (string, string) {
+	return "", ""
+}
+
+//This function returns the sink which writes the entries to stderr in the given format
+func newConsoleSink(formatter logrus.Formatter) *sink {
+	return &sink{
+		name:   "console",
+		level:  logrus.TraceLevel,
+		format: formatter.Format,
+		output: &writerOutput{writer: os.Stderr},
+	}
+}
+
+//This function returns the formatter of the logs written to stderr
+func newConsoleFormatter(format string) (logrus.Formatter, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return &logrus.JSONFormatter{CallerPrettyfier: omitCaller}, nil
+	case FormatText:
+		return &logrus.TextFormatter{FullTimestamp: true, DisableColors: true, CallerPrettyfier: omitCaller}, nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected %s or %s", format, FormatJSON, FormatText)
+	}
+}
+
+//This function returns the sink which writes the entries to the log file in json
+func newFileSink(writer io.WriteCloser) *sink {
+	return &sink{
+		name:   "file",
+		level:  logrus.TraceLevel,
+		format: (&logrus.JSONFormatter{CallerPrettyfier: omitCaller}).Format,
+		output: &writerOutput{writer: writer, closer: writer},
+	}
+}
+
+//This function creates the sink configured in razor.yaml
+func newSink(config SinkConfig) (*sink, error) {
+	level := logrus.TraceLevel
+	if config.Level != "" {
+		var err error
+		level, err = logrus.ParseLevel(config.Level)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tag := config.Tag
+	if tag == "" {
+		tag = DefaultTag
+	}
+
+	sinkType := strings.ToLower(config.Type)
+	switch sinkType {
+	case SinkTCP, SinkUDP:
+		if config.Address == "" {
+			return nil, fmt.Errorf("address is required for %s sink", sinkType)
+		}
+		return &sink{
+			name:   sinkType + " " + config.Address,
+			level:  level,
+			format: (&logrus.JSONFormatter{CallerPrettyfier: omitCaller}).Format,
+			output: newShipper(sinkType, config.Address),
+		}, nil
+	case SinkSyslog:
+		network, address := strings.ToLower(config.Network), config.Address
+		if network == "" && address == "" {
+			var err error
+			network, address, err = getLocalSyslogSocket()
+			if err != nil {
+				return nil, err
+			}
+		}
+		switch network {
+		case "udp", "tcp", "unix", "unixgram":
+		default:
+			return nil, fmt.Errorf("invalid syslog network %q, expected udp, tcp or unixgram", config.Network)
+		}
+		if address == "" {
+			return nil, errors.New("address is required for syslog sink")
+		}
+		hostname, _ := os.Hostname()
+		formatter := &syslogFormatter{hostname: hostname, tag: tag, formatter: &logrus.JSONFormatter{CallerPrettyfier: omitCaller}}
+		return &sink{
+			name:   "syslog " + address,
+			level:  level,
+			format: formatter.Format,
+			output: newShipper(network, address),
+		}, nil
+	case SinkJournald:
+		address := config.Address
+		if address == "" {
+			address = defaultJournaldSocket
+		}
+		formatter := &journaldFormatter{tag: tag}
+		return &sink{
+			name:   "journald " + address,
+			level:  level,
+			format: formatter.Format,
+			output: newShipper("unixgram", address),
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid sink type %q, expected %s, %s, %s or %s", config.Type, SinkSyslog, SinkTCP, SinkUDP, SinkJournald)
+	}
+}
+
+//This function returns the socket of the local syslog daemon
+func getLocalSyslogSocket() (string, string, error) {
+	for _, socket := range localSyslogSockets {
+		if _, err := os.Stat(socket); err == nil {
+			return "unixgram", socket, nil
+		}
+	}
+	return "", "", errors.New("no local syslog socket found, address of the syslog server is required")
+}
+
+// writerOutput writes the entries to a local writer as they are logged
+type writerOutput struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+func (o *writerOutput) write(data []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := o.writer.Write(data)
+	return err
+}
+
+func (o *writerOutput) close() error {
+	if o.closer == nil {
+		return nil
+	}
+	return o.closer.Close()
+}
+
+// shipper ships the entries to a socket in the background, so that a slow or unreachable destination doesn't block the node.
+// Every entry is written in a single write, which is a single datagram for udp and unixgram.
+type shipper struct {
+	network   string
+	address   string
+	queue     chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   atomic.Int64
+
+	// conn and retryAt are only accessed by the shipping goroutine
+	conn    net.Conn
+	retryAt time.Time
+}
+
+//This function creates a shipper to the address and starts shipping
+func newShipper(network string, address string) *shipper {
+	s := &shipper{
+		network: network,
+		address: address,
+		queue:   make(chan []byte, core.LogSinkQueueSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *shipper) write(data []byte) error {
+	select {
+	case s.queue <- data:
+	default:
+		s.dropped.Add(1)
+	}
+	return nil
+}
+
+//This function stops accepting entries and waits for the queued entries to be shipped for at most LogSinkFlushTimeout seconds
+func (s *shipper) close() error {
+	s.closeOnce.Do(func() {
+		close(s.queue)
+	})
+	select {
+	case <-s.done:
+		return nil
+	case <-time.After(core.LogSinkFlushTimeout * time.Second):
+		return fmt.Errorf("%d entries to %s %s weren't shipped", len(s.queue), s.network, s.address)
+	}
+}
+
+//This function ships the queued entries until the shipper is closed
+func (s *shipper) run() {
+	defer close(s.done)
+	for data := range s.queue {
+		s.ship(data)
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+//This function writes an entry to the connection, connecting first if needed. While the destination is unreachable the entries are dropped.
+func (s *shipper) ship(data []byte) {
+	if s.conn == nil {
+		if time.Now().Before(s.retryAt) {
+			s.dropped.Add(1)
+			return
+		}
+		conn, err := net.DialTimeout(s.network, s.address, core.LogSinkDialTimeout*time.Second)
+		if err != nil {
+			// Errors of the sinks are written to stderr directly, logging them would be shipped to the failing sink again
+			fmt.Fprintf(os.Stderr, "Error in connecting to log sink %s %s, retrying in %d seconds: %v\n", s.network, s.address, core.LogSinkRetryInterval, err)
+			s.retryAt = time.Now().Add(core.LogSinkRetryInterval * time.Second)
+			s.dropped.Add(1)
+			return
+		}
+		s.conn = conn
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			fmt.Fprintf(os.Stderr, "Dropped %d log entries while log sink %s %s was unavailable\n", dropped, s.network, s.address)
+		}
+	}
+	_ = s.conn.SetWriteDeadline(time.Now().Add(core.LogSinkWriteTimeout * time.Second))
+	if _, err := s.conn.Write(data); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in shipping log entry to %s %s: %v\n", s.network, s.address, err)
+		s.conn.Close()
+		s.conn = nil
+		s.dropped.Add(1)
+	}
+}
+
+//This function returns the syslog severity of the level
+func syslogSeverity(level logrus.Level) int {
+	switch level {
+	case logrus.PanicLevel:
+		return 0
+	case logrus.FatalLevel:
+		return 2
+	case logrus.ErrorLevel:
+		return 3
+	case logrus.WarnLevel:
+		return 4
+	case logrus.InfoLevel:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// syslogFacilityUser is the facility of the syslog messages
+const syslogFacilityUser = 1
+
+// syslogFormatter formats the entries as RFC 5424 syslog messages whose message is the entry in json
+type syslogFormatter struct {
+	hostname  string
+	tag       string
+	formatter logrus.Formatter
+}
+
+func (f *syslogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	message, err := f.formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	hostname := f.hostname
+	if hostname == "" {
+		hostname = "-"
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %d - - ", syslogFacilityUser*8+syslogSeverity(entry.Level), entry.Time.Format(time.RFC3339Nano), hostname, f.tag, os.Getpid())
+	return append([]byte(header), message...), nil
+}
+
+// invalidJournalFieldCharacters matches the characters which are not allowed in the field names of the journal
+var invalidJournalFieldCharacters = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// journaldFormatter formats the entries in the native protocol of systemd-journald, every field of the entry becomes a field of the journal entry
+type journaldFormatter struct {
+	tag string
+}
+
+func (f *journaldFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	var buffer bytes.Buffer
+	writeJournalField(&buffer, "MESSAGE", entry.Message)
+	writeJournalField(&buffer, "PRIORITY", fmt.Sprint(syslogSeverity(entry.Level)))
+	writeJournalField(&buffer, "SYSLOG_IDENTIFIER", f.tag)
+
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := invalidJournalFieldCharacters.ReplaceAllString(strings.ToUpper(key), "_")
+		// Field names starting with an underscore are trusted fields set by journald
+		name = strings.TrimLeft(name, "_")
+		if name == "" || name == "MESSAGE" || name == "PRIORITY" || name == "SYSLOG_IDENTIFIER" {
+			continue
+		}
+		value := entry.Data[key]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		writeJournalField(&buffer, name, fmt.Sprint(value))
+	}
+	return buffer.Bytes(), nil
+}
+
+//This function writes a field in the native protocol of journald, values with a newline are written with their length as the protocol requires
+func writeJournalField(buffer *bytes.Buffer, name string, value string) {
+	if !strings.Contains(value, "\n") {
+		buffer.WriteString(name + "=" + value + "\n")
+		return
+	}
+	buffer.WriteString(name + "\n")
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value + "\n")
+}
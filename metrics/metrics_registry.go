@@ -1,11 +1,21 @@
 package metrics
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/razor-network/goInfo"
+	"net/url"
 	"razor/core"
 	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
 )
 
 var (
@@ -23,10 +33,189 @@ var (
 			"go_version":       runtime.Version(),
 		},
 	})
+
+	CurrentEpochMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "razor_epoch",
+		Help: "Current epoch as seen by the vote loop",
+	})
+
+	VoteActionAttemptsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_vote_action_attempts_total",
+		Help: "Number of commit, reveal, propose, dispute and claimBlockReward attempts by outcome",
+	}, []string{"action", "outcome"})
+
+	VoteActionLastEpochMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_vote_action_last_epoch",
+		Help: "Epoch of the last attempt of a voting action by outcome",
+	}, []string{"action", "outcome"})
+
+	StakerLastActionEpochMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_staker_last_action_epoch",
+		Help: "Epoch in which the staker last committed, revealed or proposed as reported by the contracts",
+	}, []string{"action"})
+
+	TransactionGasUsedMetric = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "razor_transaction_gas_used",
+		Help:    "Gas used by mined transactions",
+		Buckets: prometheus.ExponentialBuckets(25000, 2, 10),
+	})
+
+	TransactionConfirmationDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "razor_transaction_confirmation_duration_seconds",
+		Help:    "Time taken by WaitForBlockCompletion for a transaction by outcome",
+		Buckets: []float64{1, 2, 5, 10, 20, 30, 60, 120, 300},
+	}, []string{"outcome"})
+
+	TransactionOutcomesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_transaction_outcomes_total",
+		Help: "Number of transactions tracked by the transaction manager by their final outcome",
+	}, []string{"action", "outcome"})
+
+	TransactionReplacementsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_transaction_replacements_total",
+		Help: "Number of pending transactions replaced by the transaction manager to bump gas price or to cancel them",
+	}, []string{"action", "reason"})
+
+	RPCEndpointLatencyMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_rpc_endpoint_latency_seconds",
+		Help: "Latency of the latest block number call to an RPC endpoint",
+	}, []string{"endpoint"})
+
+	RPCEndpointBlockLagMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_rpc_endpoint_block_lag",
+		Help: "Number of blocks an RPC endpoint is behind the most up to date endpoint",
+	}, []string{"endpoint"})
+
+	RPCEndpointErrorsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_rpc_endpoint_errors_total",
+		Help: "Number of failed calls and metrics calculations for an RPC endpoint",
+	}, []string{"endpoint"})
+
+	RPCEndpointScoreMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_rpc_endpoint_score",
+		Help: "Score of an RPC endpoint from its weight, latency, error rate and block lag",
+	}, []string{"endpoint"})
+
+	RPCEndpointQuarantinedMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "razor_rpc_endpoint_quarantined",
+		Help: "Whether an RPC endpoint is quarantined after consecutive failed calls",
+	}, []string{"endpoint"})
+
+	RPCSwitchesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_rpc_switches_total",
+		Help: "Number of switches from one RPC endpoint to the next best endpoint",
+	}, []string{"from", "to"})
+
+	RPCQuorumDisagreementsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_rpc_quorum_disagreements_total",
+		Help: "Number of quorum reads for which an RPC endpoint returned a different result than the quorum",
+	}, []string{"method", "endpoint"})
+
+	RPCQuorumFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_rpc_quorum_failures_total",
+		Help: "Number of quorum reads for which not enough RPC endpoints agreed on a result",
+	}, []string{"method"})
+
+	JobFetchDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "razor_job_fetch_duration_seconds",
+		Help:    "Time taken to fetch and parse the data of a job",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"job_id", "job_name"})
+
+	JobFetchFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_job_fetch_failures_total",
+		Help: "Number of failures in fetching or parsing the data of a job",
+	}, []string{"job_id", "job_name"})
+
+	CompoundingActionsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_compounding_actions_total",
+		Help: "Number of commission claims and stakes of the auto compounding policy by outcome",
+	}, []string{"action", "outcome"})
+
+	CompoundedAmountMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "razor_compounded_amount_rzr_total",
+		Help: "RZR claimed and staked by the auto compounding policy",
+	}, []string{"action"})
 )
 
 func init() {
 	//create a registry
 	RazorRegistry = prometheus.NewRegistry()
-	RazorRegistry.MustRegister(ClientMetric)
+	RazorRegistry.MustRegister(
+		ClientMetric,
+		CurrentEpochMetric,
+		VoteActionAttemptsMetric,
+		VoteActionLastEpochMetric,
+		StakerLastActionEpochMetric,
+		TransactionGasUsedMetric,
+		TransactionConfirmationDurationMetric,
+		TransactionOutcomesMetric,
+		TransactionReplacementsMetric,
+		RPCEndpointLatencyMetric,
+		RPCEndpointBlockLagMetric,
+		RPCEndpointErrorsMetric,
+		RPCEndpointScoreMetric,
+		RPCEndpointQuarantinedMetric,
+		RPCSwitchesMetric,
+		RPCQuorumDisagreementsMetric,
+		RPCQuorumFailuresMetric,
+		JobFetchDurationMetric,
+		JobFetchFailuresMetric,
+		CompoundingActionsMetric,
+		CompoundedAmountMetric,
+	)
+}
+
+// ObserveVoteAction records an attempt of a voting action along with its outcome in the given epoch.
+func ObserveVoteAction(action string, epoch uint32, err error) {
+	outcome := outcomeFromError(err)
+	VoteActionAttemptsMetric.WithLabelValues(action, outcome).Inc()
+	VoteActionLastEpochMetric.WithLabelValues(action, outcome).Set(float64(epoch))
+}
+
+// ObserveTransactionConfirmation records the time taken for a transaction to be mined or to fail.
+func ObserveTransactionConfirmation(durationInSeconds float64, err error) {
+	TransactionConfirmationDurationMetric.WithLabelValues(outcomeFromError(err)).Observe(durationInSeconds)
+}
+
+// ObserveJobFetch records the fetch latency of a job and counts the failure if any.
+func ObserveJobFetch(jobId uint16, jobName string, durationInSeconds float64, err error) {
+	id := strconv.Itoa(int(jobId))
+	JobFetchDurationMetric.WithLabelValues(id, jobName).Observe(durationInSeconds)
+	if err != nil {
+		JobFetchFailuresMetric.WithLabelValues(id, jobName).Inc()
+	}
+}
+
+// ObserveCompounding records a compounding action along with its outcome and counts the RZR amount if it succeeded.
+func ObserveCompounding(action string, amountInRZR float64, err error) {
+	CompoundingActionsMetric.WithLabelValues(action, outcomeFromError(err)).Inc()
+	if err == nil {
+		CompoundedAmountMetric.WithLabelValues(action).Add(amountInRZR)
+	}
+}
+
+// EndpointLabel returns the label of an RPC endpoint in the metrics, which is its host. Provider URLs often carry an API key in
+// their path, query or user info, so instead of them the label has a short hash of the URL, which keeps endpoints on the same host apart.
+func EndpointLabel(endpointURL string) string {
+	parsedURL, err := url.Parse(endpointURL)
+	if err != nil || parsedURL.Host == "" {
+		return "endpoint-" + urlHash(endpointURL)
+	}
+	if parsedURL.User == nil && strings.Trim(parsedURL.Path, "/") == "" && parsedURL.RawQuery == "" && parsedURL.Fragment == "" {
+		return parsedURL.Host
+	}
+	return parsedURL.Host + "#" + urlHash(endpointURL)
+}
+
+func urlHash(endpointURL string) string {
+	hash := sha256.Sum256([]byte(endpointURL))
+	return hex.EncodeToString(hash[:4])
+}
+
+func outcomeFromError(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
 }
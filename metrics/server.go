@@ -14,9 +14,10 @@ var (
 //Run runs metrics http server
 func Run(port string, certFile string, certKey string) error {
 	portNumber := ":" + port
-	logrus.Infof("Starting http server to serve metrics at port '%s', endpoint '%s'", portNumber, endpoint)
+	logrus.Infof("Starting http server to serve metrics at port '%s', endpoints '%s' and '%s'", portNumber, endpoint, statusEndpoint)
 
 	http.Handle(endpoint, promhttp.Handler())
+	http.Handle(statusEndpoint, NodeStatusStore)
 
 	if certFile != "" && certKey != "" {
 		// start an https server using the mux server
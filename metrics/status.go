@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var statusEndpoint = "/status"
+
+// StateError holds the last error that occurred while handling a particular state.
+type StateError struct {
+	Epoch     uint32    `json:"epoch"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NodeStatus is the JSON representation of a running vote process that is served at the status endpoint.
+// Big integers are rendered as decimal strings in wei so that consumers don't lose precision.
+type NodeStatus struct {
+	Address            string                `json:"address"`
+	StakerId           uint32                `json:"stakerId"`
+	Epoch              uint32                `json:"epoch"`
+	State              int64                 `json:"state"`
+	StateName          string                `json:"stateName"`
+	BlockNumber        string                `json:"blockNumber"`
+	Stake              string                `json:"stake"`
+	SRZRBalance        string                `json:"sRZRBalance"`
+	SFUELBalance       string                `json:"sFUELBalance"`
+	LastCommittedEpoch uint32                `json:"lastCommittedEpoch"`
+	LastRevealedEpoch  uint32                `json:"lastRevealedEpoch"`
+	LastProposedEpoch  uint32                `json:"lastProposedEpoch"`
+	LastVerification   uint32                `json:"lastVerification"`
+	BlockConfirmed     uint32                `json:"blockConfirmed"`
+	BestRPCEndpoint    string                `json:"bestRPCEndpoint"`
+	Endpoints          []EndpointStatus      `json:"endpoints"`
+	LastErrors         map[string]StateError `json:"lastErrors"`
+	UpdatedAt          time.Time             `json:"updatedAt"`
+}
+
+// EndpointStatus is the current ranking and health of an RPC endpoint of the node.
+// Endpoints are identified by their EndpointLabel, so that no API key in their URL is served.
+type EndpointStatus struct {
+	Rank              int       `json:"rank"`
+	Endpoint          string    `json:"endpoint"`
+	Priority          int       `json:"priority"`
+	Weight            float64   `json:"weight"`
+	Score             float64   `json:"score"`
+	BlockNumber       uint64    `json:"blockNumber"`
+	BlockLag          uint64    `json:"blockLag"`
+	AverageLatency    float64   `json:"averageLatency"`
+	ErrorRate         float64   `json:"errorRate"`
+	ConsecutiveErrors int       `json:"consecutiveErrors"`
+	QuarantinedUntil  time.Time `json:"quarantinedUntil"`
+	IsBest            bool      `json:"isBest"`
+	IsAvailable       bool      `json:"isAvailable"`
+	LastError         string    `json:"lastError,omitempty"`
+}
+
+// StatusStore keeps the latest status of the vote process and is safe for concurrent use.
+type StatusStore struct {
+	mu     sync.RWMutex
+	status NodeStatus
+	// endpoints returns the current status of the RPC endpoints when the status is read
+	endpoints func() []EndpointStatus
+}
+
+// NodeStatusStore is the status store which is updated by the vote loop and read by the status endpoint.
+var NodeStatusStore = NewStatusStore()
+
+// NewStatusStore creates a new instance of StatusStore
+func NewStatusStore() *StatusStore {
+	return &StatusStore{
+		status: NodeStatus{
+			LastErrors: make(map[string]StateError),
+		},
+	}
+}
+
+// Update applies the given function to the status under lock and refreshes the updated timestamp.
+func (s *StatusStore) Update(updateFunc func(status *NodeStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updateFunc(&s.status)
+	s.status.UpdatedAt = time.Now()
+}
+
+// SetBalances stores stake and balances of the staker as decimal strings.
+func (s *StatusStore) SetBalances(stake *big.Int, sRZRBalance *big.Int, sFUELBalance *big.Int) {
+	s.Update(func(status *NodeStatus) {
+		status.Stake = bigIntToString(stake)
+		status.SRZRBalance = bigIntToString(sRZRBalance)
+		status.SFUELBalance = bigIntToString(sFUELBalance)
+	})
+}
+
+// SetError records the last error for the given state.
+func (s *StatusStore) SetError(stateName string, epoch uint32, err error) {
+	if err == nil {
+		return
+	}
+	s.Update(func(status *NodeStatus) {
+		status.LastErrors[stateName] = StateError{
+			Epoch:     epoch,
+			Message:   err.Error(),
+			Timestamp: time.Now(),
+		}
+	})
+}
+
+// SetEndpointsSource sets the function returning the current status of the RPC endpoints of the node.
+func (s *StatusStore) SetEndpointsSource(endpoints func() []EndpointStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.endpoints = endpoints
+}
+
+// Get returns a copy of the current status.
+func (s *StatusStore) Get() NodeStatus {
+	s.mu.RLock()
+	status := s.status
+	status.LastErrors = make(map[string]StateError, len(s.status.LastErrors))
+	for stateName, stateError := range s.status.LastErrors {
+		status.LastErrors[stateName] = stateError
+	}
+	endpoints := s.endpoints
+	s.mu.RUnlock()
+
+	if endpoints != nil {
+		status.Endpoints = endpoints()
+	}
+	return status
+}
+
+// ServeHTTP writes the current status as JSON.
+func (s *StatusStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Get()); err != nil {
+		logrus.Errorf("Error in encoding node status: %v", err)
+	}
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusStoreServeHTTP(t *testing.T) {
+	store := NewStatusStore()
+	store.Update(func(status *NodeStatus) {
+		status.StakerId = 5
+		status.Epoch = 100
+		status.StateName = "Reveal"
+	})
+	store.SetBalances(big.NewInt(1000), nil, big.NewInt(1e15))
+	store.SetError("Commit", 99, errors.New("commit error"))
+	store.SetError("Reveal", 100, nil)
+
+	recorder := httptest.NewRecorder()
+	store.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, statusEndpoint, nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var status NodeStatus
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	if status.StakerId != 5 || status.Epoch != 100 || status.StateName != "Reveal" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status.Stake != "1000" || status.SRZRBalance != "0" || status.SFUELBalance != "1000000000000000" {
+		t.Errorf("unexpected balances: stake = %s, sRZR = %s, sFUEL = %s", status.Stake, status.SRZRBalance, status.SFUELBalance)
+	}
+	if len(status.LastErrors) != 1 || status.LastErrors["Commit"].Message != "commit error" || status.LastErrors["Commit"].Epoch != 99 {
+		t.Errorf("unexpected last errors: %+v", status.LastErrors)
+	}
+}
+
+func TestStatusStoreRejectsNonGetRequests(t *testing.T) {
+	store := NewStatusStore()
+	recorder := httptest.NewRecorder()
+	store.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, statusEndpoint, nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status code %d, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+}
+
+func TestStatusStoreGetReturnsCopy(t *testing.T) {
+	store := NewStatusStore()
+	store.SetError("Propose", 1, errors.New("propose error"))
+
+	status := store.Get()
+	status.LastErrors["Dispute"] = StateError{Message: "not stored"}
+
+	if _, ok := store.Get().LastErrors["Dispute"]; ok {
+		t.Error("modifying the returned status should not modify the store")
+	}
+}
+
+func TestStatusStoreEndpoints(t *testing.T) {
+	store := NewStatusStore()
+	if status := store.Get(); status.Endpoints != nil {
+		t.Errorf("expected no endpoints without an endpoints source, got %+v", status.Endpoints)
+	}
+
+	calls := 0
+	store.SetEndpointsSource(func() []EndpointStatus {
+		calls++
+		return []EndpointStatus{{Rank: 1, Endpoint: "rpc.example.com", BlockNumber: uint64(100 + calls), IsBest: true}}
+	})
+	store.Get()
+	status := store.Get()
+	if len(status.Endpoints) != 1 || status.Endpoints[0].Endpoint != "rpc.example.com" || status.Endpoints[0].BlockNumber != 102 {
+		t.Errorf("expected the endpoints to be read when the status is read, got %+v", status.Endpoints)
+	}
+}
@@ -0,0 +1,255 @@
+// Package notifier sends notifications about critical events of the node to webhooks.
+// Every event has a severity which can be overridden per event, and notifications for the same
+// event of a staker are rate limited so that a condition which persists over many blocks isn't reported on every block.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"razor/core"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Following are the events the node sends notifications for
+const (
+	EventLowSFuelBalance   = "lowSFuelBalance"
+	EventStakerSlashed     = "stakerSlashed"
+	EventStakeBelowMinimum = "stakeBelowMinimum"
+	EventCommitFailed      = "commitFailed"
+	EventRevealFailed      = "revealFailed"
+	EventBlockDisputed     = "blockDisputed"
+)
+
+// Severity is how urgently an event needs the attention of the operator.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityCritical: "critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if strings.EqualFold(name, severityName) {
+			return severity, nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("invalid severity %q, expected info, warning or critical", name)
+}
+
+// defaultSeverities holds the severity of every event unless it is overridden in the config
+var defaultSeverities = map[string]Severity{
+	EventLowSFuelBalance:   SeverityWarning,
+	EventStakerSlashed:     SeverityCritical,
+	EventStakeBelowMinimum: SeverityCritical,
+	EventCommitFailed:      SeverityWarning,
+	EventRevealFailed:      SeverityCritical,
+	EventBlockDisputed:     SeverityCritical,
+}
+
+// Event is a single occurrence of an event of a staker.
+type Event struct {
+	Name     string
+	Severity Severity
+	Message  string
+	Address  string
+	Epoch    uint32
+	Time     time.Time
+}
+
+// Sink delivers notifications to a destination.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// SinkConfig configures a destination of the notifications in razor.yaml.
+type SinkConfig struct {
+	// Type is one of webhook, slack or telegram
+	Type string `mapstructure:"type"`
+	// URL is the webhook to POST the notifications to. For telegram it defaults to the sendMessage API of the bot.
+	URL         string `mapstructure:"url"`
+	BotToken    string `mapstructure:"botToken"`
+	ChatId      string `mapstructure:"chatId"`
+	MinSeverity string `mapstructure:"minSeverity"`
+}
+
+// EventConfig overrides the defaults of an event in razor.yaml.
+type EventConfig struct {
+	// Severity overrides the default severity of the event, "off" disables the notifications of the event
+	Severity string `mapstructure:"severity"`
+	// RateLimit is the minimum number of seconds between two notifications of the event for a staker
+	RateLimit int64 `mapstructure:"rateLimit"`
+}
+
+// Config is the notifications section of razor.yaml.
+type Config struct {
+	Sinks     []SinkConfig           `mapstructure:"sinks"`
+	RateLimit int64                  `mapstructure:"rateLimit"`
+	Events    map[string]EventConfig `mapstructure:"events"`
+}
+
+type sinkEntry struct {
+	sink        Sink
+	name        string
+	minSeverity Severity
+}
+
+type eventSettings struct {
+	severity  Severity
+	disabled  bool
+	rateLimit time.Duration
+}
+
+// Notifier sends the events to all the sinks whose minimum severity they reach.
+// All the methods can be called on a nil Notifier, in which case nothing is sent.
+type Notifier struct {
+	mu               sync.Mutex
+	wg               sync.WaitGroup
+	sinks            []sinkEntry
+	events           map[string]eventSettings
+	defaultRateLimit time.Duration
+	lastSent         map[string]time.Time
+	now              func() time.Time
+}
+
+// New creates a notifier from the config, which sends the notifications with httpClient.
+func New(config Config, httpClient *http.Client) (*Notifier, error) {
+	notifier := &Notifier{
+		events:           make(map[string]eventSettings),
+		defaultRateLimit: core.NotificationRateLimit * time.Second,
+		lastSent:         make(map[string]time.Time),
+		now:              time.Now,
+	}
+	if config.RateLimit > 0 {
+		notifier.defaultRateLimit = time.Duration(config.RateLimit) * time.Second
+	}
+
+	for i, sinkConfig := range config.Sinks {
+		sink, err := newSink(sinkConfig, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification sink %d: %w", i+1, err)
+		}
+		minSeverity := SeverityInfo
+		if sinkConfig.MinSeverity != "" {
+			minSeverity, err = ParseSeverity(sinkConfig.MinSeverity)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notification sink %d: %w", i+1, err)
+			}
+		}
+		notifier.sinks = append(notifier.sinks, sinkEntry{sink: sink, name: strings.ToLower(sinkConfig.Type), minSeverity: minSeverity})
+	}
+
+	for name, eventConfig := range config.Events {
+		// Keys of maps in razor.yaml are lower cased when the config is read, so events are matched case insensitively
+		eventName, ok := canonicalEventName(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid notification event %q", name)
+		}
+		settings := eventSettings{severity: defaultSeverities[eventName]}
+		switch {
+		case strings.EqualFold(eventConfig.Severity, "off"):
+			settings.disabled = true
+		case eventConfig.Severity != "":
+			severity, err := ParseSeverity(eventConfig.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notification event %s: %w", eventName, err)
+			}
+			settings.severity = severity
+		}
+		if eventConfig.RateLimit > 0 {
+			settings.rateLimit = time.Duration(eventConfig.RateLimit) * time.Second
+		}
+		notifier.events[eventName] = settings
+	}
+	return notifier, nil
+}
+
+func canonicalEventName(name string) (string, bool) {
+	for eventName := range defaultSeverities {
+		if strings.EqualFold(name, eventName) {
+			return eventName, true
+		}
+	}
+	return "", false
+}
+
+// Notify sends the event to the sinks in the background, unless the event is disabled or a notification
+// for the same event of the staker was sent within the rate limit of the event.
+func (n *Notifier) Notify(name string, address string, epoch uint32, message string) {
+	if n == nil || len(n.sinks) == 0 {
+		return
+	}
+
+	n.mu.Lock()
+	settings, ok := n.events[name]
+	if !ok {
+		settings = eventSettings{severity: defaultSeverities[name]}
+	}
+	if settings.disabled {
+		n.mu.Unlock()
+		return
+	}
+	rateLimit := settings.rateLimit
+	if rateLimit == 0 {
+		rateLimit = n.defaultRateLimit
+	}
+	now := n.now()
+	key := name + "/" + strings.ToLower(address)
+	if lastSent, sent := n.lastSent[key]; sent && now.Sub(lastSent) < rateLimit {
+		n.mu.Unlock()
+		logrus.Debugf("Not sending %s notification as one was sent at %s", name, lastSent.Format(time.RFC3339))
+		return
+	}
+	n.lastSent[key] = now
+	n.mu.Unlock()
+
+	event := Event{
+		Name:     name,
+		Severity: settings.severity,
+		Message:  message,
+		Address:  address,
+		Epoch:    epoch,
+		Time:     now.UTC(),
+	}
+	for _, entry := range n.sinks {
+		if event.Severity < entry.minSeverity {
+			continue
+		}
+		n.wg.Add(1)
+		go func(entry sinkEntry) {
+			defer n.wg.Done()
+			if err := entry.sink.Send(context.Background(), event); err != nil {
+				logrus.Errorf("Error in sending %s notification to %s sink: %v", event.Name, entry.name, err)
+			}
+		}(entry)
+	}
+}
+
+// Wait blocks until all the notifications which are being sent are delivered or have failed.
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.wg.Wait()
+}
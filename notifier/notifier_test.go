@@ -0,0 +1,191 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingServer collects the bodies POSTed to it
+type recordingServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newRecordingServer(t *testing.T, status int) *recordingServer {
+	server := &recordingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Expected JSON body, got error %v", err)
+		}
+		server.mu.Lock()
+		server.bodies = append(server.bodies, body)
+		server.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *recordingServer) received() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}{}, s.bodies...)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When all the sink types and event overrides are valid",
+			config: Config{
+				Sinks: []SinkConfig{
+					{Type: "webhook", URL: "http://localhost/hook"},
+					{Type: "Slack", URL: "http://localhost/slack", MinSeverity: "critical"},
+					{Type: "telegram", BotToken: "token", ChatId: "42"},
+				},
+				Events: map[string]EventConfig{
+					"lowsfuelbalance": {Severity: "off"},
+					"commitFailed":    {Severity: "critical", RateLimit: 60},
+				},
+			},
+		},
+		{
+			name:    "Test 2: When the sink type is invalid",
+			config:  Config{Sinks: []SinkConfig{{Type: "email", URL: "http://localhost/hook"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test 3: When the url of a webhook sink is missing",
+			config:  Config{Sinks: []SinkConfig{{Type: "webhook"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test 4: When the chat id of a telegram sink is missing",
+			config:  Config{Sinks: []SinkConfig{{Type: "telegram", BotToken: "token"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test 5: When the minimum severity of a sink is invalid",
+			config:  Config{Sinks: []SinkConfig{{Type: "slack", URL: "http://localhost/slack", MinSeverity: "urgent"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test 6: When an unknown event is configured",
+			config:  Config{Events: map[string]EventConfig{"unknownEvent": {Severity: "info"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test 7: When the severity of an event is invalid",
+			config:  Config{Events: map[string]EventConfig{"stakerSlashed": {Severity: "high"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	webhook := newRecordingServer(t, http.StatusOK)
+	slack := newRecordingServer(t, http.StatusOK)
+	telegram := newRecordingServer(t, http.StatusOK)
+
+	notifier, err := New(Config{
+		Sinks: []SinkConfig{
+			{Type: "webhook", URL: webhook.URL},
+			{Type: "slack", URL: slack.URL, MinSeverity: "critical"},
+			{Type: "telegram", URL: telegram.URL, ChatId: "42"},
+		},
+		RateLimit: 300,
+		Events: map[string]EventConfig{
+			"commitfailed":    {Severity: "critical", RateLimit: 30},
+			"lowsfuelbalance": {Severity: "off"},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	notifier.now = func() time.Time { return now }
+
+	notifier.Notify(EventStakerSlashed, "0xabc", 10, "Staker is slashed")
+	notifier.Notify(EventLowSFuelBalance, "0xabc", 10, "sFUEL balance is low")
+	notifier.Notify(EventRevealFailed, "0xabc", 10, "Reveal failed")
+	notifier.Wait()
+
+	webhookBodies := webhook.received()
+	if len(webhookBodies) != 2 {
+		t.Fatalf("Expected 2 notifications on the webhook, got %d", len(webhookBodies))
+	}
+	for _, body := range webhookBodies {
+		if body["address"] != "0xabc" || body["epoch"] != float64(10) || body["severity"] != "critical" {
+			t.Errorf("Unexpected webhook body %v", body)
+		}
+	}
+	if len(slack.received()) != 2 {
+		t.Errorf("Expected the critical notifications on slack, got %v", slack.received())
+	}
+	telegramBodies := telegram.received()
+	if len(telegramBodies) != 2 || telegramBodies[0]["chat_id"] != "42" {
+		t.Errorf("Expected 2 notifications on telegram for chat 42, got %v", telegramBodies)
+	}
+	for _, body := range slack.received() {
+		text, _ := body["text"].(string)
+		if !strings.HasPrefix(text, "[CRITICAL] razor-go ") || !strings.Contains(text, "(staker 0xabc, epoch 10)") {
+			t.Errorf("Unexpected slack text %q", text)
+		}
+	}
+
+	// The same event of the same staker is rate limited, the same event of another staker is not
+	now = now.Add(time.Minute)
+	notifier.Notify(EventStakerSlashed, "0xabc", 11, "Staker is slashed")
+	notifier.Notify(EventStakerSlashed, "0xdef", 11, "Staker is slashed")
+	notifier.Wait()
+	if got := len(webhook.received()); got != 3 {
+		t.Errorf("Expected rate limited notification to be dropped, got %d notifications", got)
+	}
+
+	now = now.Add(5 * time.Minute)
+	notifier.Notify(EventStakerSlashed, "0xabc", 12, "Staker is slashed")
+	notifier.Wait()
+	if got := len(webhook.received()); got != 4 {
+		t.Errorf("Expected notification after the rate limit to be sent, got %d notifications", got)
+	}
+
+	// The rate limit and severity of an event can be overridden
+	notifier.Notify(EventCommitFailed, "0xabc", 12, "Commit failed")
+	now = now.Add(time.Minute)
+	notifier.Notify(EventCommitFailed, "0xabc", 13, "Commit failed")
+	notifier.Wait()
+	if got := len(slack.received()); got != 6 {
+		t.Errorf("Expected overridden critical commit failures outside their rate limit on slack, got %d notifications", got)
+	}
+}
+
+func TestNotifyWithFailingSink(t *testing.T) {
+	webhook := newRecordingServer(t, http.StatusInternalServerError)
+	sink := NewHTTPSink(webhook.URL, nil, webhookPayloadOf)
+	if err := sink.Send(context.Background(), Event{Name: EventStakerSlashed, Severity: SeverityCritical}); err == nil {
+		t.Error("Expected an error when the webhook doesn't respond with a 2xx status")
+	}
+
+	var nilNotifier *Notifier
+	nilNotifier.Notify(EventStakerSlashed, "0xabc", 1, "Staker is slashed")
+	nilNotifier.Wait()
+}
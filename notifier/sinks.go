@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Following are the types of sinks which can be configured
+const (
+	SinkTypeWebhook  = "webhook"
+	SinkTypeSlack    = "slack"
+	SinkTypeTelegram = "telegram"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// webhookPayload is the body POSTed to a generic webhook
+type webhookPayload struct {
+	Event    string    `json:"event"`
+	Severity string    `json:"severity"`
+	Message  string    `json:"message"`
+	Address  string    `json:"address,omitempty"`
+	Epoch    uint32    `json:"epoch,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// slackPayload is the body of a Slack incoming webhook
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// telegramPayload is the body of the sendMessage API of a Telegram bot
+type telegramPayload struct {
+	ChatId string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// HTTPSink POSTs every event as JSON to a URL. The body depends on the type of the sink.
+type HTTPSink struct {
+	url        string
+	httpClient *http.Client
+	payload    func(event Event) interface{}
+}
+
+func newSink(config SinkConfig, httpClient *http.Client) (*HTTPSink, error) {
+	switch strings.ToLower(config.Type) {
+	case SinkTypeWebhook:
+		if config.URL == "" {
+			return nil, errors.New("url is required for a webhook sink")
+		}
+		return NewHTTPSink(config.URL, httpClient, webhookPayloadOf), nil
+	case SinkTypeSlack:
+		if config.URL == "" {
+			return nil, errors.New("url is required for a slack sink")
+		}
+		return NewHTTPSink(config.URL, httpClient, func(event Event) interface{} {
+			return slackPayload{Text: formatText(event)}
+		}), nil
+	case SinkTypeTelegram:
+		if config.ChatId == "" {
+			return nil, errors.New("chatId is required for a telegram sink")
+		}
+		url := config.URL
+		if url == "" {
+			if config.BotToken == "" {
+				return nil, errors.New("botToken or url is required for a telegram sink")
+			}
+			url = fmt.Sprintf("%s/bot%s/sendMessage", telegramAPIURL, config.BotToken)
+		}
+		return NewHTTPSink(url, httpClient, func(event Event) interface{} {
+			return telegramPayload{ChatId: config.ChatId, Text: formatText(event)}
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid type %q, expected webhook, slack or telegram", config.Type)
+	}
+}
+
+// NewHTTPSink creates a sink which POSTs the JSON encoding of payload(event) to url for every event.
+func NewHTTPSink(url string, httpClient *http.Client, payload func(event Event) interface{}) *HTTPSink {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPSink{
+		url:        url,
+		httpClient: httpClient,
+		payload:    payload,
+	}
+}
+
+// Send POSTs the event and fails if the webhook doesn't respond with a 2xx status.
+func (s *HTTPSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(s.payload(event))
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("webhook responded with status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
+func webhookPayloadOf(event Event) interface{} {
+	return webhookPayload{
+		Event:    event.Name,
+		Severity: event.Severity.String(),
+		Message:  event.Message,
+		Address:  event.Address,
+		Epoch:    event.Epoch,
+		Time:     event.Time,
+	}
+}
+
+// formatText formats the event as a single line message for chat sinks
+func formatText(event Event) string {
+	text := fmt.Sprintf("[%s] razor-go %s: %s", strings.ToUpper(event.Severity.String()), event.Name, event.Message)
+	if event.Address != "" {
+		text += fmt.Sprintf(" (staker %s", event.Address)
+		if event.Epoch != 0 {
+			text += fmt.Sprintf(", epoch %d", event.Epoch)
+		}
+		text += ")"
+	} else if event.Epoch != 0 {
+		text += fmt.Sprintf(" (epoch %d)", event.Epoch)
+	}
+	return text
+}
@@ -114,6 +114,48 @@ func (_m *PathInterface) GetDotENVFilePath() (string, error) {
 	return r0, r1
 }
 
+// GetEpochJournalFileName provides a mock function with given fields: address
+func (_m *PathInterface) GetEpochJournalFileName(address string) (string, error) {
+	ret := _m.Called(address)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecordingFileName provides a mock function with given fields: epoch
+func (_m *PathInterface) GetRecordingFileName(epoch uint32) (string, error) {
+	ret := _m.Called(epoch)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(uint32) string); ok {
+		r0 = rf(epoch)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(epoch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetJobFilePath provides a mock function with given fields:
 func (_m *PathInterface) GetJobFilePath() (string, error) {
 	ret := _m.Called()
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"razor/core"
+	"strconv"
 )
 
 //This function returns the default path
@@ -113,3 +114,38 @@ func (PathUtils) GetDisputeDataFileName(address string) (string, error) {
 	}
 	return filepath.Join(dataFileDir, address+core.DisputeDataFile), nil
 }
+
+//This function returns the file name of epoch journal file
+func (PathUtils) GetEpochJournalFileName(address string) (string, error) {
+	razorDir, err := PathUtilsInterface.GetDefaultPath()
+	if err != nil {
+		return "", err
+	}
+	dataFileDir := filepath.Join(razorDir, core.DataFileDirectory)
+	if _, err := OSUtilsInterface.Stat(dataFileDir); OSUtilsInterface.IsNotExist(err) {
+		mkdirErr := OSUtilsInterface.Mkdir(dataFileDir, 0700)
+		if mkdirErr != nil {
+			return "", mkdirErr
+		}
+	}
+	return filepath.Join(dataFileDir, address+core.EpochJournalFile), nil
+}
+
+//This function returns the file path of the recording of the job responses of an epoch
+func (PathUtils) GetRecordingFileName(epoch uint32) (string, error) {
+	razorDir, err := PathUtilsInterface.GetDefaultPath()
+	if err != nil {
+		return "", err
+	}
+	dataFileDir := filepath.Join(razorDir, core.DataFileDirectory)
+	recordingsDir := filepath.Join(dataFileDir, core.RecordingsDirectory)
+	for _, dir := range []string{dataFileDir, recordingsDir} {
+		if _, err := OSUtilsInterface.Stat(dir); OSUtilsInterface.IsNotExist(err) {
+			mkdirErr := OSUtilsInterface.Mkdir(dir, 0700)
+			if mkdirErr != nil {
+				return "", mkdirErr
+			}
+		}
+	}
+	return filepath.Join(recordingsDir, strconv.Itoa(int(epoch))+core.RecordingFile), nil
+}
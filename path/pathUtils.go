@@ -20,6 +20,8 @@ type PathInterface interface {
 	GetCommitDataFileName(address string) (string, error)
 	GetProposeDataFileName(address string) (string, error)
 	GetDisputeDataFileName(address string) (string, error)
+	GetEpochJournalFileName(address string) (string, error)
+	GetRecordingFileName(epoch uint32) (string, error)
 }
 
 type OSInterface interface {
@@ -568,3 +568,183 @@ func TestGetDisputeDataFileName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEpochJournalFileName(t *testing.T) {
+	var fileInfo fs.FileInfo
+	type args struct {
+		address    string
+		path       string
+		pathErr    error
+		statErr    error
+		isNotExist bool
+		mkdirErr   error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Test 1: When GetEpochJournalFileName executes successfully",
+			args: args{
+				address: "0x000000000000000000000000000000000000dead",
+				path:    "/home",
+			},
+			want:    "/home/data_files/0x000000000000000000000000000000000000dead_epochJournal.jsonl",
+			wantErr: nil,
+		},
+		{
+			name: "Test 2: When there is an error in getting path",
+			args: args{
+				address: "0x000000000000000000000000000000000000dead",
+				pathErr: errors.New("path error"),
+			},
+			want:    "",
+			wantErr: errors.New("path error"),
+		},
+		{
+			name: "Test 3: When data_files directory is not present and mkdir creates it",
+			args: args{
+				address:    "0x000000000000000000000000000000000000dead",
+				path:       "/home",
+				statErr:    errors.New("not exists"),
+				isNotExist: true,
+			},
+			want:    "/home/data_files/0x000000000000000000000000000000000000dead_epochJournal.jsonl",
+			wantErr: nil,
+		},
+		{
+			name: "Test 4: When data_files directory is not present and there is an error in creating new one",
+			args: args{
+				address:    "0x000000000000000000000000000000000000dead",
+				path:       "/home",
+				statErr:    errors.New("not exists"),
+				isNotExist: true,
+				mkdirErr:   errors.New("mkdir error"),
+			},
+			want:    "",
+			wantErr: errors.New("mkdir error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			pathMock := new(mocks.PathInterface)
+			osMock := new(mocks.OSInterface)
+
+			OSUtilsInterface = osMock
+			PathUtilsInterface = pathMock
+
+			pathMock.On("GetDefaultPath").Return(tt.args.path, tt.args.pathErr)
+			osMock.On("Stat", mock.AnythingOfType("string")).Return(fileInfo, tt.args.statErr)
+			osMock.On("IsNotExist", mock.Anything).Return(tt.args.isNotExist)
+			osMock.On("Mkdir", mock.Anything, mock.Anything).Return(tt.args.mkdirErr)
+
+			pa := &PathUtils{}
+			got, err := pa.GetEpochJournalFileName(tt.args.address)
+			if got != tt.want {
+				t.Errorf("GetEpochJournalFileName got = %v, want %v", got, tt.want)
+			}
+			if err == nil || tt.wantErr == nil {
+				if err != tt.wantErr {
+					t.Errorf("Error for GetEpochJournalFileName, got = %v, want = %v", err, tt.wantErr)
+				}
+			} else {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("Error for GetEpochJournalFileName, got = %v, want = %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRecordingFileName(t *testing.T) {
+	var fileInfo fs.FileInfo
+	type args struct {
+		epoch      uint32
+		path       string
+		pathErr    error
+		statErr    error
+		isNotExist bool
+		mkdirErr   error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Test 1: When GetRecordingFileName executes successfully",
+			args: args{
+				epoch: 1000,
+				path:  "/home",
+			},
+			want:    "/home/data_files/recordings/1000.jsonl",
+			wantErr: nil,
+		},
+		{
+			name: "Test 2: When there is an error in getting path",
+			args: args{
+				epoch:   1000,
+				pathErr: errors.New("path error"),
+			},
+			want:    "",
+			wantErr: errors.New("path error"),
+		},
+		{
+			name: "Test 3: When recordings directory is not present and mkdir creates it",
+			args: args{
+				epoch:      1000,
+				path:       "/home",
+				statErr:    errors.New("not exists"),
+				isNotExist: true,
+			},
+			want:    "/home/data_files/recordings/1000.jsonl",
+			wantErr: nil,
+		},
+		{
+			name: "Test 4: When recordings directory is not present and there is an error in creating new one",
+			args: args{
+				epoch:      1000,
+				path:       "/home",
+				statErr:    errors.New("not exists"),
+				isNotExist: true,
+				mkdirErr:   errors.New("mkdir error"),
+			},
+			want:    "",
+			wantErr: errors.New("mkdir error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			pathMock := new(mocks.PathInterface)
+			osMock := new(mocks.OSInterface)
+
+			OSUtilsInterface = osMock
+			PathUtilsInterface = pathMock
+
+			pathMock.On("GetDefaultPath").Return(tt.args.path, tt.args.pathErr)
+			osMock.On("Stat", mock.AnythingOfType("string")).Return(fileInfo, tt.args.statErr)
+			osMock.On("IsNotExist", mock.Anything).Return(tt.args.isNotExist)
+			osMock.On("Mkdir", mock.Anything, mock.Anything).Return(tt.args.mkdirErr)
+
+			pa := &PathUtils{}
+			got, err := pa.GetRecordingFileName(tt.args.epoch)
+			if got != tt.want {
+				t.Errorf("GetRecordingFileName got = %v, want %v", got, tt.want)
+			}
+			if err == nil || tt.wantErr == nil {
+				if err != tt.wantErr {
+					t.Errorf("Error for GetRecordingFileName, got = %v, want = %v", err, tt.wantErr)
+				}
+			} else {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("Error for GetRecordingFileName, got = %v, want = %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
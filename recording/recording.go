@@ -0,0 +1,289 @@
+// Package recording stores the raw responses of the job data sources used in every epoch,
+// so that the values committed in an epoch can be replayed and explained later
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactedValue replaces the value of a header which holds a secret
+const RedactedValue = "<redacted>"
+
+// secretHeaderKeywords are the keywords of header names whose values are secrets
+var secretHeaderKeywords = []string{"auth", "key", "token", "secret", "cookie", "password"}
+
+// Response is the raw response of the data source of a job in an epoch along with the value parsed from it.
+// URL and Header are recorded before API keys from the env file are substituted, so that no secret is recorded.
+// Attempt is the number of the fetch of the data source in the epoch, starting at 1.
+type Response struct {
+	Epoch        uint32            `json:"epoch"`
+	Attempt      int               `json:"attempt,omitempty"`
+	JobId        uint16            `json:"jobId"`
+	JobName      string            `json:"jobName"`
+	URL          string            `json:"url"`
+	Type         string            `json:"type"`
+	Selector     string            `json:"selector"`
+	SelectorType uint8             `json:"selectorType"`
+	Header       map[string]string `json:"header,omitempty"`
+	Body         string            `json:"body"`
+	BodyHash     string            `json:"bodyHash"`
+	Timestamp    time.Time         `json:"timestamp"`
+	Value        string            `json:"value,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// Key identifies the data source of a job
+func Key(jobId uint16, url string, selector string) string {
+	return fmt.Sprintf("%d|%s|%s", jobId, url, selector)
+}
+
+// Key returns the key of the data source of the response
+func (r Response) Key() string {
+	return Key(r.JobId, r.URL, r.Selector)
+}
+
+// HashBody returns the hex encoded SHA-256 hash of a response body
+func HashBody(body []byte) string {
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// RedactHeader returns a copy of the header in which the values of headers holding secrets are redacted.
+// Values which are placeholders of the env file, e.g. ${API_KEY}, are not secrets and are kept.
+func RedactHeader(header map[string]string, apiKeyPlaceholder func(string) bool) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(header))
+	for name, value := range header {
+		redacted[name] = value
+		if apiKeyPlaceholder != nil && apiKeyPlaceholder(value) {
+			continue
+		}
+		lowerName := strings.ToLower(name)
+		for _, keyword := range secretHeaderKeywords {
+			if strings.Contains(lowerName, keyword) {
+				redacted[name] = RedactedValue
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// Recorder appends the responses of the current epoch to the recording file of that epoch.
+// A new file is opened whenever the epoch changes.
+type Recorder struct {
+	mu        sync.Mutex
+	fileName  func(epoch uint32) (string, error)
+	epoch     uint32
+	file      *os.File
+	fileEpoch uint32
+	// lastAttempts holds the last response recorded in the epoch for every data source
+	lastAttempts map[string]Response
+}
+
+// NewRecorder returns a recorder which writes the responses of an epoch to the file returned by fileName
+func NewRecorder(fileName func(epoch uint32) (string, error)) *Recorder {
+	return &Recorder{fileName: fileName}
+}
+
+// SetEpoch sets the epoch of the responses recorded next
+func (r *Recorder) SetEpoch(epoch uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch = epoch
+}
+
+// Record appends the response to the recording of the current epoch as the next attempt of its data source.
+// A response which is the same as the last attempt of its data source, e.g. the same data source used by several stakers, is not recorded again.
+func (r *Recorder) Record(response Response) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.epoch == 0 {
+		return errors.New("epoch of recording is not set")
+	}
+	response.Epoch = r.epoch
+	if err := r.openEpochFile(); err != nil {
+		return err
+	}
+	key := response.Key()
+	if response.BodyHash == "" {
+		response.BodyHash = HashBody([]byte(response.Body))
+	}
+	lastAttempt, isRecorded := r.lastAttempts[key]
+	if isRecorded && lastAttempt.BodyHash == response.BodyHash && lastAttempt.Value == response.Value && lastAttempt.Error == response.Error {
+		return nil
+	}
+	response.Attempt = lastAttempt.Attempt + 1
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if _, err := r.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	r.lastAttempts[key] = response
+	return nil
+}
+
+// openEpochFile opens the recording file of the current epoch if it isn't open, the caller must hold the lock.
+// The attempts of the responses already present in the file, e.g. recorded before a restart, are continued.
+func (r *Recorder) openEpochFile() error {
+	if r.file != nil && r.fileEpoch == r.epoch {
+		return nil
+	}
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+	filePath, err := r.fileName(r.epoch)
+	if err != nil {
+		return err
+	}
+	existing, err := Load(filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	r.file = file
+	r.fileEpoch = r.epoch
+	r.lastAttempts = make(map[string]Response, len(existing))
+	for _, response := range existing {
+		// Responses recorded without an attempt are numbered in the order they were recorded
+		if response.Attempt == 0 {
+			response.Attempt = r.lastAttempts[response.Key()].Attempt + 1
+		}
+		r.lastAttempts[response.Key()] = response
+	}
+	return nil
+}
+
+// Close closes the recording file of the current epoch
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Load reads all the responses of a recording file. A partially written response at the end of the file is ignored.
+func Load(filePath string) ([]Response, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var responses []Response
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		// A line without a trailing newline is a response which was not completely written
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			line = bytes.TrimSpace(line)
+			if len(line) > 0 {
+				var response Response
+				if unmarshalErr := json.Unmarshal(line, &response); unmarshalErr != nil {
+					logrus.Warnf("Skipping corrupted response in recording %s: %v", filePath, unmarshalErr)
+				} else {
+					responses = append(responses, response)
+				}
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read recording: %w", err)
+		}
+	}
+	return responses, nil
+}
+
+// Replay serves the recorded responses of an epoch in place of the data sources and collects the values parsed from them
+type Replay struct {
+	mu        sync.Mutex
+	responses map[string]Response
+	values    []ReplayedValue
+}
+
+// ReplayedValue is the value of a job recomputed from its recorded response
+type ReplayedValue struct {
+	Response Response
+	Value    string
+	Error    string
+}
+
+// NewReplay returns a replay of the latest successful response recorded for every data source, or of its latest response if every attempt failed.
+// The responses are expected in the order they were recorded.
+func NewReplay(responses []Response) *Replay {
+	replay := &Replay{responses: make(map[string]Response, len(responses))}
+	for _, response := range responses {
+		replayed, ok := replay.responses[response.Key()]
+		if !ok || response.Error == "" || replayed.Error != "" {
+			replay.responses[response.Key()] = response
+		}
+	}
+	return replay
+}
+
+// RecordedBefore returns the responses recorded before the given time, e.g. the responses the values committed at that time were computed from
+func RecordedBefore(responses []Response, before time.Time) []Response {
+	var recorded []Response
+	for _, response := range responses {
+		if !response.Timestamp.After(before) {
+			recorded = append(recorded, response)
+		}
+	}
+	return recorded
+}
+
+// Response returns the recorded response of the data source of a job
+func (r *Replay) Response(jobId uint16, url string, selector string) (Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	response, ok := r.responses[Key(jobId, url, selector)]
+	return response, ok
+}
+
+// Observe collects the value recomputed from a recorded response
+func (r *Replay) Observe(response Response, value string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	replayed := ReplayedValue{Response: response, Value: value}
+	if err != nil {
+		replayed.Error = err.Error()
+	}
+	r.values = append(r.values, replayed)
+}
+
+// TakeValues returns the values collected since the last call and clears them
+func (r *Replay) TakeValues() []ReplayedValue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := r.values
+	r.values = nil
+	return values
+}
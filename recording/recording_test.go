@@ -0,0 +1,201 @@
+package recording
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testRecorder(dir string) *Recorder {
+	return NewRecorder(func(epoch uint32) (string, error) {
+		return filepath.Join(dir, strconv.Itoa(int(epoch))+".jsonl"), nil
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	recorder := testRecorder(dir)
+
+	response := Response{JobId: 1, JobName: "ethusd", URL: "https://api.example.com/ethusd", Selector: "last", Body: `{"last":"2000"}`, Value: "200000"}
+	if err := recorder.Record(response); err == nil {
+		t.Fatal("Record() without an epoch, expected an error")
+	}
+
+	recorder.SetEpoch(10)
+	for i := 0; i < 2; i++ {
+		// The same response of the same data source in the epoch is not recorded again
+		if err := recorder.Record(response); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	retriedResponse := response
+	retriedResponse.Body = `{"last":"2001"}`
+	retriedResponse.Value = "200100"
+	if err := recorder.Record(retriedResponse); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	recorder.SetEpoch(11)
+	if err := recorder.Record(response); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// A restarted recorder continues the attempts recorded in the epoch before the restart
+	restarted := testRecorder(dir)
+	restarted.SetEpoch(11)
+	if err := restarted.Record(response); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := restarted.Record(retriedResponse); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	otherResponse := response
+	otherResponse.JobId = 2
+	if err := restarted.Record(otherResponse); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	restarted.Close()
+
+	type recordedAttempt struct {
+		JobId   uint16
+		Attempt int
+		Body    string
+	}
+	wantAttempts := map[uint32][]recordedAttempt{
+		10: {{1, 1, response.Body}, {1, 2, retriedResponse.Body}},
+		11: {{1, 1, response.Body}, {1, 2, retriedResponse.Body}, {2, 1, response.Body}},
+	}
+	for epoch, want := range wantAttempts {
+		responses, err := Load(filepath.Join(dir, strconv.Itoa(int(epoch))+".jsonl"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		var got []recordedAttempt
+		for _, recorded := range responses {
+			got = append(got, recordedAttempt{recorded.JobId, recorded.Attempt, recorded.Body})
+			if recorded.Epoch != epoch {
+				t.Errorf("Recorded epoch = %d, want %d", recorded.Epoch, epoch)
+			}
+			if recorded.BodyHash != HashBody([]byte(recorded.Body)) {
+				t.Errorf("Recorded body hash = %s, want hash of %s", recorded.BodyHash, recorded.Body)
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Recorded attempts of epoch %d = %+v, want %+v", epoch, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "1.jsonl")
+	data := `{"epoch":1,"jobId":1,"url":"https://api.example.com","body":"{}"}` + "\n" +
+		"not json\n" +
+		`{"epoch":1,"jobId":2,"url":"https://api.example.com","body":"{}"}` + "\n" +
+		`{"epoch":1,"jobId":3,"url":"https://api.exa`
+	if err := os.WriteFile(filePath, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	responses, err := Load(filePath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(responses) != 2 || responses[0].JobId != 1 || responses[1].JobId != 2 {
+		t.Errorf("Load() = %+v, want the responses of jobs 1 and 2", responses)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "2.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("Load() of missing recording error = %v, want not exist error", err)
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	isPlaceholder := func(value string) bool {
+		return value == "${API_KEY}"
+	}
+	header := map[string]string{
+		"content-type":  "application/json",
+		"Authorization": "Bearer secret",
+		"x-api-key":     "${API_KEY}",
+		"X-Auth-Token":  "secret",
+	}
+	want := map[string]string{
+		"content-type":  "application/json",
+		"Authorization": RedactedValue,
+		"x-api-key":     "${API_KEY}",
+		"X-Auth-Token":  RedactedValue,
+	}
+	if got := RedactHeader(header, isPlaceholder); !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactHeader() = %v, want %v", got, want)
+	}
+	if got := RedactHeader(nil, isPlaceholder); got != nil {
+		t.Errorf("RedactHeader() of empty header = %v, want nil", got)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	response := Response{JobId: 1, URL: "https://api.example.com/ethusd", Selector: "last", Body: `{"last":"2000"}`}
+	replay := NewReplay([]Response{response})
+
+	if got, ok := replay.Response(1, response.URL, "last"); !ok || got.Body != response.Body {
+		t.Errorf("Response() = %+v, %v, want the recorded response", got, ok)
+	}
+	if _, ok := replay.Response(2, response.URL, "last"); ok {
+		t.Error("Response() of a job which wasn't recorded, expected no response")
+	}
+
+	replay.Observe(response, "200000", nil)
+	if values := replay.TakeValues(); len(values) != 1 || values[0].Value != "200000" {
+		t.Errorf("TakeValues() = %+v, want the observed value", values)
+	}
+	if values := replay.TakeValues(); len(values) != 0 {
+		t.Errorf("TakeValues() after taking the values = %+v, want none", values)
+	}
+}
+
+func TestReplayLatestAttempt(t *testing.T) {
+	committedAt := time.Now()
+	first := Response{JobId: 1, URL: "https://api.example.com/ethusd", Selector: "last", Attempt: 1, Body: `{"last":"2000"}`, Timestamp: committedAt.Add(-time.Minute)}
+	second := first
+	second.Attempt, second.Body, second.Timestamp = 2, `{"last":"2001"}`, committedAt.Add(-time.Second)
+	failed := first
+	failed.Attempt, failed.Body, failed.Error, failed.Timestamp = 3, "", "timeout", committedAt.Add(-time.Millisecond)
+	afterCommit := first
+	afterCommit.Attempt, afterCommit.Body, afterCommit.Timestamp = 4, `{"last":"2002"}`, committedAt.Add(time.Minute)
+	responses := []Response{first, second, failed, afterCommit}
+
+	tests := []struct {
+		name      string
+		responses []Response
+		want      Response
+	}{
+		{
+			name:      "Test 1: When the latest attempt was successful",
+			responses: responses,
+			want:      afterCommit,
+		},
+		{
+			name:      "Test 2: When only the attempts recorded before the commit are replayed",
+			responses: RecordedBefore(responses, committedAt),
+			want:      second,
+		},
+		{
+			name:      "Test 3: When every attempt failed",
+			responses: []Response{failed},
+			want:      failed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewReplay(tt.responses).Response(1, first.URL, "last")
+			if !ok || got.Attempt != tt.want.Attempt {
+				t.Errorf("Response() = attempt %d, %v, want attempt %d", got.Attempt, ok, tt.want.Attempt)
+			}
+		})
+	}
+}
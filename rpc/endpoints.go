@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// EndpointConfig is an endpoint listed in endpoints.json, either as its URL or as an object with its URL, weight and priority
+type EndpointConfig struct {
+	URL string `json:"url"`
+	// Weight scales the score of the endpoint, it is 1 if it is not set
+	Weight float64 `json:"weight,omitempty"`
+	// Priority ranks the endpoints before their score, an endpoint is only used when no available endpoint has a lower priority
+	Priority int `json:"priority,omitempty"`
+}
+
+func (c *EndpointConfig) UnmarshalJSON(data []byte) error {
+	var endpointURL string
+	if err := json.Unmarshal(data, &endpointURL); err == nil {
+		*c = EndpointConfig{URL: endpointURL}
+		return nil
+	}
+	type endpointConfig EndpointConfig
+	var config endpointConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return err
+	}
+	*c = EndpointConfig(config)
+	return nil
+}
+
+// EndpointsConfig is the content of endpoints.json. The file is either a list of endpoints, as written by importEndpoints,
+// or an object with the endpoints, the scoring of the endpoints and the quorum reads.
+type EndpointsConfig struct {
+	Endpoints []EndpointConfig `json:"endpoints"`
+	Scoring   ScoringConfig    `json:"scoring"`
+	Quorum    QuorumConfig     `json:"quorum"`
+}
+
+func (c *EndpointsConfig) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		*c = EndpointsConfig{}
+		return json.Unmarshal(data, &c.Endpoints)
+	}
+	type endpointsConfig EndpointsConfig
+	var config endpointsConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return err
+	}
+	*c = EndpointsConfig(config)
+	return nil
+}
+
+// URLs returns the URLs of the endpoints
+func (c EndpointsConfig) URLs() []string {
+	urls := make([]string, len(c.Endpoints))
+	for i, endpoint := range c.Endpoints {
+		urls[i] = endpoint.URL
+	}
+	return urls
+}
+
+// Validate checks that there is at least one endpoint, that every endpoint is a http(s) or ws(s) URL without duplicates
+// with a weight which isn't negative, and that the scoring and quorum are valid
+func (c EndpointsConfig) Validate() error {
+	if len(c.Endpoints) == 0 {
+		return errors.New("no endpoints present")
+	}
+	seen := make(map[string]bool)
+	for _, endpoint := range c.Endpoints {
+		parsedURL, err := url.Parse(endpoint.URL)
+		if err != nil || parsedURL.Host == "" {
+			return fmt.Errorf("endpoint %q is not a valid URL", endpoint.URL)
+		}
+		switch strings.ToLower(parsedURL.Scheme) {
+		case "http", "https", "ws", "wss":
+		default:
+			return fmt.Errorf("endpoint %q should have scheme http, https, ws or wss", endpoint.URL)
+		}
+		if seen[endpoint.URL] {
+			return fmt.Errorf("endpoint %q is listed more than once", endpoint.URL)
+		}
+		seen[endpoint.URL] = true
+		if endpoint.Weight < 0 {
+			return fmt.Errorf("endpoint %q has negative weight %v", endpoint.URL, endpoint.Weight)
+		}
+	}
+	if err := c.Scoring.Validate(); err != nil {
+		return err
+	}
+	if err := c.Quorum.Validate(); err != nil {
+		return err
+	}
+	if c.Quorum.Size > len(c.Endpoints) {
+		return fmt.Errorf("size %d of the quorum is more than the %d endpoints", c.Quorum.Size, len(c.Endpoints))
+	}
+	return nil
+}
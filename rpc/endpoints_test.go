@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEndpointsConfigUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    EndpointsConfig
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When endpoints.json is a list of URLs",
+			data: `["https://rpc.example.com", "wss://ws.example.com"]`,
+			want: EndpointsConfig{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com"}, {URL: "wss://ws.example.com"}}},
+		},
+		{
+			name: "Test 2: When endpoints.json has weights, priorities and scoring",
+			data: `{"endpoints": [{"url": "https://rpc.example.com", "weight": 2, "priority": 1}, "https://backup.example.com"], "scoring": {"errorPenalty": 20, "quarantineErrors": 5}, "quorum": {"size": 2}}`,
+			want: EndpointsConfig{
+				Endpoints: []EndpointConfig{{URL: "https://rpc.example.com", Weight: 2, Priority: 1}, {URL: "https://backup.example.com"}},
+				Scoring:   ScoringConfig{ErrorPenalty: 20, QuarantineErrors: 5},
+				Quorum:    QuorumConfig{Size: 2},
+			},
+		},
+		{
+			name:    "Test 3: When an endpoint has an unknown field",
+			data:    `{"endpoints": [{"url": "https://rpc.example.com", "wieght": 2}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test 4: When the scoring has an unknown field",
+			data:    `{"endpoints": ["https://rpc.example.com"], "scoring": {"penalty": 2}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got EndpointsConfig
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointsConfigValidate(t *testing.T) {
+	valid := EndpointsConfig{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com", Weight: 2}, {URL: "wss://ws.example.com/v1"}, {URL: "http://127.0.0.1:8545"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if got := valid.URLs(); !reflect.DeepEqual(got, []string{"https://rpc.example.com", "wss://ws.example.com/v1", "http://127.0.0.1:8545"}) {
+		t.Errorf("URLs() = %v", got)
+	}
+
+	invalidConfigs := []EndpointsConfig{
+		{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com"}, {URL: ""}}},
+		{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com", Weight: -1}}},
+		{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com"}}, Scoring: ScoringConfig{Smoothing: 1.5}},
+		{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com"}}, Scoring: ScoringConfig{QuarantineSeconds: -1}},
+		{Endpoints: []EndpointConfig{{URL: "https://rpc.example.com"}, {URL: "https://backup.example.com"}}, Quorum: QuorumConfig{Size: 3}},
+	}
+	for _, config := range invalidConfigs {
+		if err := config.Validate(); err == nil {
+			t.Errorf("Validate() of %+v, expected an error", config)
+		}
+	}
+}
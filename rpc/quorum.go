@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrQuorumDisagreement is the error recorded for an endpoint whose result of a quorum read differs from the result the quorum agreed on
+var ErrQuorumDisagreement = errors.New("result disagrees with the quorum")
+
+// ErrNoQuorum is the error of a quorum read for which not enough endpoints agreed on a result
+var ErrNoQuorum = errors.New("no quorum")
+
+// QuorumConfig configures the quorum reads, it is the quorum section of endpoints.json. Quorum reads are disabled if size is at most 1.
+type QuorumConfig struct {
+	// Size is the number of endpoints a quorum read is made to, the best endpoints first
+	Size int `json:"size,omitempty"`
+	// Threshold is the number of endpoints which have to return the same result, it is a majority of size if it is not set
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// IsEnabled returns whether the selected reads are made to multiple endpoints
+func (c QuorumConfig) IsEnabled() bool {
+	return c.Size > 1
+}
+
+// threshold returns the number of endpoints which have to agree, a majority of the size if it is not set
+func (c QuorumConfig) threshold() int {
+	if c.Threshold == 0 {
+		return c.Size/2 + 1
+	}
+	return c.Threshold
+}
+
+// Validate checks that the threshold is a majority of the size, so that two results can't both reach the threshold
+func (c QuorumConfig) Validate() error {
+	if c.Size < 0 || c.Threshold < 0 {
+		return errors.New("size and threshold of the quorum cannot be negative")
+	}
+	if !c.IsEnabled() {
+		if c.Threshold > 0 {
+			return errors.New("threshold of the quorum is set without a size of at least 2")
+		}
+		return nil
+	}
+	if c.threshold() <= c.Size/2 || c.threshold() > c.Size {
+		return fmt.Errorf("threshold %d of the quorum should be more than half of its size %d and at most its size", c.threshold(), c.Size)
+	}
+	return nil
+}
+
+// IsQuorumEnabled returns whether the selected reads are made to multiple endpoints
+func (m *RPCManager) IsQuorumEnabled() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.Quorum.IsEnabled()
+}
+
+// GetQuorumClients returns the clients of the endpoints a quorum read is made to, the best endpoint first and then by ranking,
+// and the number of them which have to agree. It returns no clients if quorum reads are disabled.
+func (m *RPCManager) GetQuorumClients() ([]*ethclient.Client, int, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if !m.Quorum.IsEnabled() {
+		return nil, 0, nil
+	}
+	now := time.Now()
+	var clients []*ethclient.Client
+	if m.BestEndpoint != nil && m.BestEndpoint.isAvailable(now) {
+		clients = append(clients, m.BestEndpoint.Client)
+	}
+	for _, endpoint := range m.Endpoints {
+		if len(clients) == m.Quorum.Size {
+			break
+		}
+		if endpoint != m.BestEndpoint && endpoint.isAvailable(now) {
+			clients = append(clients, endpoint.Client)
+		}
+	}
+	threshold := m.Quorum.threshold()
+	if len(clients) < threshold {
+		return nil, threshold, fmt.Errorf("%w: %d endpoints are available for a quorum of %d", ErrNoQuorum, len(clients), threshold)
+	}
+	return clients, threshold, nil
+}
+
+// GetEndpointURL returns the URL of the endpoint of the client
+func (m *RPCManager) GetEndpointURL(client *ethclient.Client) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if endpoint := m.endpointOf(client); endpoint != nil {
+		return endpoint.URL
+	}
+	return ""
+}
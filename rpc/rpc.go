@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"razor/core"
+	"razor/metrics"
 	"razor/path"
 	"sort"
 	"strings"
@@ -37,50 +38,67 @@ func (m *RPCManager) calculateMetrics(endpoint *RPCEndpoint) error {
 	}
 	latency := time.Since(start).Seconds()
 
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	endpoint.BlockNumber = blockNumber
 	endpoint.Latency = latency
 	endpoint.Client = client
+	endpoint.recordSuccess(m.Scoring.withDefaults(), latency)
+	metrics.RPCEndpointLatencyMetric.WithLabelValues(metrics.EndpointLabel(endpoint.URL)).Set(latency)
 
 	return nil
 }
 
-// updateAndSortEndpoints calculates metrics and sorts the endpoints
-func (m *RPCManager) updateAndSortEndpoints() error {
-	if len(m.Endpoints) == 0 {
-		return fmt.Errorf("no endpoints available to update")
-	}
-
+// measureEndpoints calculates the metrics of the endpoints concurrently, a failure is recorded in the health of the endpoint
+func (m *RPCManager) measureEndpoints(endpoints []*RPCEndpoint) {
 	var wg sync.WaitGroup
-	logrus.Debug("Starting concurrent metrics calculation for all endpoints...")
-
-	for _, endpoint := range m.Endpoints {
+	for _, endpoint := range endpoints {
 		wg.Add(1)
 		go func(ep *RPCEndpoint) {
 			defer wg.Done()
 			if err := m.calculateMetrics(ep); err != nil {
 				logrus.Errorf("Error calculating metrics for endpoint %s: %v", ep.URL, err)
+				m.mutex.Lock()
+				ep.recordFailure(m.Scoring.withDefaults(), err, time.Now())
+				m.mutex.Unlock()
 			}
 		}(endpoint)
 	}
 	wg.Wait()
+}
+
+// updateAndSortEndpoints calculates metrics of the endpoints which aren't quarantined and ranks the endpoints
+func (m *RPCManager) updateAndSortEndpoints() error {
+	if len(m.Endpoints) == 0 {
+		return fmt.Errorf("no endpoints available to update")
+	}
+
+	now := time.Now()
+	m.mutex.RLock()
+	var endpoints []*RPCEndpoint
+	for _, endpoint := range m.Endpoints {
+		if endpoint.isQuarantined(now) {
+			logrus.Debugf("Skipping metrics calculation for endpoint %s quarantined until %s", endpoint.URL, endpoint.Health.QuarantinedUntil.Format(time.RFC3339))
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	m.mutex.RUnlock()
 
+	logrus.Debug("Starting concurrent metrics calculation for all endpoints...")
+	m.measureEndpoints(endpoints)
 	log.Debug("Concurrent metrics calculation complete. Sorting endpoints...")
 
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	sort.Slice(m.Endpoints, func(i, j int) bool {
-		if m.Endpoints[i].BlockNumber == m.Endpoints[j].BlockNumber {
-			return m.Endpoints[i].Latency < m.Endpoints[j].Latency
-		}
-		return m.Endpoints[i].BlockNumber > m.Endpoints[j].BlockNumber
-	})
+	m.rankEndpoints(time.Now())
 
-	// Update the best RPC endpoint after sorting
+	// Update the best RPC endpoint after ranking
 	m.BestEndpoint = m.Endpoints[0]
 
-	logrus.Infof("Best RPC endpoint updated: %s (BlockNumber: %d, Latency: %.2f)",
-		m.BestEndpoint.URL, m.BestEndpoint.BlockNumber, m.BestEndpoint.Latency)
+	logrus.Infof("Best RPC endpoint updated: %s (BlockNumber: %d, Latency: %.2f, Score: %.3f)",
+		m.BestEndpoint.URL, m.BestEndpoint.BlockNumber, m.BestEndpoint.Latency, m.BestEndpoint.Health.Score)
 
 	return nil
 }
@@ -95,29 +113,60 @@ func (m *RPCManager) RefreshEndpoints() error {
 }
 
 func InitializeRPCManager(provider string) (*RPCManager, error) {
+	endpointsConfig, err := ReadEndpointsFile(provider)
+	if err != nil {
+		return nil, err
+	}
+	// Validating once the provider is appended, as on a reload, so that a quorum larger than the endpoints fails the startup
+	if err := endpointsConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid endpoints.json: %w", err)
+	}
+
+	// Initialize the RPC endpoints
+	rpcEndpoints := make([]*RPCEndpoint, len(endpointsConfig.Endpoints))
+	for i, endpoint := range endpointsConfig.Endpoints {
+		rpcEndpoints[i] = &RPCEndpoint{URL: endpoint.URL, Weight: endpoint.Weight, Priority: endpoint.Priority}
+	}
+
+	rpcManager := &RPCManager{
+		Endpoints: rpcEndpoints,
+		Scoring:   endpointsConfig.Scoring,
+		Quorum:    endpointsConfig.Quorum,
+	}
+
+	// Pre-calculate metrics and set the best client on initialization
+	if err := rpcManager.updateAndSortEndpoints(); err != nil {
+		return nil, fmt.Errorf("failed to initialize RPC Manager: %w", err)
+	}
+
+	return rpcManager, nil
+}
+
+// ReadEndpointsFile returns the endpoints and scoring in endpoints.json, with the provider appended if it isn't listed
+func ReadEndpointsFile(provider string) (EndpointsConfig, error) {
 	defaultPath, err := path.PathUtilsInterface.GetDefaultPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get .razor path: %w", err)
+		return EndpointsConfig{}, fmt.Errorf("failed to get .razor path: %w", err)
 	}
 
-	endpointsFile := filepath.Join(defaultPath, "endpoints.json")
+	endpointsFile := filepath.Join(defaultPath, core.EndpointsFile)
 	fileData, err := os.ReadFile(endpointsFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read endpoints.json: %w", err)
+		return EndpointsConfig{}, fmt.Errorf("failed to read endpoints.json: %w", err)
 	}
 
-	// Unmarshal the JSON file into a list of RPC endpoints
-	var rpcEndpointsList []string
-	err = json.Unmarshal(fileData, &rpcEndpointsList)
+	// Unmarshal the JSON file, either a list of RPC endpoints or an object with the endpoints and their scoring
+	var endpointsConfig EndpointsConfig
+	err = json.Unmarshal(fileData, &endpointsConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal endpoints.json: %w", err)
+		return EndpointsConfig{}, fmt.Errorf("failed to unmarshal endpoints.json: %w", err)
 	}
 
 	// Normalize provider input and check if it's already in the list
 	provider = strings.TrimSpace(provider) // Trim whitespace from the input
 	providerFound := false
-	for _, endpoint := range rpcEndpointsList {
-		if endpoint == provider {
+	for _, endpoint := range endpointsConfig.Endpoints {
+		if endpoint.URL == provider {
 			providerFound = true
 			break
 		}
@@ -126,25 +175,93 @@ func InitializeRPCManager(provider string) (*RPCManager, error) {
 	// If the provider is not found, add it to the list
 	if !providerFound && provider != "" {
 		logrus.Infof("Adding user-provided endpoint: %s", provider)
-		rpcEndpointsList = append(rpcEndpointsList, provider)
+		endpointsConfig.Endpoints = append(endpointsConfig.Endpoints, EndpointConfig{URL: provider})
 	}
+	return endpointsConfig, nil
+}
 
-	// Initialize the RPC endpoints
-	rpcEndpoints := make([]*RPCEndpoint, len(rpcEndpointsList))
-	for i, url := range rpcEndpointsList {
-		rpcEndpoints[i] = &RPCEndpoint{URL: url}
+// EndpointsUpdate holds the URLs of the endpoints which were added, removed or got a new weight or priority by UpdateEndpoints
+type EndpointsUpdate struct {
+	Added            []string
+	Removed          []string
+	Reconfigured     []string
+	IsScoringChanged bool
+	IsQuorumChanged  bool
+}
+
+// IsChanged returns whether any endpoint, the scoring or the quorum changed
+func (u EndpointsUpdate) IsChanged() bool {
+	return len(u.Added) > 0 || len(u.Removed) > 0 || len(u.Reconfigured) > 0 || u.IsScoringChanged || u.IsQuorumChanged
+}
+
+// UpdateEndpoints replaces the endpoints, scoring and quorum with the given config and ranks the endpoints again.
+// Endpoints which are kept keep their client and health, only the added endpoints are measured.
+func (m *RPCManager) UpdateEndpoints(config EndpointsConfig) (EndpointsUpdate, error) {
+	if err := config.Validate(); err != nil {
+		return EndpointsUpdate{}, err
 	}
 
-	rpcManager := &RPCManager{
-		Endpoints: rpcEndpoints,
+	m.mutex.RLock()
+	currentEndpoints := make(map[string]*RPCEndpoint, len(m.Endpoints))
+	for _, endpoint := range m.Endpoints {
+		currentEndpoints[endpoint.URL] = endpoint
+	}
+	update := EndpointsUpdate{IsScoringChanged: config.Scoring != m.Scoring, IsQuorumChanged: config.Quorum != m.Quorum}
+	m.mutex.RUnlock()
+
+	var addedEndpoints []*RPCEndpoint
+	endpoints := make([]*RPCEndpoint, len(config.Endpoints))
+	for i, endpointConfig := range config.Endpoints {
+		endpoint, ok := currentEndpoints[endpointConfig.URL]
+		if !ok {
+			endpoint = &RPCEndpoint{URL: endpointConfig.URL, Weight: endpointConfig.Weight, Priority: endpointConfig.Priority}
+			addedEndpoints = append(addedEndpoints, endpoint)
+			update.Added = append(update.Added, endpointConfig.URL)
+		} else if endpoint.Weight != endpointConfig.Weight || endpoint.Priority != endpointConfig.Priority {
+			update.Reconfigured = append(update.Reconfigured, endpointConfig.URL)
+		}
+		delete(currentEndpoints, endpointConfig.URL)
+		endpoints[i] = endpoint
+	}
+	for endpointURL := range currentEndpoints {
+		update.Removed = append(update.Removed, endpointURL)
+	}
+	sort.Strings(update.Removed)
+	if !update.IsChanged() {
+		return update, nil
 	}
 
-	// Pre-calculate metrics and set the best client on initialization
-	if err := rpcManager.updateAndSortEndpoints(); err != nil {
-		return nil, fmt.Errorf("failed to initialize RPC Manager: %w", err)
+	// The added endpoints are measured before the swap, so that the current best endpoint serves the calls until then
+	m.measureEndpoints(addedEndpoints)
+
+	m.mutex.Lock()
+	now := time.Now()
+	isAnyAvailable := false
+	for _, endpoint := range endpoints {
+		isAnyAvailable = isAnyAvailable || endpoint.isAvailable(now)
+	}
+	if !isAnyAvailable {
+		m.mutex.Unlock()
+		return EndpointsUpdate{}, errors.New("none of the endpoints is reachable")
 	}
+	for i, endpointConfig := range config.Endpoints {
+		endpoints[i].Weight = endpointConfig.Weight
+		endpoints[i].Priority = endpointConfig.Priority
+	}
+	m.Endpoints = endpoints
+	m.Scoring = config.Scoring
+	m.Quorum = config.Quorum
+	m.rankEndpoints(now)
+	m.BestEndpoint = m.Endpoints[0]
+	m.mutex.Unlock()
 
-	return rpcManager, nil
+	for _, endpointURL := range update.Removed {
+		metrics.RPCEndpointLatencyMetric.DeleteLabelValues(metrics.EndpointLabel(endpointURL))
+		metrics.RPCEndpointBlockLagMetric.DeleteLabelValues(metrics.EndpointLabel(endpointURL))
+		metrics.RPCEndpointScoreMetric.DeleteLabelValues(metrics.EndpointLabel(endpointURL))
+		metrics.RPCEndpointQuarantinedMetric.DeleteLabelValues(metrics.EndpointLabel(endpointURL))
+	}
+	return update, nil
 }
 
 func (m *RPCManager) GetBestRPCClient() (*ethclient.Client, error) {
@@ -168,6 +285,26 @@ func (m *RPCManager) GetBestEndpointURL() (string, error) {
 	return m.BestEndpoint.URL, nil
 }
 
+// GetWebSocketEndpointURLs returns the URLs of the ws and wss endpoints, the best endpoints first
+func (m *RPCManager) GetWebSocketEndpointURLs() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var urls []string
+	for _, endpoint := range m.Endpoints {
+		if IsWebSocketURL(endpoint.URL) {
+			urls = append(urls, endpoint.URL)
+		}
+	}
+	return urls
+}
+
+// IsWebSocketURL reports whether the endpoint URL uses the ws or wss scheme, over which new heads can be subscribed to
+func IsWebSocketURL(url string) bool {
+	url = strings.ToLower(url)
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
 // SwitchToNextBestRPCClient switches to the next best available client after the current best client.
 // If no valid next best client is found, it retains the current best client.
 func (m *RPCManager) SwitchToNextBestRPCClient() (bool, error) {
@@ -195,9 +332,15 @@ func (m *RPCManager) SwitchToNextBestRPCClient() (bool, error) {
 	}
 
 	// Iterate through the remaining endpoints to find a valid next best client
+	now := time.Now()
+	scoring := m.Scoring.withDefaults()
 	for i := 1; i < len(m.Endpoints); i++ {
 		nextIndex := (currentIndex + i) % len(m.Endpoints)
 		nextEndpoint := m.Endpoints[nextIndex]
+		if nextEndpoint.isQuarantined(now) {
+			logrus.Debugf("Skipping RPC endpoint %s quarantined until %s", nextEndpoint.URL, nextEndpoint.Health.QuarantinedUntil.Format(time.RFC3339))
+			continue
+		}
 
 		// Check if we can connect to the next endpoint
 		ctx, cancel := context.WithTimeout(context.Background(), core.EndpointsContextTimeout*time.Second)
@@ -206,6 +349,7 @@ func (m *RPCManager) SwitchToNextBestRPCClient() (bool, error) {
 		if err != nil {
 			cancel()
 			logrus.Errorf("Failed to connect to RPC endpoint %s: %v", nextEndpoint.URL, err)
+			nextEndpoint.recordFailure(scoring, err, now)
 			continue
 		}
 
@@ -214,6 +358,7 @@ func (m *RPCManager) SwitchToNextBestRPCClient() (bool, error) {
 		if err != nil {
 			cancel()
 			logrus.Errorf("Failed to fetch block number for endpoint %s: %v", nextEndpoint.URL, err)
+			nextEndpoint.recordFailure(scoring, err, now)
 			continue
 		}
 
@@ -223,6 +368,7 @@ func (m *RPCManager) SwitchToNextBestRPCClient() (bool, error) {
 		// Successfully connected and validated, update the best client and endpoint
 		// (Make sure that the client is correctly associated with the endpoint.)
 		nextEndpoint.Client = client
+		metrics.RPCSwitchesMetric.WithLabelValues(metrics.EndpointLabel(m.BestEndpoint.URL), metrics.EndpointLabel(nextEndpoint.URL)).Inc()
 		m.BestEndpoint = nextEndpoint
 
 		logrus.Infof("Switched to the next best RPC endpoint: %s (BlockNumber: %d, Latency: %.2f)",
@@ -11,12 +11,17 @@ type RPCEndpoint struct {
 	BlockNumber uint64
 	Latency     float64
 	Client      *ethclient.Client
+	Weight      float64 // Scales the score of the endpoint, 0 is treated as 1
+	Priority    int     // Endpoints with a lower priority are preferred regardless of their score
+	Health      EndpointHealth
 }
 
 type RPCManager struct {
 	Endpoints    []*RPCEndpoint
 	mutex        sync.RWMutex
-	BestEndpoint *RPCEndpoint // Holds the URL to current best RPC client
+	BestEndpoint *RPCEndpoint  // Holds the URL to current best RPC client
+	Scoring      ScoringConfig // Scoring of the endpoints from endpoints.json, unset fields take their default
+	Quorum       QuorumConfig  // Quorum reads from endpoints.json, disabled if not set
 }
 
 type RPCParameters struct {
@@ -2,12 +2,21 @@ package rpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"razor/core"
+	"razor/path"
+	"razor/path/mocks"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // decodeRPCRequest decodes the JSON–RPC request body into a map.
@@ -318,3 +327,205 @@ func TestSwitchToNextBestClient(t *testing.T) {
 		}
 	})
 }
+
+func TestGetWebSocketEndpointURLs(t *testing.T) {
+	manager := &RPCManager{Endpoints: []*RPCEndpoint{
+		{URL: "https://rpc.example.com"},
+		{URL: "wss://ws.example.com"},
+		{URL: "http://localhost:8545"},
+		{URL: "WS://localhost:8546"},
+	}}
+
+	urls := manager.GetWebSocketEndpointURLs()
+	expected := []string{"wss://ws.example.com", "WS://localhost:8546"}
+	if len(urls) != len(expected) {
+		t.Fatalf("Expected %d websocket endpoints, got %d: %v", len(expected), len(urls), urls)
+	}
+	for i, url := range expected {
+		if urls[i] != url {
+			t.Errorf("Expected websocket endpoint %d to be %s, got %s", i, url, urls[i])
+		}
+	}
+}
+
+// endpointsConfig returns the config of endpoints.json listing the URLs
+func endpointsConfig(urls ...string) EndpointsConfig {
+	config := EndpointsConfig{}
+	for _, url := range urls {
+		config.Endpoints = append(config.Endpoints, EndpointConfig{URL: url})
+	}
+	return config
+}
+
+// TestUpdateEndpoints verifies that reloading the endpoint list keeps the clients of the kept endpoints,
+// switches to the best of the new list and rejects invalid or unreachable lists without changing the endpoints.
+func TestUpdateEndpoints(t *testing.T) {
+	ts1 := httptest.NewServer(makeHandler(100))
+	t.Cleanup(func() { ts1.Close() })
+	ts2 := httptest.NewServer(makeHandler(105))
+	t.Cleanup(func() { ts2.Close() })
+	ts3 := httptest.NewServer(http.HandlerFunc(errorRPCHandler))
+	t.Cleanup(func() { ts3.Close() })
+
+	manager := createManagerFromServers(ts1)
+	if err := manager.RefreshEndpoints(); err != nil {
+		t.Fatalf("RefreshEndpoints failed: %v", err)
+	}
+	keptEndpoint := manager.Endpoints[0]
+
+	update, err := manager.UpdateEndpoints(endpointsConfig(ts1.URL, ts2.URL))
+	if err != nil {
+		t.Fatalf("UpdateEndpoints failed: %v", err)
+	}
+	if len(update.Added) != 1 || update.Added[0] != ts2.URL || len(update.Removed) != 0 {
+		t.Errorf("Expected %s to be added and none removed, got %+v", ts2.URL, update)
+	}
+	if bestURL, _ := manager.GetBestEndpointURL(); bestURL != ts2.URL {
+		t.Errorf("Expected best endpoint to be %s with the higher block number, got %s", ts2.URL, bestURL)
+	}
+	for _, endpoint := range manager.Endpoints {
+		if endpoint.URL == ts1.URL && endpoint != keptEndpoint {
+			t.Errorf("Expected the endpoint %s to be kept", ts1.URL)
+		}
+	}
+
+	update, err = manager.UpdateEndpoints(endpointsConfig(ts1.URL, ts2.URL))
+	if err != nil || update.IsChanged() {
+		t.Errorf("Expected no change for the same endpoints, got %+v, error %v", update, err)
+	}
+
+	// A lower priority is preferred over the higher block number of the other endpoint
+	prioritisedConfig := endpointsConfig(ts1.URL, ts2.URL)
+	prioritisedConfig.Endpoints[1].Priority = 1
+	prioritisedConfig.Scoring = ScoringConfig{BlockLagPenalty: 2}
+	update, err = manager.UpdateEndpoints(prioritisedConfig)
+	if err != nil {
+		t.Fatalf("UpdateEndpoints failed: %v", err)
+	}
+	if len(update.Reconfigured) != 1 || update.Reconfigured[0] != ts2.URL || !update.IsScoringChanged || len(update.Added) != 0 {
+		t.Errorf("Expected %s to be reconfigured and the scoring to change, got %+v", ts2.URL, update)
+	}
+	if bestURL, _ := manager.GetBestEndpointURL(); bestURL != ts1.URL {
+		t.Errorf("Expected best endpoint to be %s with the lower priority, got %s", ts1.URL, bestURL)
+	}
+
+	update, err = manager.UpdateEndpoints(endpointsConfig(ts1.URL))
+	if err != nil {
+		t.Fatalf("UpdateEndpoints failed: %v", err)
+	}
+	if len(update.Added) != 0 || len(update.Removed) != 1 || update.Removed[0] != ts2.URL {
+		t.Errorf("Expected %s to be removed, got %+v", ts2.URL, update)
+	}
+	if bestURL, _ := manager.GetBestEndpointURL(); bestURL != ts1.URL {
+		t.Errorf("Expected best endpoint to be %s after removing %s, got %s", ts1.URL, ts2.URL, bestURL)
+	}
+
+	invalidConfigs := []EndpointsConfig{
+		endpointsConfig(),
+		endpointsConfig("localhost:8545"),
+		endpointsConfig("ftp://example.com"),
+		endpointsConfig(ts1.URL, ts1.URL),
+		endpointsConfig(ts3.URL),
+		{Endpoints: []EndpointConfig{{URL: ts1.URL, Weight: -1}}},
+		{Endpoints: []EndpointConfig{{URL: ts1.URL}}, Scoring: ScoringConfig{Smoothing: 2}},
+	}
+	for _, config := range invalidConfigs {
+		if _, err := manager.UpdateEndpoints(config); err == nil {
+			t.Errorf("Expected an error when updating endpoints to %+v", config)
+		}
+		if len(manager.Endpoints) != 1 || manager.Endpoints[0].URL != ts1.URL {
+			t.Errorf("Expected endpoints to be unchanged after updating to %+v, got %d endpoints", config, len(manager.Endpoints))
+		}
+	}
+}
+
+func TestGetQuorumClients(t *testing.T) {
+	now := time.Now()
+	best := &RPCEndpoint{URL: "https://best.example.com", Client: &ethclient.Client{}}
+	second := &RPCEndpoint{URL: "https://second.example.com", Client: &ethclient.Client{}}
+	quarantined := &RPCEndpoint{URL: "https://quarantined.example.com", Client: &ethclient.Client{}, Health: EndpointHealth{QuarantinedUntil: now.Add(time.Minute)}}
+	third := &RPCEndpoint{URL: "https://third.example.com", Client: &ethclient.Client{}}
+	manager := &RPCManager{Endpoints: []*RPCEndpoint{second, best, quarantined, third}, BestEndpoint: best}
+
+	clients, _, err := manager.GetQuorumClients()
+	if err != nil || clients != nil || manager.IsQuorumEnabled() {
+		t.Errorf("Expected no quorum clients when quorum reads are disabled, got %d clients and error %v", len(clients), err)
+	}
+
+	manager.Quorum = QuorumConfig{Size: 3}
+	clients, threshold, err := manager.GetQuorumClients()
+	if err != nil {
+		t.Fatalf("GetQuorumClients failed: %v", err)
+	}
+	if threshold != 2 || len(clients) != 3 || clients[0] != best.Client || clients[1] != second.Client || clients[2] != third.Client {
+		t.Errorf("Expected the best, second and third clients with a threshold of 2, got %d clients with a threshold of %d", len(clients), threshold)
+	}
+	if got := manager.GetEndpointURL(third.Client); got != third.URL {
+		t.Errorf("GetEndpointURL() = %s, want %s", got, third.URL)
+	}
+
+	manager.Quorum = QuorumConfig{Size: 4, Threshold: 4}
+	if _, _, err := manager.GetQuorumClients(); !errors.Is(err, ErrNoQuorum) {
+		t.Errorf("Expected %v when fewer endpoints than the threshold are available, got %v", ErrNoQuorum, err)
+	}
+}
+
+func TestQuorumConfigValidate(t *testing.T) {
+	validConfigs := []QuorumConfig{{}, {Size: 1}, {Size: 3}, {Size: 3, Threshold: 3}, {Size: 4, Threshold: 3}}
+	for _, config := range validConfigs {
+		if err := config.Validate(); err != nil {
+			t.Errorf("Validate() of %+v error = %v", config, err)
+		}
+	}
+	invalidConfigs := []QuorumConfig{{Size: -1}, {Threshold: 2}, {Size: 4, Threshold: 2}, {Size: 3, Threshold: 4}}
+	for _, config := range invalidConfigs {
+		if err := config.Validate(); err == nil {
+			t.Errorf("Validate() of %+v, expected an error", config)
+		}
+	}
+}
+
+func TestInitializeRPCManagerValidatesEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		provider string
+		wantErr  string
+	}{
+		{
+			name:    "Test 1: When an endpoint is not a valid URL",
+			file:    `["not-a-url"]`,
+			wantErr: "is not a valid URL",
+		},
+		{
+			name:     "Test 2: When the provider is listed more than once",
+			file:     `{"endpoints": [{"url": "http://localhost:1"}, {"url": "http://localhost:1"}]}`,
+			provider: "http://localhost:2",
+			wantErr:  "is listed more than once",
+		},
+		{
+			name:     "Test 3: When the quorum is larger than the endpoints with the provider",
+			file:     `{"endpoints": [{"url": "http://localhost:1"}], "quorum": {"size": 3, "threshold": 2}}`,
+			provider: "http://localhost:2",
+			wantErr:  "size 3 of the quorum is more than the 2 endpoints",
+		},
+	}
+	originalPathUtils := path.PathUtilsInterface
+	defer func() { path.PathUtilsInterface = originalPathUtils }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			razorPath := t.TempDir()
+			if err := os.WriteFile(filepath.Join(razorPath, core.EndpointsFile), []byte(tt.file), 0600); err != nil {
+				t.Fatal(err)
+			}
+			pathMock := new(mocks.PathInterface)
+			pathMock.On("GetDefaultPath").Return(razorPath, nil)
+			path.PathUtilsInterface = pathMock
+
+			_, err := InitializeRPCManager(tt.provider)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("InitializeRPCManager() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
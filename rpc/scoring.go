@@ -0,0 +1,318 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"razor/core"
+	"razor/metrics"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrTimeout is the error of a contract call which didn't return within the RPC timeout
+var ErrTimeout = errors.New("RPC timeout error")
+
+// ScoringConfig configures how the endpoints are ranked, it is the scoring section of endpoints.json. Fields which are not set take their default.
+//
+// The score of an endpoint is its weight divided by 1 plus its penalties for latency, error rate and block lag, so a healthy endpoint with
+// weight 1 scores close to 1. Endpoints are ranked by priority first and then by score, quarantined and unreachable endpoints last.
+type ScoringConfig struct {
+	// Smoothing is the weight of the latest sample in the exponentially weighted latency and error rate, between 0 and 1
+	Smoothing float64 `json:"smoothing,omitempty"`
+	// LatencyPenalty is the penalty per second of average latency
+	LatencyPenalty float64 `json:"latencyPenalty,omitempty"`
+	// ErrorPenalty is the penalty of an endpoint whose calls all fail
+	ErrorPenalty float64 `json:"errorPenalty,omitempty"`
+	// BlockLagPenalty is the penalty per block behind the most up to date endpoint
+	BlockLagPenalty float64 `json:"blockLagPenalty,omitempty"`
+	// QuarantineErrors is the number of consecutive failed calls after which an endpoint is quarantined
+	QuarantineErrors int `json:"quarantineErrors,omitempty"`
+	// QuarantineSeconds is the duration of the first quarantine of an endpoint, it doubles with every quarantine without a successful call in between
+	QuarantineSeconds int `json:"quarantineSeconds,omitempty"`
+	// MaxQuarantineSeconds is the maximum duration of a quarantine
+	MaxQuarantineSeconds int `json:"maxQuarantineSeconds,omitempty"`
+}
+
+// withDefaults returns the scoring with the defaults for the fields which are not set
+func (c ScoringConfig) withDefaults() ScoringConfig {
+	if c.Smoothing == 0 {
+		c.Smoothing = core.RPCScoreSmoothing
+	}
+	if c.LatencyPenalty == 0 {
+		c.LatencyPenalty = core.RPCScoreLatencyPenalty
+	}
+	if c.ErrorPenalty == 0 {
+		c.ErrorPenalty = core.RPCScoreErrorPenalty
+	}
+	if c.BlockLagPenalty == 0 {
+		c.BlockLagPenalty = core.RPCScoreBlockLagPenalty
+	}
+	if c.QuarantineErrors == 0 {
+		c.QuarantineErrors = core.RPCQuarantineErrors
+	}
+	if c.QuarantineSeconds == 0 {
+		c.QuarantineSeconds = core.RPCQuarantineDuration
+	}
+	if c.MaxQuarantineSeconds == 0 {
+		c.MaxQuarantineSeconds = core.RPCMaxQuarantineDuration
+	}
+	return c
+}
+
+// Validate checks that the smoothing is at most 1 and that no field is negative
+func (c ScoringConfig) Validate() error {
+	if c.Smoothing < 0 || c.Smoothing > 1 {
+		return fmt.Errorf("smoothing %v should be between 0 and 1", c.Smoothing)
+	}
+	if c.LatencyPenalty < 0 || c.ErrorPenalty < 0 || c.BlockLagPenalty < 0 {
+		return errors.New("penalties of the scoring cannot be negative")
+	}
+	if c.QuarantineErrors < 0 || c.QuarantineSeconds < 0 || c.MaxQuarantineSeconds < 0 {
+		return errors.New("quarantine of the scoring cannot be negative")
+	}
+	return nil
+}
+
+// EndpointHealth is the history of the calls to an endpoint which it is scored by
+type EndpointHealth struct {
+	// AverageLatency is the exponentially weighted latency in seconds of the successful calls
+	AverageLatency float64
+	// ErrorRate is the exponentially weighted rate of failed calls, between 0 and 1
+	ErrorRate         float64
+	ConsecutiveErrors int
+	// Quarantines is the number of quarantines in a row, without a successful call in between
+	Quarantines      int
+	QuarantinedUntil time.Time
+	LastError        string
+	Score            float64
+}
+
+// EndpointStatus is the rank and health of an endpoint
+type EndpointStatus struct {
+	Rank        int
+	URL         string
+	Priority    int
+	Weight      float64
+	BlockNumber uint64
+	BlockLag    uint64
+	IsBest      bool
+	IsAvailable bool
+	Health      EndpointHealth
+}
+
+// IsEndpointError returns whether the error is caused by the endpoint, like a connection, timeout or server error or a result
+// which disagrees with the quorum, rather than by the call itself, like a reverted call
+func IsEndpointError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	// The errors of all the attempts of a retried call, like retry.Error, are classified by the last attempt
+	if wrapper, ok := err.(interface{ WrappedErrors() []error }); ok {
+		wrappedErrors := wrapper.WrappedErrors()
+		for i := len(wrappedErrors) - 1; i >= 0; i-- {
+			if wrappedErrors[i] != nil {
+				return IsEndpointError(wrappedErrors[i])
+			}
+		}
+		return false
+	}
+	if errors.Is(err, ErrTimeout) || errors.Is(err, ErrQuorumDisagreement) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var httpErr gethRPC.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+	var jsonRPCErr gethRPC.Error
+	if errors.As(err, &jsonRPCErr) {
+		// Internal errors and exceeded limits of the endpoint, other codes are errors of the call
+		return jsonRPCErr.ErrorCode() == -32603 || jsonRPCErr.ErrorCode() == -32005
+	}
+	return false
+}
+
+//This function returns the weight of the endpoint, which is 1 if it is not set
+func (e *RPCEndpoint) weight() float64 {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+//This function returns whether the endpoint can be used, that is it has a client and isn't quarantined
+func (e *RPCEndpoint) isAvailable(now time.Time) bool {
+	return e.Client != nil && !e.isQuarantined(now)
+}
+
+func (e *RPCEndpoint) isQuarantined(now time.Time) bool {
+	return now.Before(e.Health.QuarantinedUntil)
+}
+
+//This function records a successful call which took latency seconds
+func (e *RPCEndpoint) recordSuccess(scoring ScoringConfig, latency float64) {
+	if e.Health.AverageLatency == 0 {
+		e.Health.AverageLatency = latency
+	} else {
+		e.Health.AverageLatency += scoring.Smoothing * (latency - e.Health.AverageLatency)
+	}
+	e.Health.ErrorRate -= scoring.Smoothing * e.Health.ErrorRate
+	e.Health.ConsecutiveErrors = 0
+	e.Health.Quarantines = 0
+}
+
+//This function records a failed call and returns whether the endpoint is quarantined because of it
+func (e *RPCEndpoint) recordFailure(scoring ScoringConfig, err error, now time.Time) bool {
+	e.Health.ErrorRate += scoring.Smoothing * (1 - e.Health.ErrorRate)
+	e.Health.ConsecutiveErrors++
+	e.Health.LastError = err.Error()
+	metrics.RPCEndpointErrorsMetric.WithLabelValues(metrics.EndpointLabel(e.URL)).Inc()
+	if e.Health.ConsecutiveErrors < scoring.QuarantineErrors {
+		return false
+	}
+
+	duration := float64(scoring.QuarantineSeconds) * math.Pow(2, float64(e.Health.Quarantines))
+	duration = math.Min(duration, float64(scoring.MaxQuarantineSeconds))
+	e.Health.QuarantinedUntil = now.Add(time.Duration(duration) * time.Second)
+	e.Health.Quarantines++
+	e.Health.ConsecutiveErrors = 0
+	logrus.Warnf("RPC endpoint %s failed %d calls in a row, quarantining it until %s: %v", e.URL, scoring.QuarantineErrors, e.Health.QuarantinedUntil.Format(time.RFC3339), err)
+	return true
+}
+
+//This function returns the score of the endpoint against the block number of the most up to date endpoint
+func (e *RPCEndpoint) score(scoring ScoringConfig, highestBlockNumber uint64) float64 {
+	penalty := 1 + e.Health.AverageLatency*scoring.LatencyPenalty + e.Health.ErrorRate*scoring.ErrorPenalty
+	if highestBlockNumber > e.BlockNumber {
+		penalty += float64(highestBlockNumber-e.BlockNumber) * scoring.BlockLagPenalty
+	}
+	return e.weight() / penalty
+}
+
+//This function returns the highest block number of the available endpoints, it is called with the lock held
+func (m *RPCManager) highestBlockNumber(now time.Time) uint64 {
+	var highestBlockNumber uint64
+	for _, endpoint := range m.Endpoints {
+		if endpoint.isAvailable(now) && endpoint.BlockNumber > highestBlockNumber {
+			highestBlockNumber = endpoint.BlockNumber
+		}
+	}
+	return highestBlockNumber
+}
+
+//This function scores the endpoints and sorts them by availability, priority and score, it is called with the lock held
+func (m *RPCManager) rankEndpoints(now time.Time) {
+	scoring := m.Scoring.withDefaults()
+	highestBlockNumber := m.highestBlockNumber(now)
+	for _, endpoint := range m.Endpoints {
+		endpoint.Health.Score = endpoint.score(scoring, highestBlockNumber)
+	}
+	sort.SliceStable(m.Endpoints, func(i, j int) bool {
+		a, b := m.Endpoints[i], m.Endpoints[j]
+		if a.isAvailable(now) != b.isAvailable(now) {
+			return a.isAvailable(now)
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Health.Score > b.Health.Score
+	})
+
+	for _, endpoint := range m.Endpoints {
+		metrics.RPCEndpointScoreMetric.WithLabelValues(metrics.EndpointLabel(endpoint.URL)).Set(endpoint.Health.Score)
+		quarantined := 0.0
+		if endpoint.isQuarantined(now) {
+			quarantined = 1
+		}
+		metrics.RPCEndpointQuarantinedMetric.WithLabelValues(metrics.EndpointLabel(endpoint.URL)).Set(quarantined)
+		// Block lag is measured against the endpoint with the highest block number
+		if highestBlockNumber >= endpoint.BlockNumber {
+			metrics.RPCEndpointBlockLagMetric.WithLabelValues(metrics.EndpointLabel(endpoint.URL)).Set(float64(highestBlockNumber - endpoint.BlockNumber))
+		}
+	}
+}
+
+//This function returns the endpoint of the client, it is called with the lock held
+func (m *RPCManager) endpointOf(client *ethclient.Client) *RPCEndpoint {
+	for _, endpoint := range m.Endpoints {
+		if endpoint.Client == client {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// RecordCall records the outcome of a call made with the client in the health of its endpoint. Only errors caused by the endpoint count as failures.
+// When the best endpoint is quarantined, the next available endpoint in the ranking becomes the best endpoint.
+func (m *RPCManager) RecordCall(client *ethclient.Client, latency time.Duration, err error) {
+	if client == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	endpoint := m.endpointOf(client)
+	if endpoint == nil {
+		return
+	}
+	scoring := m.Scoring.withDefaults()
+	if !IsEndpointError(err) {
+		// A call which failed because of its arguments or the contract still shows the endpoint responds
+		endpoint.recordSuccess(scoring, latency.Seconds())
+		return
+	}
+	now := time.Now()
+	if !endpoint.recordFailure(scoring, err, now) || endpoint != m.BestEndpoint {
+		return
+	}
+
+	m.rankEndpoints(now)
+	nextBest := m.Endpoints[0]
+	if nextBest == endpoint || !nextBest.isAvailable(now) {
+		logrus.Warn("No available RPC endpoint to switch to from the quarantined endpoint. Retaining the current best client.")
+		return
+	}
+	metrics.RPCSwitchesMetric.WithLabelValues(metrics.EndpointLabel(endpoint.URL), metrics.EndpointLabel(nextBest.URL)).Inc()
+	m.BestEndpoint = nextBest
+	logrus.Infof("Switched to the next best RPC endpoint: %s (BlockNumber: %d, Score: %.3f)", nextBest.URL, nextBest.BlockNumber, nextBest.Health.Score)
+}
+
+// GetEndpointsStatus returns the endpoints with their health in the order of the last ranking applied by RefreshEndpoints or RecordCall.
+// The endpoints aren't ranked again, so that the status always agrees with the best endpoint in use.
+func (m *RPCManager) GetEndpointsStatus() []EndpointStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	highestBlockNumber := m.highestBlockNumber(now)
+	statuses := make([]EndpointStatus, len(m.Endpoints))
+	for i, endpoint := range m.Endpoints {
+		statuses[i] = EndpointStatus{
+			Rank:        i + 1,
+			URL:         endpoint.URL,
+			Priority:    endpoint.Priority,
+			Weight:      endpoint.weight(),
+			BlockNumber: endpoint.BlockNumber,
+			IsBest:      endpoint == m.BestEndpoint,
+			IsAvailable: endpoint.isAvailable(now),
+			Health:      endpoint.Health,
+		}
+		if highestBlockNumber > endpoint.BlockNumber {
+			statuses[i].BlockLag = highestBlockNumber - endpoint.BlockNumber
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+)
+
+type jsonRPCError struct {
+	code int
+}
+
+func (e jsonRPCError) Error() string  { return fmt.Sprintf("json-rpc error %d", e.code) }
+func (e jsonRPCError) ErrorCode() int { return e.code }
+
+func TestIsEndpointError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no error", err: nil, want: false},
+		{name: "timeout of the call", err: ErrTimeout, want: true},
+		{name: "deadline of the context", err: fmt.Errorf("call failed: %w", context.DeadlineExceeded), want: true},
+		{name: "cancelled context", err: context.Canceled, want: false},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "connection closed", err: io.ErrUnexpectedEOF, want: true},
+		{name: "server error", err: gethRPC.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, want: true},
+		{name: "rate limited", err: gethRPC.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, want: true},
+		{name: "bad request", err: gethRPC.HTTPError{StatusCode: 400, Status: "400 Bad Request"}, want: false},
+		{name: "internal json-rpc error", err: jsonRPCError{code: -32603}, want: true},
+		{name: "reverted call", err: jsonRPCError{code: 3}, want: false},
+		{name: "other error", err: errors.New("execution reverted"), want: false},
+		{name: "retried call which timed out", err: retry.Error{errors.New("execution reverted"), ErrTimeout, nil}, want: true},
+		{name: "retried call which reverted", err: retry.Error{ErrTimeout, errors.New("execution reverted")}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEndpointError(tt.err); got != tt.want {
+				t.Errorf("IsEndpointError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankEndpoints(t *testing.T) {
+	now := time.Now()
+	upToDate := &RPCEndpoint{URL: "https://up-to-date.example.com", BlockNumber: 100, Client: &ethclient.Client{}, Health: EndpointHealth{AverageLatency: 0.2}}
+	lagging := &RPCEndpoint{URL: "https://lagging.example.com", BlockNumber: 98, Client: &ethclient.Client{}, Health: EndpointHealth{AverageLatency: 0.1}}
+	failing := &RPCEndpoint{URL: "https://failing.example.com", BlockNumber: 100, Client: &ethclient.Client{}, Health: EndpointHealth{AverageLatency: 0.1, ErrorRate: 0.5}}
+	weighted := &RPCEndpoint{URL: "https://weighted.example.com", BlockNumber: 100, Client: &ethclient.Client{}, Weight: 4, Health: EndpointHealth{AverageLatency: 0.1, ErrorRate: 0.5}}
+	quarantined := &RPCEndpoint{URL: "https://quarantined.example.com", BlockNumber: 200, Client: &ethclient.Client{}, Health: EndpointHealth{QuarantinedUntil: now.Add(time.Minute)}}
+	unreachable := &RPCEndpoint{URL: "https://unreachable.example.com"}
+	backup := &RPCEndpoint{URL: "https://backup.example.com", BlockNumber: 100, Client: &ethclient.Client{}, Priority: 1}
+
+	manager := &RPCManager{Endpoints: []*RPCEndpoint{unreachable, backup, quarantined, failing, lagging, weighted, upToDate}}
+	manager.rankEndpoints(now)
+
+	want := []*RPCEndpoint{upToDate, weighted, lagging, failing, backup, quarantined, unreachable}
+	for i, endpoint := range manager.Endpoints {
+		if endpoint != want[i] {
+			t.Errorf("Endpoint %d = %s (score %.3f), want %s (score %.3f)", i, endpoint.URL, endpoint.Health.Score, want[i].URL, want[i].Health.Score)
+		}
+	}
+	// The block lag is measured against the available endpoints only
+	if upToDate.Health.Score <= lagging.Health.Score {
+		t.Errorf("Expected the up to date endpoint to score more than the lagging endpoint, got %.3f and %.3f", upToDate.Health.Score, lagging.Health.Score)
+	}
+}
+
+func TestRecordCall(t *testing.T) {
+	best := &RPCEndpoint{URL: "https://best.example.com", BlockNumber: 100, Client: &ethclient.Client{}}
+	next := &RPCEndpoint{URL: "https://next.example.com", BlockNumber: 100, Client: &ethclient.Client{}, Health: EndpointHealth{AverageLatency: 0.5}}
+	manager := &RPCManager{Endpoints: []*RPCEndpoint{best, next}, BestEndpoint: best, Scoring: ScoringConfig{QuarantineErrors: 2, QuarantineSeconds: 10, MaxQuarantineSeconds: 15}}
+
+	manager.RecordCall(best.Client, 200*time.Millisecond, nil)
+	manager.RecordCall(best.Client, 400*time.Millisecond, errors.New("execution reverted"))
+	if best.Health.AverageLatency < 0.259 || best.Health.AverageLatency > 0.261 || best.Health.ErrorRate != 0 {
+		t.Errorf("Expected average latency 0.26 without errors, got %+v", best.Health)
+	}
+
+	manager.RecordCall(best.Client, time.Second, ErrTimeout)
+	if best.Health.ConsecutiveErrors != 1 || best.Health.ErrorRate == 0 || manager.BestEndpoint != best {
+		t.Errorf("Expected one error to be recorded without a switch, got %+v", best.Health)
+	}
+
+	start := time.Now()
+	manager.RecordCall(best.Client, time.Second, ErrTimeout)
+	if manager.BestEndpoint != next {
+		t.Fatalf("Expected a switch to %s after quarantining the best endpoint, got %s", next.URL, manager.BestEndpoint.URL)
+	}
+	if quarantine := best.Health.QuarantinedUntil.Sub(start); quarantine < 10*time.Second || quarantine > 11*time.Second {
+		t.Errorf("Expected a quarantine of 10s, got %v", quarantine)
+	}
+
+	// Quarantines without a successful call in between double up to the maximum
+	manager.RecordCall(best.Client, time.Second, ErrTimeout)
+	manager.RecordCall(best.Client, time.Second, ErrTimeout)
+	if quarantine := best.Health.QuarantinedUntil.Sub(start); quarantine < 15*time.Second || quarantine > 16*time.Second || best.Health.Quarantines != 2 {
+		t.Errorf("Expected a second quarantine of 15s, got %v after %d quarantines", quarantine, best.Health.Quarantines)
+	}
+
+	statuses := manager.GetEndpointsStatus()
+	if len(statuses) != 2 || statuses[0].URL != next.URL || !statuses[0].IsBest || statuses[1].IsAvailable || statuses[1].Health.LastError != ErrTimeout.Error() {
+		t.Errorf("GetEndpointsStatus() = %+v", statuses)
+	}
+
+	// Calls of a client which isn't an endpoint are ignored
+	manager.RecordCall(&ethclient.Client{}, time.Second, ErrTimeout)
+	manager.RecordCall(nil, time.Second, ErrTimeout)
+}
+
+func TestGetEndpointsStatus(t *testing.T) {
+	best := &RPCEndpoint{URL: "https://best.example.com", BlockNumber: 100, Health: EndpointHealth{Score: 1, AverageLatency: 0.5}}
+	next := &RPCEndpoint{URL: "https://next.example.com", BlockNumber: 98, Health: EndpointHealth{Score: 0.5, AverageLatency: 1}}
+	manager := &RPCManager{Endpoints: []*RPCEndpoint{best, next}, BestEndpoint: best}
+
+	// The next endpoint would rank first now, but the status keeps the last ranking applied
+	next.BlockNumber = 100
+	next.Health.AverageLatency = 0.1
+	statuses := manager.GetEndpointsStatus()
+	if len(statuses) != 2 || statuses[0].URL != best.URL || statuses[0].Rank != 1 || !statuses[0].IsBest || statuses[0].Health.Score != 1 {
+		t.Fatalf("GetEndpointsStatus() = %+v, want the best endpoint ranked first", statuses)
+	}
+	if statuses[1].URL != next.URL || statuses[1].Rank != 2 || statuses[1].IsBest || statuses[1].Health.Score != 0.5 {
+		t.Errorf("GetEndpointsStatus() = %+v, want the next endpoint ranked second with its last score", statuses)
+	}
+	if manager.Endpoints[0] != best || manager.BestEndpoint != best {
+		t.Error("Expected GetEndpointsStatus() not to rank the endpoints again")
+	}
+}
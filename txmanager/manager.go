@@ -0,0 +1,446 @@
+// Package txmanager tracks the transactions sent by the node until they reach a final outcome.
+// It assigns nonces on top of the transactions which are still pending, resubmits transactions
+// which are stuck with an increased gas price and cancels transactions which are no longer relevant.
+package txmanager
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"razor/core"
+	"razor/metrics"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Outcome is the final state of a tracked transaction.
+type Outcome string
+
+const (
+	// OutcomeMined is reported when the transaction or one of its replacements is mined successfully.
+	OutcomeMined Outcome = "mined"
+	// OutcomeReverted is reported when the transaction or one of its replacements is mined but reverted.
+	OutcomeReverted Outcome = "reverted"
+	// OutcomeCancelled is reported when the cancellation sent for the transaction is mined.
+	OutcomeCancelled Outcome = "cancelled"
+	// OutcomeDropped is reported when the nonce of the transaction is used by a transaction which is not tracked.
+	OutcomeDropped Outcome = "dropped"
+	// OutcomeAbandoned is reported when the transaction is still pending after the maximum number of gas bumps.
+	OutcomeAbandoned Outcome = "abandoned"
+)
+
+const (
+	replacementReasonGasBump = "gas_bump"
+	replacementReasonCancel  = "cancel"
+)
+
+// Backend is the part of the ethereum client used by the manager.
+type Backend interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// GasPriceFunc returns the gas price the node would currently use for a new transaction.
+type GasPriceFunc func() *big.Int
+
+// Window is the epoch and state in which a transaction is relevant. A transaction which
+// is still pending after its window closes is cancelled.
+type Window struct {
+	Epoch uint32
+	State int64
+}
+
+// Meta describes what a transaction does.
+type Meta struct {
+	Action string
+	Window *Window
+}
+
+// Result is the final outcome of a tracked transaction.
+type Result struct {
+	Action  string
+	From    common.Address
+	Nonce   uint64
+	Hash    common.Hash
+	Outcome Outcome
+}
+
+// Config holds the thresholds used to resubmit pending transactions.
+type Config struct {
+	StuckTimeout   time.Duration
+	GasBumpPercent int64
+	MaxGasBumps    int
+}
+
+// DefaultConfig returns the config built from the transaction constants in core.
+func DefaultConfig() Config {
+	return Config{
+		StuckTimeout:   core.TransactionStuckTimeout * time.Second,
+		GasBumpPercent: core.TransactionGasBumpPercent,
+		MaxGasBumps:    core.TransactionMaxGasBumps,
+	}
+}
+
+type trackedTransaction struct {
+	meta       Meta
+	from       common.Address
+	nonce      uint64
+	current    *types.Transaction
+	hashes     []common.Hash
+	sentAt     time.Time
+	gasBumps   int
+	cancelling bool
+	signer     bind.SignerFn
+}
+
+// Manager tracks the pending transactions of every sender by nonce.
+type Manager struct {
+	mu sync.Mutex
+	// checkMu makes sure that only one check goes through the pending transactions at a time,
+	// as the fields of a tracked transaction are only changed by the check
+	checkMu  sync.Mutex
+	config   Config
+	gasPrice GasPriceFunc
+	pending  map[common.Address]map[uint64]*trackedTransaction
+	now      func() time.Time
+}
+
+// NewManager creates a manager which uses gasPrice as the minimum gas price of resubmitted transactions.
+func NewManager(config Config, gasPrice GasPriceFunc) *Manager {
+	return &Manager{
+		config:   config,
+		gasPrice: gasPrice,
+		pending:  make(map[common.Address]map[uint64]*trackedTransaction),
+		now:      time.Now,
+	}
+}
+
+// NextNonce returns the nonce for a new transaction of the sender. The nonce fetched from the chain
+// doesn't account for transactions which are still pending, so the next nonce after the highest
+// tracked transaction is used if it is greater.
+func (m *Manager) NextNonce(from common.Address, chainNonce uint64) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce := chainNonce
+	for trackedNonce := range m.pending[from] {
+		if trackedNonce >= nonce {
+			nonce = trackedNonce + 1
+		}
+	}
+	return nonce
+}
+
+// WrapTransactor returns a copy of the transaction options which sends every transaction it signs to the backend
+// and starts tracking it once it is sent, so that a transaction which fails to be sent doesn't hold a nonce. The
+// returned options don't send the transaction again. The original signer is kept to sign replacements of the transaction.
+func (m *Manager) WrapTransactor(txnOpts *bind.TransactOpts, backend Backend, meta Meta) *bind.TransactOpts {
+	if txnOpts == nil || txnOpts.Signer == nil || txnOpts.NoSend {
+		return txnOpts
+	}
+	wrappedOpts := *txnOpts
+	wrappedOpts.NoSend = true
+	signer := txnOpts.Signer
+	wrappedOpts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(from, tx)
+		if err != nil {
+			return nil, err
+		}
+		ctx := wrappedOpts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := backend.SendTransaction(ctx, signedTx); err != nil {
+			return nil, err
+		}
+		m.track(from, signedTx, signer, meta)
+		return signedTx, nil
+	}
+	return &wrappedOpts
+}
+
+func (m *Manager) track(from common.Address, tx *types.Transaction, signer bind.SignerFn, meta Meta) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.pending[from] == nil {
+		m.pending[from] = make(map[uint64]*trackedTransaction)
+	}
+	if existing, ok := m.pending[from][tx.Nonce()]; ok {
+		logrus.Warnf("Transaction %s replaces tracked %s transaction %s with nonce %d", tx.Hash().Hex(), existing.meta.Action, existing.current.Hash().Hex(), tx.Nonce())
+	}
+	m.pending[from][tx.Nonce()] = &trackedTransaction{
+		meta:    meta,
+		from:    from,
+		nonce:   tx.Nonce(),
+		current: tx,
+		hashes:  []common.Hash{tx.Hash()},
+		sentAt:  m.now(),
+		signer:  signer,
+	}
+}
+
+// ResolveHash returns the hash of the latest submission of the tracked transaction which was first sent with the given hash.
+// The hash is returned unchanged if the transaction is not tracked.
+func (m *Manager) ResolveHash(hash common.Hash) common.Hash {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, transactions := range m.pending {
+		for _, transaction := range transactions {
+			for _, submittedHash := range transaction.hashes {
+				if submittedHash == hash {
+					return transaction.current.Hash()
+				}
+			}
+		}
+	}
+	return hash
+}
+
+// PendingCount returns the number of transactions which haven't reached a final outcome.
+func (m *Manager) PendingCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, transactions := range m.pending {
+		count += len(transactions)
+	}
+	return count
+}
+
+// Check goes through all the pending transactions once. Transactions which are mined or whose nonce has been used
+// are reported and removed, transactions whose window has closed at the given header are cancelled and transactions
+// which have been pending for longer than the stuck timeout are resubmitted with an increased gas price.
+// The pending transactions are checked without holding the lock, so that new transactions can be tracked meanwhile.
+func (m *Manager) Check(ctx context.Context, backend Backend, header *types.Header) []Result {
+	m.checkMu.Lock()
+	defer m.checkMu.Unlock()
+
+	var results []Result
+	for from, transactions := range m.pendingSnapshot() {
+		confirmedNonce, err := backend.NonceAt(ctx, from, nil)
+		if err != nil {
+			logrus.Errorf("Error in fetching nonce of %s to check pending transactions: %v", from.Hex(), err)
+			continue
+		}
+		for _, transaction := range transactions {
+			result, done := m.checkTransaction(ctx, backend, header, transaction, confirmedNonce)
+			if done {
+				m.untrack(transaction)
+				results = append(results, result)
+				metrics.TransactionOutcomesMetric.WithLabelValues(result.Action, string(result.Outcome)).Inc()
+				logrus.Infof("Transaction %s for %s with nonce %d reached final outcome: %s", result.Hash.Hex(), result.Action, result.Nonce, result.Outcome)
+			}
+		}
+	}
+	return results
+}
+
+// Watch checks the pending transactions every interval until the context is done, so that a stuck transaction is
+// resubmitted while the node is blocked waiting for a transaction to be mined and not only when it handles a new block.
+// latest returns the backend and the latest header to check the transactions with, and the results of a check are passed to report.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration, latest func() (Backend, *types.Header, error), report func([]Result)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if m.PendingCount() == 0 {
+				continue
+			}
+			backend, header, err := latest()
+			if err != nil {
+				logrus.Errorf("Error in getting the latest header to check pending transactions: %v", err)
+				continue
+			}
+			if results := m.Check(ctx, backend, header); len(results) > 0 && report != nil {
+				report(results)
+			}
+		}
+	}
+}
+
+// pendingSnapshot returns the pending transactions of every sender sorted by nonce
+func (m *Manager) pendingSnapshot() map[common.Address][]*trackedTransaction {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[common.Address][]*trackedTransaction, len(m.pending))
+	for from, transactions := range m.pending {
+		for _, nonce := range sortedNonces(transactions) {
+			snapshot[from] = append(snapshot[from], transactions[nonce])
+		}
+	}
+	return snapshot
+}
+
+// untrack removes the transaction unless a new transaction with the same nonce has been tracked since
+func (m *Manager) untrack(transaction *trackedTransaction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	transactions := m.pending[transaction.from]
+	if transactions[transaction.nonce] != transaction {
+		return
+	}
+	delete(transactions, transaction.nonce)
+	if len(transactions) == 0 {
+		delete(m.pending, transaction.from)
+	}
+}
+
+func (m *Manager) checkTransaction(ctx context.Context, backend Backend, header *types.Header, transaction *trackedTransaction, confirmedNonce uint64) (Result, bool) {
+	result := Result{
+		Action: transaction.meta.Action,
+		From:   transaction.from,
+		Nonce:  transaction.nonce,
+		Hash:   transaction.current.Hash(),
+	}
+
+	// Any of the submissions can be mined, so the receipts of all of them are checked starting from the latest
+	for i := len(transaction.hashes) - 1; i >= 0; i-- {
+		receipt, err := backend.TransactionReceipt(ctx, transaction.hashes[i])
+		if err != nil || receipt == nil {
+			continue
+		}
+		result.Hash = transaction.hashes[i]
+		switch {
+		case transaction.cancelling && i == len(transaction.hashes)-1:
+			result.Outcome = OutcomeCancelled
+		case receipt.Status == types.ReceiptStatusSuccessful:
+			result.Outcome = OutcomeMined
+		default:
+			result.Outcome = OutcomeReverted
+		}
+		return result, true
+	}
+
+	if transaction.nonce < confirmedNonce {
+		result.Outcome = OutcomeDropped
+		return result, true
+	}
+
+	if !transaction.cancelling && transaction.meta.Window != nil && header != nil && !isInWindow(header, *transaction.meta.Window) {
+		logrus.Warnf("Window of %s transaction %s has closed, cancelling it", transaction.meta.Action, transaction.current.Hash().Hex())
+		if err := m.replace(ctx, backend, transaction, true); err != nil {
+			logrus.Errorf("Error in cancelling %s transaction %s: %v", transaction.meta.Action, transaction.current.Hash().Hex(), err)
+		}
+		return result, false
+	}
+
+	if m.now().Sub(transaction.sentAt) < m.config.StuckTimeout {
+		return result, false
+	}
+	if transaction.gasBumps >= m.config.MaxGasBumps {
+		result.Outcome = OutcomeAbandoned
+		return result, true
+	}
+	logrus.Warnf("%s transaction %s is pending for %s, resubmitting it with higher gas price", transaction.meta.Action, transaction.current.Hash().Hex(), m.now().Sub(transaction.sentAt).Round(time.Second))
+	if err := m.replace(ctx, backend, transaction, transaction.cancelling); err != nil {
+		logrus.Errorf("Error in resubmitting %s transaction %s: %v", transaction.meta.Action, transaction.current.Hash().Hex(), err)
+	}
+	return result, false
+}
+
+// replace signs and sends a replacement of the transaction with the same nonce and a higher gas price.
+// If cancel is true the replacement is a zero value transfer to the sender itself.
+func (m *Manager) replace(ctx context.Context, backend Backend, transaction *trackedTransaction, cancel bool) error {
+	replacement := m.bumpedTransaction(transaction.current, transaction.from, cancel)
+	signedTx, err := transaction.signer(transaction.from, replacement)
+	if err != nil {
+		return err
+	}
+	// The attempt counts even if sending fails so that a transaction which can never be sent is eventually abandoned
+	m.mu.Lock()
+	transaction.gasBumps++
+	transaction.sentAt = m.now()
+	m.mu.Unlock()
+	if err := backend.SendTransaction(ctx, signedTx); err != nil {
+		return err
+	}
+
+	reason := replacementReasonGasBump
+	if cancel {
+		reason = replacementReasonCancel
+	}
+	metrics.TransactionReplacementsMetric.WithLabelValues(transaction.meta.Action, reason).Inc()
+	logrus.Infof("Replaced %s transaction %s with %s to %s", transaction.meta.Action, transaction.current.Hash().Hex(), signedTx.Hash().Hex(), reason)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	transaction.current = signedTx
+	transaction.hashes = append(transaction.hashes, signedTx.Hash())
+	transaction.cancelling = cancel
+	return nil
+}
+
+// bumpedTransaction returns an unsigned copy of the transaction with its gas price increased by the bump percent.
+// The current gas price of the network is used instead if it is higher.
+func (m *Manager) bumpedTransaction(tx *types.Transaction, from common.Address, cancel bool) *types.Transaction {
+	to, value, data, gas := tx.To(), tx.Value(), tx.Data(), tx.Gas()
+	if cancel {
+		to, value, data, gas = &from, big.NewInt(0), nil, core.TransactionCancelGasLimit
+	}
+
+	if tx.Type() == types.DynamicFeeTxType {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: m.bump(tx.GasTipCap()),
+			GasFeeCap: m.bump(tx.GasFeeCap()),
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: m.bump(tx.GasPrice()),
+		Gas:      gas,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+func (m *Manager) bump(price *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(price, big.NewInt(100+m.config.GasBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	// A replacement must be strictly more expensive than the transaction it replaces
+	if bumped.Cmp(price) <= 0 {
+		bumped.Add(price, big.NewInt(1))
+	}
+	if m.gasPrice != nil {
+		if networkPrice := m.gasPrice(); networkPrice != nil && networkPrice.Cmp(bumped) > 0 {
+			bumped = new(big.Int).Set(networkPrice)
+		}
+	}
+	return bumped
+}
+
+func isInWindow(header *types.Header, window Window) bool {
+	epoch := uint32(header.Time / core.EpochLength)
+	state := int64((header.Time / core.StateLength) % core.NumberOfStates)
+	return epoch == window.Epoch && state == window.State
+}
+
+func sortedNonces(transactions map[uint64]*trackedTransaction) []uint64 {
+	nonces := make([]uint64, 0, len(transactions))
+	for nonce := range transactions {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces
+}
@@ -0,0 +1,365 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"razor/core"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+type testChain struct {
+	backend  *simulated.Backend
+	client   simulated.Client
+	from     common.Address
+	txnOpts  *bind.TransactOpts
+	gasPrice *big.Int
+}
+
+func newTestChain(t *testing.T) *testChain {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		from: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	client := backend.Client()
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, chainId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{
+		backend:  backend,
+		client:   client,
+		from:     from,
+		txnOpts:  txnOpts,
+		gasPrice: gasPrice,
+	}
+}
+
+// droppingBackend accepts every transaction without sending it, as if the transaction was dropped from the pool
+type droppingBackend struct {
+	Backend
+	err error
+}
+
+func (b droppingBackend) SendTransaction(context.Context, *types.Transaction) error {
+	return b.err
+}
+
+// signTransfer signs a transfer with the given transaction options, which also sends and tracks it if the options are wrapped
+func (c *testChain) signTransfer(t *testing.T, txnOpts *bind.TransactOpts, nonce uint64) *types.Transaction {
+	to := common.HexToAddress("0x911654feb423363fb771e04e18d1e7325ae10a91")
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: c.gasPrice,
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	})
+	signedTx, err := txnOpts.Signer(c.from, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signedTx
+}
+
+func (c *testChain) header(t *testing.T) *types.Header {
+	header, err := c.client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
+func windowOf(header *types.Header) *Window {
+	return &Window{
+		Epoch: uint32(header.Time / core.EpochLength),
+		State: int64((header.Time / core.StateLength) % core.NumberOfStates),
+	}
+}
+
+func newTestManager(chain *testChain, config Config) (*Manager, *time.Time) {
+	manager := NewManager(config, func() *big.Int { return chain.gasPrice })
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+	return manager, &now
+}
+
+func TestNextNonce(t *testing.T) {
+	chain := newTestChain(t)
+	manager, _ := newTestManager(chain, DefaultConfig())
+	txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "commit"})
+
+	if got := manager.NextNonce(chain.from, 0); got != 0 {
+		t.Errorf("NextNonce() without tracked transactions got = %d, want 0", got)
+	}
+
+	chain.signTransfer(t, txnOpts, 0)
+	chain.signTransfer(t, txnOpts, 1)
+	if got := manager.NextNonce(chain.from, 0); got != 2 {
+		t.Errorf("NextNonce() with pending transactions got = %d, want 2", got)
+	}
+	if got := manager.NextNonce(chain.from, 5); got != 5 {
+		t.Errorf("NextNonce() with chain nonce ahead of pending transactions got = %d, want 5", got)
+	}
+	if got := manager.NextNonce(common.HexToAddress("0x01"), 3); got != 3 {
+		t.Errorf("NextNonce() for another sender got = %d, want 3", got)
+	}
+
+	failingOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{err: errors.New("send error")}, Meta{Action: "commit"})
+	if _, err := failingOpts.Signer(chain.from, types.NewTx(&types.LegacyTx{Nonce: 2, GasPrice: chain.gasPrice, Gas: 21000, To: &chain.from})); err == nil {
+		t.Fatalf("Signer() with failing send got no error")
+	}
+	if got := manager.NextNonce(chain.from, 0); got != 2 {
+		t.Errorf("NextNonce() after failed send got = %d, want 2", got)
+	}
+	if got := manager.PendingCount(); got != 2 {
+		t.Errorf("PendingCount() after failed send got = %d, want 2", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Test 1: When transaction is mined", func(t *testing.T) {
+		chain := newTestChain(t)
+		manager, _ := newTestManager(chain, DefaultConfig())
+		txnOpts := manager.WrapTransactor(chain.txnOpts, chain.client, Meta{Action: "commit"})
+
+		tx := chain.signTransfer(t, txnOpts, 0)
+		if results := manager.Check(ctx, chain.client, chain.header(t)); len(results) != 0 {
+			t.Fatalf("Check() before mining got results = %+v, want none", results)
+		}
+
+		chain.backend.Commit()
+		results := manager.Check(ctx, chain.client, chain.header(t))
+		if len(results) != 1 || results[0].Outcome != OutcomeMined || results[0].Hash != tx.Hash() {
+			t.Fatalf("Check() got results = %+v, want mined %s", results, tx.Hash().Hex())
+		}
+		if manager.PendingCount() != 0 {
+			t.Errorf("PendingCount() got = %d, want 0", manager.PendingCount())
+		}
+	})
+
+	t.Run("Test 2: When stuck transaction is resubmitted with higher gas price", func(t *testing.T) {
+		chain := newTestChain(t)
+		manager, now := newTestManager(chain, DefaultConfig())
+		txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "reveal"})
+
+		// The transaction never reaches the pool, as if it was dropped
+		tx := chain.signTransfer(t, txnOpts, 0)
+		if results := manager.Check(ctx, chain.client, chain.header(t)); len(results) != 0 {
+			t.Fatalf("Check() before stuck timeout got results = %+v, want none", results)
+		}
+		if manager.ResolveHash(tx.Hash()) != tx.Hash() {
+			t.Fatalf("ResolveHash() changed before resubmission")
+		}
+
+		*now = now.Add(DefaultConfig().StuckTimeout)
+		if results := manager.Check(ctx, chain.client, chain.header(t)); len(results) != 0 {
+			t.Fatalf("Check() after stuck timeout got results = %+v, want none", results)
+		}
+		replacementHash := manager.ResolveHash(tx.Hash())
+		if replacementHash == tx.Hash() {
+			t.Fatalf("ResolveHash() got original hash, want replacement hash")
+		}
+
+		chain.backend.Commit()
+		results := manager.Check(ctx, chain.client, chain.header(t))
+		if len(results) != 1 || results[0].Outcome != OutcomeMined || results[0].Hash != replacementHash {
+			t.Fatalf("Check() got results = %+v, want mined %s", results, replacementHash.Hex())
+		}
+		minedTx, _, err := chain.client.TransactionByHash(ctx, replacementHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if minedTx.GasPrice().Cmp(tx.GasPrice()) <= 0 {
+			t.Errorf("Replacement gas price %s is not higher than original gas price %s", minedTx.GasPrice(), tx.GasPrice())
+		}
+		if minedTx.Nonce() != tx.Nonce() || minedTx.Value().Cmp(tx.Value()) != 0 {
+			t.Errorf("Replacement doesn't keep nonce and value of the original transaction")
+		}
+	})
+
+	t.Run("Test 3: When transaction is cancelled after its window closes", func(t *testing.T) {
+		chain := newTestChain(t)
+		manager, _ := newTestManager(chain, DefaultConfig())
+		window := windowOf(chain.header(t))
+		window.Epoch--
+		txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "commit", Window: window})
+
+		tx := chain.signTransfer(t, txnOpts, 0)
+		if results := manager.Check(ctx, chain.client, chain.header(t)); len(results) != 0 {
+			t.Fatalf("Check() got results = %+v, want none", results)
+		}
+
+		chain.backend.Commit()
+		results := manager.Check(ctx, chain.client, chain.header(t))
+		if len(results) != 1 || results[0].Outcome != OutcomeCancelled {
+			t.Fatalf("Check() got results = %+v, want cancelled", results)
+		}
+		cancelTx, _, err := chain.client.TransactionByHash(ctx, results[0].Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *cancelTx.To() != chain.from || cancelTx.Value().Sign() != 0 || cancelTx.Nonce() != tx.Nonce() {
+			t.Errorf("Cancellation is not a zero value transfer to the sender with the same nonce")
+		}
+	})
+
+	t.Run("Test 4: When transaction in its window is not cancelled", func(t *testing.T) {
+		chain := newTestChain(t)
+		manager, _ := newTestManager(chain, DefaultConfig())
+		header := chain.header(t)
+		txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "commit", Window: windowOf(header)})
+
+		tx := chain.signTransfer(t, txnOpts, 0)
+		if results := manager.Check(ctx, chain.client, header); len(results) != 0 {
+			t.Fatalf("Check() got results = %+v, want none", results)
+		}
+		if manager.ResolveHash(tx.Hash()) != tx.Hash() {
+			t.Errorf("Transaction in its window was replaced")
+		}
+	})
+
+	t.Run("Test 5: When nonce of transaction is used by another transaction", func(t *testing.T) {
+		chain := newTestChain(t)
+		manager, _ := newTestManager(chain, DefaultConfig())
+		txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "propose"})
+
+		chain.signTransfer(t, txnOpts, 0)
+		otherTx, err := chain.txnOpts.Signer(chain.from, types.NewTx(&types.LegacyTx{
+			Nonce:    0,
+			GasPrice: chain.gasPrice,
+			Gas:      21000,
+			To:       &chain.from,
+			Value:    big.NewInt(2),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := chain.client.SendTransaction(ctx, otherTx); err != nil {
+			t.Fatal(err)
+		}
+		chain.backend.Commit()
+
+		results := manager.Check(ctx, chain.client, chain.header(t))
+		if len(results) != 1 || results[0].Outcome != OutcomeDropped {
+			t.Fatalf("Check() got results = %+v, want dropped", results)
+		}
+	})
+
+	t.Run("Test 6: When transaction is pending after maximum gas bumps", func(t *testing.T) {
+		chain := newTestChain(t)
+		config := DefaultConfig()
+		config.MaxGasBumps = 0
+		manager, now := newTestManager(chain, config)
+		txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "reveal"})
+
+		chain.signTransfer(t, txnOpts, 0)
+		*now = now.Add(config.StuckTimeout)
+		results := manager.Check(ctx, chain.client, chain.header(t))
+		if len(results) != 1 || results[0].Outcome != OutcomeAbandoned {
+			t.Fatalf("Check() got results = %+v, want abandoned", results)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chain := newTestChain(t)
+	manager, now := newTestManager(chain, DefaultConfig())
+	txnOpts := manager.WrapTransactor(chain.txnOpts, droppingBackend{}, Meta{Action: "reveal"})
+
+	// The transaction never reaches the pool and is stuck while it is waited for
+	tx := chain.signTransfer(t, txnOpts, 0)
+	*now = now.Add(DefaultConfig().StuckTimeout)
+
+	reported := make(chan []Result, 1)
+	go manager.Watch(ctx, 10*time.Millisecond, func() (Backend, *types.Header, error) {
+		header, err := chain.client.HeaderByNumber(ctx, nil)
+		return chain.client, header, err
+	}, func(results []Result) { reported <- results })
+
+	// Waiting for the transaction to be mined as WaitForBlockCompletion does, without checking the pending transactions
+	var receipt *types.Receipt
+	for i := 0; i < 200 && receipt == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		chain.backend.Commit()
+		receipt, _ = chain.client.TransactionReceipt(ctx, manager.ResolveHash(tx.Hash()))
+	}
+	if receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("Expected the stuck transaction to be resubmitted and mined while it is waited for, got receipt %+v", receipt)
+	}
+	if receipt.TxHash == tx.Hash() {
+		t.Errorf("Expected the replacement of the stuck transaction to be mined, got the original transaction")
+	}
+
+	select {
+	case results := <-reported:
+		if len(results) != 1 || results[0].Outcome != OutcomeMined {
+			t.Errorf("Watch() reported results = %+v, want mined", results)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected Watch() to report the mined transaction")
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		name         string
+		price        *big.Int
+		networkPrice *big.Int
+		want         *big.Int
+	}{
+		{
+			name:         "Test 1: When bumped price is higher than network price",
+			price:        big.NewInt(100),
+			networkPrice: big.NewInt(100),
+			want:         big.NewInt(120),
+		},
+		{
+			name:         "Test 2: When network price is higher than bumped price",
+			price:        big.NewInt(100),
+			networkPrice: big.NewInt(200),
+			want:         big.NewInt(200),
+		},
+		{
+			name:         "Test 3: When price is too low to be bumped by percentage",
+			price:        big.NewInt(1),
+			networkPrice: big.NewInt(0),
+			want:         big.NewInt(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager(DefaultConfig(), func() *big.Int { return tt.networkPrice })
+			if got := manager.bump(tt.price); got.Cmp(tt.want) != 0 {
+				t.Errorf("bump() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
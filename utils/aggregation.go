@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"razor/core/types"
+	"sort"
+
+	"github.com/tidwall/gjson"
+)
+
+// Following are the values of the method field in the aggregation policy of a collection
+const (
+	AggregationMethodMedian      = "median"
+	AggregationMethodMean        = "mean"
+	AggregationMethodTrimmedMean = "trimmedMean"
+)
+
+// Following are the values of the outlierMethod field in the aggregation policy of a collection
+const (
+	// OutlierMethodMAD rejects results which deviate from the median by more than outlierThreshold times the median absolute deviation
+	OutlierMethodMAD = "mad"
+	// OutlierMethodPercent rejects results which deviate from the median by more than outlierThreshold percent of the median
+	OutlierMethodPercent = "percent"
+)
+
+//This function returns the aggregation policy of the collection from the assets.json file data, the policy is empty if the collection has none
+func GetAggregationPolicyFromJSONFile(collection string, jsonFileData string) (types.AggregationPolicy, error) {
+	var policy types.AggregationPolicy
+	policyJSONResult := gjson.Get(jsonFileData, "assets.collection."+collection+".aggregation")
+	if !policyJSONResult.Exists() {
+		return policy, nil
+	}
+	if err := json.Unmarshal([]byte(policyJSONResult.Raw), &policy); err != nil {
+		return types.AggregationPolicy{}, errors.New("Error in parsing aggregation policy of collection " + collection + ": " + err.Error())
+	}
+	if err := validateAggregationPolicy(policy); err != nil {
+		return types.AggregationPolicy{}, errors.New("Invalid aggregation policy of collection " + collection + ": " + err.Error())
+	}
+	return policy, nil
+}
+
+func validateAggregationPolicy(policy types.AggregationPolicy) error {
+	if policy.MinJobs < 0 {
+		return errors.New("minJobs cannot be negative")
+	}
+	switch policy.OutlierMethod {
+	case "":
+	case OutlierMethodMAD, OutlierMethodPercent:
+		if policy.OutlierThreshold <= 0 {
+			return errors.New("outlierThreshold should be greater than 0")
+		}
+	default:
+		return errors.New("invalid outlierMethod " + policy.OutlierMethod)
+	}
+	switch policy.Method {
+	case "", AggregationMethodMedian, AggregationMethodMean:
+	case AggregationMethodTrimmedMean:
+		if policy.TrimPercent < 0 || policy.TrimPercent >= 50 {
+			return errors.New("trimPercent should be between 0 and 50")
+		}
+	default:
+		return errors.New("invalid method " + policy.Method)
+	}
+	return nil
+}
+
+//This function rejects the outliers and aggregates the remaining job results as per the policy, it returns false if the remaining results don't meet the quorum of the policy
+func aggregateWithPolicy(data []*big.Int, weight []uint8, aggregationMethod uint32, policy types.AggregationPolicy) (*big.Int, bool, error) {
+	data, weight = rejectOutliers(data, weight, policy)
+	if !isQuorumMet(data, weight, policy) {
+		return nil, false, nil
+	}
+
+	var (
+		aggregatedValue *big.Int
+		err             error
+	)
+	switch policy.Method {
+	case AggregationMethodMedian:
+		aggregatedValue, err = performAggregation(data, weight, 1)
+	case AggregationMethodMean:
+		aggregatedValue, err = performAggregation(data, weight, 2)
+	case AggregationMethodTrimmedMean:
+		aggregatedValue, err = calculateWeightedTrimmedMean(data, weight, policy.TrimPercent)
+	default:
+		aggregatedValue, err = performAggregation(data, weight, aggregationMethod)
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	return aggregatedValue, true, nil
+}
+
+//This function checks if the number and total weight of the job results are at least the minimum of the policy
+func isQuorumMet(data []*big.Int, weight []uint8, policy types.AggregationPolicy) bool {
+	if len(data) == 0 || len(data) < policy.MinJobs {
+		return false
+	}
+	return CalculateSumOfUint8Array(weight) >= policy.MinWeight
+}
+
+//This function removes the job results which deviate too much from their weighted median as per the outlier method of the policy
+func rejectOutliers(data []*big.Int, weight []uint8, policy types.AggregationPolicy) ([]*big.Int, []uint8) {
+	if policy.OutlierMethod == "" || len(data) < 3 {
+		return data, weight
+	}
+	median := calculateWeightedMedian(data, weight, CalculateSumOfUint8Array(weight))
+	if median == nil {
+		return data, weight
+	}
+
+	deviations := make([]*big.Int, len(data))
+	for i, datum := range data {
+		deviations[i] = new(big.Int).Abs(new(big.Int).Sub(datum, median))
+	}
+
+	var maxDeviation *big.Float
+	switch policy.OutlierMethod {
+	case OutlierMethodMAD:
+		medianDeviation := calculateMedian(deviations)
+		// At least half of the results equal the median, any other result would be rejected however close it is
+		if medianDeviation.Sign() == 0 {
+			log.Debugf("Median absolute deviation of the job results is 0, not rejecting outliers")
+			return data, weight
+		}
+		maxDeviation = new(big.Float).Mul(medianDeviation, big.NewFloat(policy.OutlierThreshold))
+	case OutlierMethodPercent:
+		absoluteMedian := new(big.Float).SetInt(new(big.Int).Abs(median))
+		maxDeviation = new(big.Float).Quo(new(big.Float).Mul(absoluteMedian, big.NewFloat(policy.OutlierThreshold)), big.NewFloat(100))
+	default:
+		return data, weight
+	}
+
+	var (
+		acceptedData   []*big.Int
+		acceptedWeight []uint8
+	)
+	for i, datum := range data {
+		if new(big.Float).SetInt(deviations[i]).Cmp(maxDeviation) > 0 {
+			log.Warnf("Rejecting job result %s as an outlier, it deviates from median %s by more than %s", datum, median, maxDeviation.Text('f', 0))
+			continue
+		}
+		acceptedData = append(acceptedData, datum)
+		acceptedWeight = append(acceptedWeight, weight[i])
+	}
+	return acceptedData, acceptedWeight
+}
+
+//This function returns the unweighted median of the values, the mean of the two middle values is taken if the number of values is even
+func calculateMedian(values []*big.Int) *big.Float {
+	sortedValues := make([]*big.Int, len(values))
+	copy(sortedValues, values)
+	sort.Slice(sortedValues, func(i, j int) bool {
+		return sortedValues[i].Cmp(sortedValues[j]) < 0
+	})
+	middle := len(sortedValues) / 2
+	if len(sortedValues)%2 == 1 {
+		return new(big.Float).SetInt(sortedValues[middle])
+	}
+	sum := new(big.Float).SetInt(new(big.Int).Add(sortedValues[middle-1], sortedValues[middle]))
+	return sum.Quo(sum, big.NewFloat(2))
+}
+
+//This function drops trimPercent of the job results from each end after sorting them and returns the weighted mean of the rest
+func calculateWeightedTrimmedMean(data []*big.Int, weight []uint8, trimPercent float64) (*big.Int, error) {
+	if len(data) == 0 {
+		return nil, errors.New("aggregation cannot be performed for nil data")
+	}
+	indices := make([]int, len(data))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return data[indices[i]].Cmp(data[indices[j]]) < 0
+	})
+
+	trimCount := int(float64(len(data)) * trimPercent / 100)
+	var (
+		trimmedData   []*big.Int
+		trimmedWeight []uint8
+	)
+	for _, index := range indices[trimCount : len(indices)-trimCount] {
+		trimmedData = append(trimmedData, data[index])
+		trimmedWeight = append(trimmedWeight, weight[index])
+	}
+
+	totalWeight := CalculateSumOfUint8Array(trimmedWeight)
+	if totalWeight == 0 {
+		return nil, errors.New("total weight of trimmed job results is 0")
+	}
+	weightedSum := CalculateWeightedSum(trimmedData, trimmedWeight)
+	return weightedSum.Div(weightedSum, big.NewInt(int64(totalWeight))), nil
+}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"math/big"
+	"razor/core/types"
+	"reflect"
+	"testing"
+)
+
+func bigIntArray(values ...int64) []*big.Int {
+	var result []*big.Int
+	for _, value := range values {
+		result = append(result, big.NewInt(value))
+	}
+	return result
+}
+
+func TestGetAggregationPolicyFromJSONFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonData string
+		want     types.AggregationPolicy
+		wantErr  bool
+	}{
+		{
+			name:     "Test 1: When collection has no aggregation policy",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"power":2}}}}`,
+			want:     types.AggregationPolicy{},
+		},
+		{
+			name:     "Test 2: When collection has an aggregation policy",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"aggregation":{"minJobs":3,"minWeight":5,"outlierMethod":"mad","outlierThreshold":3,"method":"trimmedMean","trimPercent":20}}}}}`,
+			want:     types.AggregationPolicy{MinJobs: 3, MinWeight: 5, OutlierMethod: "mad", OutlierThreshold: 3, Method: "trimmedMean", TrimPercent: 20},
+		},
+		{
+			name:     "Test 3: When outlier method is invalid",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"aggregation":{"outlierMethod":"zscore","outlierThreshold":3}}}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Test 4: When outlier threshold is missing",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"aggregation":{"outlierMethod":"percent"}}}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Test 5: When trim percent is out of range",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"aggregation":{"method":"trimmedMean","trimPercent":50}}}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Test 6: When aggregation policy has wrong field types",
+			jsonData: `{"assets":{"collection":{"ethCollection":{"aggregation":{"minJobs":"three"}}}}}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAggregationPolicyFromJSONFile("ethCollection", tt.jsonData)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAggregationPolicyFromJSONFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAggregationPolicyFromJSONFile() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateWithPolicy(t *testing.T) {
+	type args struct {
+		data              []*big.Int
+		weight            []uint8
+		aggregationMethod uint32
+		policy            types.AggregationPolicy
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          *big.Int
+		wantQuorumMet bool
+		wantErr       bool
+	}{
+		{
+			name: "Test 1: When there is no policy and aggregation method is mean",
+			args: args{
+				data:              bigIntArray(100, 200, 300),
+				weight:            []uint8{1, 1, 1},
+				aggregationMethod: 2,
+			},
+			want:          big.NewInt(200),
+			wantQuorumMet: true,
+		},
+		{
+			name: "Test 2: When there are no job results",
+			args: args{
+				aggregationMethod: 1,
+			},
+			wantQuorumMet: false,
+		},
+		{
+			name: "Test 3: When number of job results is less than minJobs",
+			args: args{
+				data:              bigIntArray(100, 101),
+				weight:            []uint8{1, 1},
+				aggregationMethod: 1,
+				policy:            types.AggregationPolicy{MinJobs: 3},
+			},
+			wantQuorumMet: false,
+		},
+		{
+			name: "Test 4: When weight of job results is less than minWeight",
+			args: args{
+				data:              bigIntArray(100, 101, 102),
+				weight:            []uint8{1, 1, 1},
+				aggregationMethod: 1,
+				policy:            types.AggregationPolicy{MinWeight: 4},
+			},
+			wantQuorumMet: false,
+		},
+		{
+			name: "Test 5: When outlier is rejected with MAD",
+			args: args{
+				data:              bigIntArray(100, 102, 98, 101, 1000),
+				weight:            []uint8{1, 1, 1, 1, 1},
+				aggregationMethod: 2,
+				policy:            types.AggregationPolicy{OutlierMethod: OutlierMethodMAD, OutlierThreshold: 3},
+			},
+			want:          big.NewInt(100),
+			wantQuorumMet: true,
+		},
+		{
+			name: "Test 6: When outlier is rejected with percent deviation",
+			args: args{
+				data:              bigIntArray(1000, 1010, 990, 1500),
+				weight:            []uint8{1, 1, 1, 1},
+				aggregationMethod: 2,
+				policy:            types.AggregationPolicy{OutlierMethod: OutlierMethodPercent, OutlierThreshold: 5},
+			},
+			want:          big.NewInt(1000),
+			wantQuorumMet: true,
+		},
+		{
+			name: "Test 7: When rejecting outliers leaves less than minJobs results",
+			args: args{
+				data:              bigIntArray(1000, 1010, 5000),
+				weight:            []uint8{1, 1, 1},
+				aggregationMethod: 2,
+				policy:            types.AggregationPolicy{MinJobs: 3, OutlierMethod: OutlierMethodPercent, OutlierThreshold: 5},
+			},
+			wantQuorumMet: false,
+		},
+		{
+			name: "Test 8: When policy method is trimmed mean",
+			args: args{
+				data:              bigIntArray(1, 100, 102, 104, 10000),
+				weight:            []uint8{1, 1, 1, 1, 1},
+				aggregationMethod: 1,
+				policy:            types.AggregationPolicy{Method: AggregationMethodTrimmedMean, TrimPercent: 20},
+			},
+			want:          big.NewInt(102),
+			wantQuorumMet: true,
+		},
+		{
+			name: "Test 9: When policy method overrides aggregation method of collection",
+			args: args{
+				data:              bigIntArray(100, 200, 600),
+				weight:            []uint8{1, 1, 1},
+				aggregationMethod: 2,
+				policy:            types.AggregationPolicy{Method: AggregationMethodMedian},
+			},
+			want:          big.NewInt(200),
+			wantQuorumMet: true,
+		},
+		{
+			name: "Test 10: When aggregation method is invalid",
+			args: args{
+				data:              bigIntArray(100),
+				weight:            []uint8{1},
+				aggregationMethod: 3,
+			},
+			wantQuorumMet: true,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotQuorumMet, err := aggregateWithPolicy(tt.args.data, tt.args.weight, tt.args.aggregationMethod, tt.args.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("aggregateWithPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotQuorumMet != tt.wantQuorumMet {
+				t.Errorf("aggregateWithPolicy() quorum met = %v, want %v", gotQuorumMet, tt.wantQuorumMet)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aggregateWithPolicy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectOutliers(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []*big.Int
+		weight     []uint8
+		policy     types.AggregationPolicy
+		wantData   []*big.Int
+		wantWeight []uint8
+	}{
+		{
+			name:       "Test 1: When there is no outlier method",
+			data:       bigIntArray(1, 1000, 1000000),
+			weight:     []uint8{1, 2, 3},
+			wantData:   bigIntArray(1, 1000, 1000000),
+			wantWeight: []uint8{1, 2, 3},
+		},
+		{
+			name:       "Test 2: When there are less than 3 results",
+			data:       bigIntArray(1, 1000),
+			weight:     []uint8{1, 2},
+			policy:     types.AggregationPolicy{OutlierMethod: OutlierMethodPercent, OutlierThreshold: 1},
+			wantData:   bigIntArray(1, 1000),
+			wantWeight: []uint8{1, 2},
+		},
+		{
+			name:       "Test 3: When weights of outliers are removed along with them",
+			data:       bigIntArray(500, 1000, 1001, 999),
+			weight:     []uint8{4, 1, 2, 3},
+			policy:     types.AggregationPolicy{OutlierMethod: OutlierMethodPercent, OutlierThreshold: 10},
+			wantData:   bigIntArray(1000, 1001, 999),
+			wantWeight: []uint8{1, 2, 3},
+		},
+		{
+			name:       "Test 4: When median absolute deviation is 0",
+			data:       bigIntArray(100, 100, 100, 101),
+			weight:     []uint8{1, 1, 1, 1},
+			policy:     types.AggregationPolicy{OutlierMethod: OutlierMethodMAD, OutlierThreshold: 3},
+			wantData:   bigIntArray(100, 100, 100, 101),
+			wantWeight: []uint8{1, 1, 1, 1},
+		},
+		{
+			name:       "Test 5: When results deviate by more than the threshold times the median absolute deviation",
+			data:       bigIntArray(100, 102, 98, 101, 150),
+			weight:     []uint8{1, 2, 3, 4, 5},
+			policy:     types.AggregationPolicy{OutlierMethod: OutlierMethodMAD, OutlierThreshold: 3},
+			wantData:   bigIntArray(100, 102, 98, 101),
+			wantWeight: []uint8{1, 2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotData, gotWeight := rejectOutliers(tt.data, tt.weight, tt.policy)
+			if !reflect.DeepEqual(gotData, tt.wantData) {
+				t.Errorf("rejectOutliers() got data = %v, want %v", gotData, tt.wantData)
+			}
+			if !reflect.DeepEqual(gotWeight, tt.wantWeight) {
+				t.Errorf("rejectOutliers() got weight = %v, want %v", gotWeight, tt.wantWeight)
+			}
+		})
+	}
+}
@@ -18,27 +18,9 @@ import (
 	"github.com/gocolly/colly"
 )
 
+//This function fetches the data of the data source without a selector, see GetDataFromSource
 func GetDataFromAPI(commitParams *types.CommitParams, dataSourceURLStruct types.DataSourceURL) ([]byte, error) {
-	cacheKey, err := generateCacheKey(dataSourceURLStruct.URL, dataSourceURLStruct.Body)
-	if err != nil {
-		log.Errorf("Error in generating cache key for API %s: %v", dataSourceURLStruct.URL, err)
-		return nil, err
-	}
-
-	cachedData, found := commitParams.LocalCache.Read(cacheKey)
-	if found {
-		log.Debugf("Getting Data for URL %s from local cache...", dataSourceURLStruct.URL)
-		return cachedData, nil
-	}
-
-	response, err := makeAPIRequest(commitParams.HttpClient, dataSourceURLStruct)
-	if err != nil {
-		return nil, err
-	}
-
-	// Storing the API results data into cache
-	commitParams.LocalCache.Update(response, cacheKey, time.Now().Add(time.Second*time.Duration(core.StateLength)).Unix())
-	return response, nil
+	return GetDataFromSource(commitParams, dataSourceURLStruct, "")
 }
 
 func makeAPIRequest(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL) ([]byte, error) {
@@ -111,16 +93,28 @@ func GetDataFromJSON(jsonObject map[string]interface{}, selector string) (interf
 }
 
 func GetDataFromXHTML(dataSourceURLStruct types.DataSourceURL, selector string) (string, error) {
+	priceData, _, err := getDataFromXHTML(dataSourceURLStruct, selector)
+	return priceData, err
+}
+
+//This function returns the value at the selector along with the raw response of the XHTML data source
+func getDataFromXHTML(dataSourceURLStruct types.DataSourceURL, selector string) (string, []byte, error) {
 	c := colly.NewCollector()
-	var priceData string
+	var (
+		priceData string
+		response  []byte
+	)
+	c.OnResponse(func(r *colly.Response) {
+		response = r.Body
+	})
 	c.OnXML(selector, func(e *colly.XMLElement) {
 		priceData = e.Text
 	})
 	err := c.Visit(dataSourceURLStruct.URL)
 	if err != nil {
-		return "", err
+		return "", response, err
 	}
-	return priceData, nil
+	return priceData, response, nil
 }
 
 func processHeaderValue(value string, re *regexp.Regexp) string {
@@ -9,7 +9,7 @@ import (
 	"razor/cache"
 	"razor/core"
 	"razor/core/types"
-	"razor/path"
+	"razor/metrics"
 	"razor/pkg/bindings"
 	"razor/rpc"
 	"regexp"
@@ -126,31 +126,27 @@ func (*UtilsStruct) GetAggregatedDataOfCollection(rpcParameters rpc.RPCParameter
 func (*UtilsStruct) Aggregate(rpcParameters rpc.RPCParameters, previousEpoch uint32, collection bindings.StructsCollection, commitParams *types.CommitParams) (*big.Int, error) {
 	var jobs []bindings.StructsJob
 	var overriddenJobIds []uint16
+	var aggregationPolicy types.AggregationPolicy
 
 	// Checks if assets.JSON file exists
-	assetsFilePath, err := path.PathUtilsInterface.GetJobFilePath()
+	assetsFile, err := readAssetsFile()
 	if err != nil {
 		return nil, err
 	}
-	if _, err := path.OSUtilsInterface.Stat(assetsFilePath); !errors.Is(err, os.ErrNotExist) {
+	if assetsFile.IsPresent {
 		log.Debugf("assets.json file is present, checking jobs for collection Id: %v...", collection.Id)
-		jsonFile, err := path.OSUtilsInterface.Open(assetsFilePath)
-		if err != nil {
-			return nil, err
-		}
-		defer jsonFile.Close()
-
-		data, err := IOInterface.ReadAll(jsonFile)
-		if err != nil {
-			return nil, err
-		}
-		dataString := string(data)
+		dataString := assetsFile.Data
 
 		powerFromJSONFile := gjson.Get(dataString, "assets.collection."+collection.Name+".power").Int()
 		if powerFromJSONFile != 0 {
 			collection.Power = int8(powerFromJSONFile)
 		}
 
+		aggregationPolicy, err = GetAggregationPolicyFromJSONFile(collection.Name, dataString)
+		if err != nil {
+			return nil, err
+		}
+
 		// Overriding the jobs from contracts with official jobs present in asset.go
 		overrideJobs, overriddenJobIdsFromJSONfile := UtilsInterface.HandleOfficialJobsFromJSONFile(collection, dataString, commitParams)
 		jobs = append(jobs, overrideJobs...)
@@ -180,14 +176,19 @@ func (*UtilsStruct) Aggregate(rpcParameters rpc.RPCParameters, previousEpoch uin
 		return nil, errors.New("no jobs present in the collection")
 	}
 	dataToCommit, weight := UtilsInterface.GetDataToCommitFromJobs(jobs, commitParams)
-	if len(dataToCommit) == 0 {
+	aggregatedValue, isQuorumMet, err := aggregateWithPolicy(dataToCommit, weight, collection.AggregationMethod, aggregationPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if !isQuorumMet {
+		log.Warnf("Collection Id %d: %d of %d jobs succeeded which doesn't meet the quorum, using value of previous epoch", collection.Id, len(dataToCommit), len(jobs))
 		prevCommitmentData, err := UtilsInterface.FetchPreviousValue(rpcParameters, previousEpoch, collection.Id)
 		if err != nil {
 			return nil, err
 		}
 		return prevCommitmentData, nil
 	}
-	return performAggregation(dataToCommit, weight, collection.AggregationMethod)
+	return aggregatedValue, nil
 }
 
 func (*UtilsStruct) GetActiveJob(rpcParameters rpc.RPCParameters, jobId uint16) (bindings.StructsJob, error) {
@@ -243,10 +244,19 @@ func processJobConcurrently(wg *sync.WaitGroup, mu *sync.Mutex, data *[]*big.Int
 }
 
 func (*UtilsStruct) GetDataToCommitFromJob(job bindings.StructsJob, commitParams *types.CommitParams) (*big.Int, error) {
+	start := time.Now()
+	data, err := getDataToCommitFromJob(job, commitParams)
+	metrics.ObserveJobFetch(job.Id, job.Name, time.Since(start).Seconds(), err)
+	return data, err
+}
+
+func getDataToCommitFromJob(job bindings.StructsJob, commitParams *types.CommitParams) (data *big.Int, err error) {
 	var (
 		response            []byte
 		apiErr              error
 		dataSourceURLStruct types.DataSourceURL
+		// templateURL is the URL of the data source before API keys are substituted
+		templateURL = job.Url
 	)
 	log.Debugf("Job ID: %d, Getting the data to commit for job %s", job.Id, job.Name)
 	if isJSONCompatible(job.Url) {
@@ -259,6 +269,7 @@ func (*UtilsStruct) GetDataToCommitFromJob(job bindings.StructsJob, commitParams
 			return nil, err
 		}
 		log.Infof("Job ID: %d, URL Struct: %+v", job.Id, dataSourceURLStruct)
+		templateURL = dataSourceURLStruct.URL
 	} else {
 		log.Debugf("Job ID: %d, Job URL passed is a direct URL: %s", job.Id, job.Url)
 		re := regexp.MustCompile(core.APIKeyRegex)
@@ -273,11 +284,16 @@ func (*UtilsStruct) GetDataToCommitFromJob(job bindings.StructsJob, commitParams
 			Header: nil,
 		}
 	}
+	jobResponse := newJobResponse(job, templateURL, dataSourceURLStruct)
+	defer func() {
+		finishJobResponse(jobResponse, data, err)
+	}()
+
 	// Fetch data from API with retry mechanism
 	var parsedData interface{}
 	if job.SelectorType == 0 {
 		start := time.Now()
-		response, apiErr = GetDataFromAPI(commitParams, dataSourceURLStruct)
+		response, apiErr = fetchJobResponse(commitParams, dataSourceURLStruct, job.Selector, jobResponse)
 		if apiErr != nil {
 			log.Errorf("Job ID: %d, Error in fetching data from API %s: %v", job.Id, job.Url, apiErr)
 			return nil, apiErr
@@ -298,7 +314,7 @@ func (*UtilsStruct) GetDataToCommitFromJob(job bindings.StructsJob, commitParams
 		}
 	} else {
 		//TODO: Add retry here.
-		dataPoint, err := GetDataFromXHTML(dataSourceURLStruct, job.Selector)
+		dataPoint, err := fetchXHTMLJobValue(dataSourceURLStruct, job.Selector, jobResponse)
 		if err != nil {
 			log.Errorf("Job ID: %d, Error in fetching value from parsed XHTML: %v", job.Id, err)
 			return nil, err
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"razor/path"
+	"sync/atomic"
+)
+
+// AssetsFile is the content of the assets.json file, IsPresent is false when there is no assets.json file
+type AssetsFile struct {
+	IsPresent bool
+	Data      string
+}
+
+// AssetsSnapshot is the assets.json file the collections are aggregated with. The vote process sets it when it starts
+// and swaps it when a changed assets.json is valid, so that a file which is being edited is never used.
+// When it isn't set, the assets.json file is read on every aggregation.
+var AssetsSnapshot atomic.Pointer[AssetsFile]
+
+//This function returns the assets.json file the collections are aggregated with, from the snapshot if it is set or else from the .razor directory
+func readAssetsFile() (AssetsFile, error) {
+	if snapshot := AssetsSnapshot.Load(); snapshot != nil {
+		return *snapshot, nil
+	}
+	return ReadAssetsFile()
+}
+
+//This function reads the assets.json file from the .razor directory
+func ReadAssetsFile() (AssetsFile, error) {
+	assetsFilePath, err := path.PathUtilsInterface.GetJobFilePath()
+	if err != nil {
+		return AssetsFile{}, err
+	}
+	if _, err := path.OSUtilsInterface.Stat(assetsFilePath); errors.Is(err, os.ErrNotExist) {
+		return AssetsFile{}, nil
+	}
+	jsonFile, err := path.OSUtilsInterface.Open(assetsFilePath)
+	if err != nil {
+		return AssetsFile{}, err
+	}
+	defer jsonFile.Close()
+
+	data, err := IOInterface.ReadAll(jsonFile)
+	if err != nil {
+		return AssetsFile{}, err
+	}
+	return AssetsFile{IsPresent: true, Data: string(data)}, nil
+}
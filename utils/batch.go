@@ -22,7 +22,11 @@ func (c ClientStruct) BatchCall(rpcParameters rpc.RPCParameters, contractABI *ab
 		return nil, err
 	}
 
-	err = performBatchCallWithRetry(rpcParameters, calls)
+	if quorumBatchReads[methodName] && rpcParameters.RPCManager.IsQuorumEnabled() {
+		err = performQuorumBatchCallWithRetry(rpcParameters, methodName, calls)
+	} else {
+		err = performBatchCallWithRetry(rpcParameters, calls)
+	}
 	if err != nil {
 		log.Errorf("Error in performing batch call: %v", err)
 		return nil, err
@@ -10,6 +10,7 @@ import (
 	"razor/core"
 	"razor/core/types"
 	"razor/logger"
+	"razor/metrics"
 	"razor/rpc"
 	"time"
 
@@ -65,10 +66,22 @@ func (*UtilsStruct) CheckTransactionReceipt(rpcParameters rpc.RPCParameters, _tx
 	}
 
 	txnReceipt := returnedValues[0].Interface().(*Types.Receipt)
+	metrics.TransactionGasUsedMetric.Observe(float64(txnReceipt.GasUsed))
 	return int(txnReceipt.Status)
 }
 
 func (*UtilsStruct) WaitForBlockCompletion(rpcParameters rpc.RPCParameters, hashToRead string) error {
+	if DryRun {
+		log.Infof("Dry run: Transaction %s was not broadcast, not waiting for it to be mined", hashToRead)
+		return ErrDryRunTransaction
+	}
+	start := time.Now()
+	err := waitForBlockCompletion(rpcParameters, hashToRead)
+	metrics.ObserveTransactionConfirmation(time.Since(start).Seconds(), err)
+	return err
+}
+
+func waitForBlockCompletion(rpcParameters rpc.RPCParameters, hashToRead string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), core.BlockCompletionTimeout*time.Second)
 	defer cancel()
 
@@ -79,6 +92,9 @@ func (*UtilsStruct) WaitForBlockCompletion(rpcParameters rpc.RPCParameters, hash
 			return errors.New("timeout exceeded for transaction mining")
 		default:
 			log.Debug("Checking if transaction is mined....")
+			if TransactionManager != nil {
+				hashToRead = TransactionManager.ResolveHash(common.HexToHash(hashToRead)).Hex()
+			}
 			transactionStatus := UtilsInterface.CheckTransactionReceipt(rpcParameters, hashToRead)
 
 			if transactionStatus == 0 {
@@ -374,10 +390,10 @@ func (*FileStruct) ReadFromDisputeJsonFile(filePath string) (types.DisputeFileDa
 }
 
 func (*UtilsStruct) CheckPassword(account types.Account) error {
-	_, err := account.AccountManager.GetPrivateKey(account.Address, account.Password)
+	err := account.AccountManager.VerifyAccount(account.Address, account.Password)
 	if err != nil {
 		log.Info("Kindly check your password!")
-		log.Error("CheckPassword: Error in getting private key: ", err)
+		log.Error("CheckPassword: Error in verifying account: ", err)
 		return err
 	}
 	return nil
@@ -394,3 +410,12 @@ func (*UtilsStruct) AccountManagerForKeystore() (types.AccountManagerInterface,
 	accountManager := accounts.NewAccountManager(keystorePath)
 	return accountManager, nil
 }
+
+func (*UtilsStruct) AccountManagerForRemoteSigner(signerURL string) (types.AccountManagerInterface, error) {
+	accountManager, err := accounts.NewRemoteSigner(signerURL)
+	if err != nil {
+		log.Error("AccountManagerForRemoteSigner: Error in connecting to remote signer: ", err)
+		return nil, err
+	}
+	return accountManager, nil
+}
@@ -544,6 +544,7 @@ func TestWaitForBlockCompletion(t *testing.T) {
 
 	type args struct {
 		transactionStatus int
+		dryRun            bool
 	}
 	tests := []struct {
 		name string
@@ -571,11 +572,21 @@ func TestWaitForBlockCompletion(t *testing.T) {
 			},
 			want: errors.New("maximum attempts failed for transaction mining"),
 		},
+		{
+			name: "Test 4: When transaction is not broadcast in dry run mode",
+			args: args{
+				transactionStatus: 0,
+				dryRun:            true,
+			},
+			want: ErrDryRunTransaction,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			utilsMock := new(mocks.Utils)
 			timeMock := new(mocks.TimeUtils)
+			DryRun = tt.args.dryRun
+			defer func() { DryRun = false }()
 
 			optionsPackageStruct := OptionsPackageStruct{
 				UtilsInterface: utilsMock,
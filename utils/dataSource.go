@@ -0,0 +1,353 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"razor/core"
+	"razor/core/types"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gorilla/websocket"
+)
+
+// Following are the types of data sources which can be used in the type field of a job URL struct
+const (
+	DataSourceTypeGET       = "GET"
+	DataSourceTypePOST      = "POST"
+	DataSourceTypeGraphQL   = "GRAPHQL"
+	DataSourceTypeWebSocket = "WEBSOCKET"
+	DataSourceTypeEthCall   = "ETH_CALL"
+)
+
+// DataSourceProvider fetches the response of a job data source as JSON, which is then parsed with the selector of the job.
+// The selector is passed for data sources which need it to pick the right response, like websocket streams.
+type DataSourceProvider interface {
+	Fetch(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error)
+}
+
+var (
+	dataSourceProvidersMu sync.RWMutex
+	dataSourceProviders   = map[string]DataSourceProvider{
+		DataSourceTypeGET:       HTTPDataSource{},
+		DataSourceTypePOST:      HTTPDataSource{},
+		DataSourceTypeGraphQL:   GraphQLDataSource{},
+		DataSourceTypeWebSocket: WebSocketDataSource{},
+		DataSourceTypeEthCall:   EthCallDataSource{},
+	}
+)
+
+//This function registers the provider for the given data source type, replacing the existing provider of the type if any
+func RegisterDataSourceProvider(sourceType string, provider DataSourceProvider) {
+	dataSourceProvidersMu.Lock()
+	defer dataSourceProvidersMu.Unlock()
+	dataSourceProviders[strings.ToUpper(sourceType)] = provider
+}
+
+//This function returns the provider registered for the given data source type
+func GetDataSourceProvider(sourceType string) (DataSourceProvider, error) {
+	dataSourceProvidersMu.RLock()
+	defer dataSourceProvidersMu.RUnlock()
+	provider, ok := dataSourceProviders[strings.ToUpper(sourceType)]
+	if !ok {
+		return nil, errors.New("invalid request type")
+	}
+	return provider, nil
+}
+
+//This function fetches the data of the data source with the provider registered for its type and stores it in the local cache for the current state
+func GetDataFromSource(commitParams *types.CommitParams, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	cacheKey, err := getDataSourceCacheKey(dataSourceURLStruct, selector)
+	if err != nil {
+		log.Errorf("Error in generating cache key for API %s: %v", dataSourceURLStruct.URL, err)
+		return nil, err
+	}
+
+	cachedData, found := commitParams.LocalCache.Read(cacheKey)
+	if found {
+		log.Debugf("Getting Data for URL %s from local cache...", dataSourceURLStruct.URL)
+		return cachedData, nil
+	}
+
+	provider, err := GetDataSourceProvider(dataSourceURLStruct.Type)
+	if err != nil {
+		return nil, err
+	}
+	response, err := provider.Fetch(commitParams.HttpClient, dataSourceURLStruct, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storing the API results data into cache
+	commitParams.LocalCache.Update(response, cacheKey, time.Now().Add(time.Second*time.Duration(core.StateLength)).Unix())
+	return response, nil
+}
+
+//This function returns the key of the data of the data source in the local cache. Data sources of different types can share
+//a URL and body, so the type is a part of the key, and so is the selector of a websocket stream, whose response depends on it.
+func getDataSourceCacheKey(dataSourceURLStruct types.DataSourceURL, selector string) (string, error) {
+	requestKey, err := generateCacheKey(dataSourceURLStruct.URL, dataSourceURLStruct.Body)
+	if err != nil {
+		return "", err
+	}
+	sourceType := strings.ToUpper(dataSourceURLStruct.Type)
+	cacheKey := sourceType + ":" + requestKey
+	if sourceType == DataSourceTypeWebSocket {
+		cacheKey += ":" + selector
+	}
+	return cacheKey, nil
+}
+
+// HTTPDataSource fetches the data of GET and POST requests
+type HTTPDataSource struct{}
+
+//This function makes the GET or POST request of the data source
+func (HTTPDataSource) Fetch(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	return makeAPIRequest(httpClient, dataSourceURLStruct)
+}
+
+// GraphQLDataSource fetches the data of a GraphQL query. The body of the data source contains the query and optionally
+// its variables, and the selector is applied on the whole response, e.g. data.pool.token0Price
+type GraphQLDataSource struct{}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+//This function posts the GraphQL query of the data source and returns the response if it has no errors
+func (GraphQLDataSource) Fetch(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	if _, ok := dataSourceURLStruct.Body["query"].(string); !ok {
+		return nil, errors.New("graphql data source requires a query in body")
+	}
+	header := map[string]string{"content-type": "application/json"}
+	for key, value := range dataSourceURLStruct.Header {
+		header[key] = value
+	}
+	response, err := makeAPIRequest(httpClient, types.DataSourceURL{
+		Type:   DataSourceTypePOST,
+		URL:    dataSourceURLStruct.URL,
+		Body:   dataSourceURLStruct.Body,
+		Header: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedResponse graphQLResponse
+	if err := json.Unmarshal(response, &parsedResponse); err != nil {
+		return nil, err
+	}
+	if len(parsedResponse.Errors) > 0 {
+		var messages []string
+		for _, graphQLError := range parsedResponse.Errors {
+			messages = append(messages, graphQLError.Message)
+		}
+		return nil, errors.New("graphql query failed: " + strings.Join(messages, "; "))
+	}
+	return response, nil
+}
+
+// WebSocketDataSource takes a snapshot of a websocket stream. The body of the data source, if any, is sent as the
+// subscription message and the first message in which the selector of the job is found is returned.
+type WebSocketDataSource struct{}
+
+//This function subscribes to the websocket data source and waits for a message containing the data of the job
+func (WebSocketDataSource) Fetch(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	var response []byte
+	err := retry.Do(
+		func() error {
+			message, err := readWebSocketSnapshot(dataSourceURLStruct, selector)
+			if err != nil {
+				log.Errorf("Error in reading websocket data source %s: %v", dataSourceURLStruct.URL, err)
+				return err
+			}
+			response = message
+			return nil
+		}, retry.Attempts(core.ProcessRequestRetryAttempts), retry.Delay(time.Second*time.Duration(core.ProcessRequestRetryDelay)))
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func readWebSocketSnapshot(dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(core.WebSocketDataSourceTimeout)*time.Second)
+	defer cancel()
+
+	re := regexp.MustCompile(core.APIKeyRegex)
+	header := http.Header{}
+	for key, value := range dataSourceURLStruct.Header {
+		header.Add(key, processHeaderValue(value, re))
+	}
+	connection, _, err := websocket.DefaultDialer.DialContext(ctx, processHeaderValue(dataSourceURLStruct.URL, re), header)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := connection.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if len(dataSourceURLStruct.Body) > 0 {
+		if err := connection.WriteJSON(dataSourceURLStruct.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		_, message, err := connection.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if selector == "" {
+			return message, nil
+		}
+		var parsedJSON interface{}
+		if err := json.Unmarshal(message, &parsedJSON); err != nil {
+			log.Debugf("Skipping websocket message which is not JSON: %s", message)
+			continue
+		}
+		if _, err := parseJSONData(parsedJSON, selector); err != nil {
+			log.Debugf("Skipping websocket message without selector %s: %s", selector, message)
+			continue
+		}
+		return message, nil
+	}
+}
+
+// EthCallDataSource calls a view function of a contract with eth_call. The url of the data source is the RPC endpoint
+// and the body describes the call. The response is a JSON object with the decoded outputs, where uint and int values
+// are decimal strings, or with the raw return data in the result field if no outputs are given.
+type EthCallDataSource struct{}
+
+// EthCallRequest is the body of an eth_call data source. Either data with the full calldata or signature of a function
+// without arguments, e.g. getReserves(), has to be given.
+type EthCallRequest struct {
+	To        string              `json:"to"`
+	Data      string              `json:"data"`
+	Signature string              `json:"signature"`
+	Outputs   []EthCallOutputType `json:"outputs"`
+	Block     *uint64             `json:"block"`
+}
+
+// EthCallOutputType is an output of the called function, unnamed outputs are returned as output0, output1 and so on
+type EthCallOutputType struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+//This function calls the contract of the data source and returns its decoded outputs as JSON
+func (EthCallDataSource) Fetch(httpClient *http.Client, dataSourceURLStruct types.DataSourceURL, selector string) ([]byte, error) {
+	request, err := parseEthCallRequest(dataSourceURLStruct.Body)
+	if err != nil {
+		return nil, err
+	}
+	callData, err := request.callData()
+	if err != nil {
+		return nil, err
+	}
+	contractAddress := common.HexToAddress(request.To)
+	var blockNumber *big.Int
+	if request.Block != nil {
+		blockNumber = new(big.Int).SetUint64(*request.Block)
+	}
+
+	var returnData []byte
+	err = retry.Do(
+		func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(core.EthCallDataSourceTimeout)*time.Second)
+			defer cancel()
+			client, err := ethclient.DialContext(ctx, processHeaderValue(dataSourceURLStruct.URL, regexp.MustCompile(core.APIKeyRegex)))
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			returnData, err = client.CallContract(ctx, ethereum.CallMsg{To: &contractAddress, Data: callData}, blockNumber)
+			if err != nil {
+				log.Errorf("Error in eth_call to %s on %s: %v", request.To, dataSourceURLStruct.URL, err)
+			}
+			return err
+		}, retry.Attempts(core.ProcessRequestRetryAttempts), retry.Delay(time.Second*time.Duration(core.ProcessRequestRetryDelay)))
+	if err != nil {
+		return nil, err
+	}
+	return decodeEthCallOutputs(returnData, request.Outputs)
+}
+
+func parseEthCallRequest(body map[string]interface{}) (EthCallRequest, error) {
+	var request EthCallRequest
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return request, err
+	}
+	if err := json.Unmarshal(bodyBytes, &request); err != nil {
+		return request, err
+	}
+	if !common.IsHexAddress(request.To) {
+		return request, errors.New("eth_call data source requires a contract address in to")
+	}
+	return request, nil
+}
+
+func (request EthCallRequest) callData() ([]byte, error) {
+	if request.Data != "" {
+		return hexutil.Decode(request.Data)
+	}
+	if request.Signature != "" {
+		return crypto.Keccak256([]byte(request.Signature))[:4], nil
+	}
+	return nil, errors.New("eth_call data source requires data or signature")
+}
+
+func decodeEthCallOutputs(returnData []byte, outputs []EthCallOutputType) ([]byte, error) {
+	if len(outputs) == 0 {
+		return json.Marshal(map[string]string{"result": "0x" + hex.EncodeToString(returnData)})
+	}
+	var arguments abi.Arguments
+	for _, output := range outputs {
+		outputType, err := abi.NewType(output.Type, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output type %s: %v", output.Type, err)
+		}
+		arguments = append(arguments, abi.Argument{Type: outputType})
+	}
+	values, err := arguments.UnpackValues(returnData)
+	if err != nil {
+		return nil, err
+	}
+	decoded := make(map[string]interface{})
+	for i, value := range values {
+		name := outputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("output%d", i)
+		}
+		if number, ok := value.(*big.Int); ok {
+			decoded[name] = number.String()
+			continue
+		}
+		if arguments[i].Type.T == abi.UintTy || arguments[i].Type.T == abi.IntTy {
+			decoded[name] = fmt.Sprint(value)
+			continue
+		}
+		decoded[name] = value
+	}
+	return json.Marshal(decoded)
+}
@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"razor/core/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+)
+
+func TestGetDataSourceProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceType string
+		want       DataSourceProvider
+		wantErr    bool
+	}{
+		{
+			name:       "Test 1: When type is GET",
+			sourceType: "GET",
+			want:       HTTPDataSource{},
+		},
+		{
+			name:       "Test 2: When type is POST in lower case",
+			sourceType: "post",
+			want:       HTTPDataSource{},
+		},
+		{
+			name:       "Test 3: When type is graphql",
+			sourceType: "graphql",
+			want:       GraphQLDataSource{},
+		},
+		{
+			name:       "Test 4: When type is websocket",
+			sourceType: "websocket",
+			want:       WebSocketDataSource{},
+		},
+		{
+			name:       "Test 5: When type is eth_call",
+			sourceType: "eth_call",
+			want:       EthCallDataSource{},
+		},
+		{
+			name:       "Test 6: When type is not registered",
+			sourceType: "PUT",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDataSourceProvider(tt.sourceType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDataSourceProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataSourceProvider() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDataSourceCacheKey(t *testing.T) {
+	getURL := types.DataSourceURL{Type: "GET", URL: "https://api.example.com/price"}
+	postURL := types.DataSourceURL{Type: "POST", URL: "https://api.example.com/price"}
+	webSocketURL := types.DataSourceURL{Type: "websocket", URL: "wss://stream.example.com"}
+
+	key := func(dataSourceURLStruct types.DataSourceURL, selector string) string {
+		cacheKey, err := getDataSourceCacheKey(dataSourceURLStruct, selector)
+		if err != nil {
+			t.Fatalf("getDataSourceCacheKey() error = %v", err)
+		}
+		return cacheKey
+	}
+
+	if key(getURL, "price") != key(getURL, "data.price") {
+		t.Error("Expected the selector not to change the key of a GET data source")
+	}
+	if key(getURL, "price") == key(postURL, "price") {
+		t.Error("Expected data sources of different types with the same URL to have different keys")
+	}
+	if key(webSocketURL, "btcusdt@ticker") == key(webSocketURL, "ethusdt@ticker") {
+		t.Error("Expected websocket data sources with different selectors to have different keys")
+	}
+	if _, err := getDataSourceCacheKey(types.DataSourceURL{Type: "POST", Body: map[string]interface{}{"key": func() {}}}, ""); err == nil {
+		t.Error("Expected an error when the body can't be marshalled")
+	}
+}
+
+func TestGraphQLDataSourceFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if strings.Contains(body["query"].(string), "unknownField") {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Cannot query field unknownField"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"pool":{"token0Price":"1834.25"}}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		body    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Test 1: When query is executed successfully",
+			body: map[string]interface{}{"query": "{ pool(id: $id) { token0Price } }", "variables": map[string]interface{}{"id": "0x1"}},
+			want: `{"data":{"pool":{"token0Price":"1834.25"}}}`,
+		},
+		{
+			name:    "Test 2: When query returns errors",
+			body:    map[string]interface{}{"query": "{ unknownField }"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 3: When body has no query",
+			body:    map[string]interface{}{"variables": map[string]interface{}{}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GraphQLDataSource{}.Fetch(http.DefaultClient, types.DataSourceURL{Type: "graphql", URL: server.URL, Body: tt.body}, "data.pool.token0Price")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Fetch() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebSocketDataSourceFetch(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connection, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer connection.Close()
+		var subscription map[string]interface{}
+		if err := connection.ReadJSON(&subscription); err != nil || subscription["op"] != "subscribe" {
+			return
+		}
+		_ = connection.WriteMessage(websocket.TextMessage, []byte(`{"event":"subscribed"}`))
+		_ = connection.WriteMessage(websocket.TextMessage, []byte(`{"data":{"price":"1834.25"}}`))
+	}))
+	defer server.Close()
+	webSocketURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{
+			name:     "Test 1: When messages without the selector are skipped",
+			selector: "data.price",
+			want:     `{"data":{"price":"1834.25"}}`,
+		},
+		{
+			name:     "Test 2: When there is no selector",
+			selector: "",
+			want:     `{"event":"subscribed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WebSocketDataSource{}.Fetch(http.DefaultClient, types.DataSourceURL{
+				Type: "websocket",
+				URL:  webSocketURL,
+				Body: map[string]interface{}{"op": "subscribe", "args": []string{"ETHUSD"}},
+			}, tt.selector)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Fetch() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthCallDataSourceFetch(t *testing.T) {
+	uint112Type, _ := abi.NewType("uint112", "", nil)
+	uint32Type, _ := abi.NewType("uint32", "", nil)
+	reserve0, _ := new(big.Int).SetString("12345678901234567890123", 10)
+	returnData, _ := abi.Arguments{{Type: uint112Type}, {Type: uint112Type}, {Type: uint32Type}}.Pack(reserve0, big.NewInt(42), uint32(1700000000))
+
+	var calledData string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "eth_call" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var callArgs struct {
+			Input string `json:"input"`
+			Data  string `json:"data"`
+		}
+		_ = json.Unmarshal(request.Params[0], &callArgs)
+		calledData = callArgs.Input + callArgs.Data
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": hexutil.Encode(returnData)})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		body         map[string]interface{}
+		wantCallData string
+		want         map[string]interface{}
+		wantErr      bool
+	}{
+		{
+			name: "Test 1: When outputs are decoded by name",
+			body: map[string]interface{}{
+				"to":        "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc",
+				"signature": "getReserves()",
+				"outputs":   []map[string]string{{"name": "reserve0", "type": "uint112"}, {"name": "reserve1", "type": "uint112"}, {"type": "uint32"}},
+			},
+			wantCallData: "0x0902f1ac",
+			want:         map[string]interface{}{"reserve0": "12345678901234567890123", "reserve1": "42", "output2": "1700000000"},
+		},
+		{
+			name: "Test 2: When there are no outputs",
+			body: map[string]interface{}{
+				"to":   "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc",
+				"data": "0x0902f1ac",
+			},
+			wantCallData: "0x0902f1ac",
+			want:         map[string]interface{}{"result": hexutil.Encode(returnData)},
+		},
+		{
+			name:    "Test 3: When contract address is invalid",
+			body:    map[string]interface{}{"to": "pool", "data": "0x0902f1ac"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 4: When there is neither data nor signature",
+			body:    map[string]interface{}{"to": "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calledData = ""
+			got, err := EthCallDataSource{}.Fetch(http.DefaultClient, types.DataSourceURL{Type: "eth_call", URL: server.URL, Body: tt.body}, "reserve0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if calledData != tt.wantCallData {
+				t.Errorf("Fetch() called with data %s, want %s", calledData, tt.wantCallData)
+			}
+			var decoded map[string]interface{}
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, tt.want) {
+				t.Errorf("Fetch() got = %v, want %v", decoded, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/hex"
+	"errors"
+	"razor/core/types"
+	"razor/rpc"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DryRun is set when the vote process runs with --dryRun. Transactions returned by GetTxnOpts are then built,
+// simulated and signed but never broadcast, and WaitForBlockCompletion returns ErrDryRunTransaction without waiting for them.
+var DryRun bool
+
+// ErrDryRunTransaction is returned by WaitForBlockCompletion in dry run mode, so that a simulated transaction is never treated as mined
+var ErrDryRunTransaction = errors.New("transaction was not broadcast in dry run mode")
+
+//This function simulates the transaction with eth_call against the latest block and logs its calldata and result
+func simulateTransaction(rpcParameters rpc.RPCParameters, transactionData types.TransactionOptions, txnOpts *bind.TransactOpts) {
+	if transactionData.MethodName == "" {
+		return
+	}
+	parsed, err := ABIInterface.Parse(strings.NewReader(transactionData.ABI))
+	if err != nil {
+		log.Error("Dry run: Error in parsing abi: ", err)
+		return
+	}
+	inputData, err := ABIInterface.Pack(parsed, transactionData.MethodName, transactionData.Parameters...)
+	if err != nil {
+		log.Error("Dry run: Error in calculating inputData: ", err)
+		return
+	}
+	log.Infof("Dry run: %s transaction to %s with nonce %s, gas limit %d, gas price %s", transactionData.MethodName, transactionData.ContractAddress, txnOpts.Nonce, txnOpts.GasLimit, txnOpts.GasPrice)
+	log.Infof("Dry run: %s calldata: 0x%s", transactionData.MethodName, hex.EncodeToString(inputData))
+
+	client, err := rpcParameters.RPCManager.GetBestRPCClient()
+	if err != nil {
+		log.Error("Dry run: Error in getting best RPC client: ", err)
+		return
+	}
+	contractAddress := common.HexToAddress(transactionData.ContractAddress)
+	_, err = client.CallContract(rpcParameters.Ctx, ethereum.CallMsg{
+		From:     txnOpts.From,
+		To:       &contractAddress,
+		Gas:      txnOpts.GasLimit,
+		GasPrice: txnOpts.GasPrice,
+		Value:    txnOpts.Value,
+		Data:     inputData,
+	}, nil)
+	if err != nil {
+		log.Warnf("Dry run: %s transaction would fail: %v", transactionData.MethodName, err)
+		return
+	}
+	log.Infof("Dry run: %s transaction executes successfully, it will not be broadcast", transactionData.MethodName)
+}
@@ -9,8 +9,10 @@ import (
 	"os"
 	"razor/cache"
 	"razor/core/types"
+	"razor/lease"
 	"razor/pkg/bindings"
 	"razor/rpc"
+	"razor/txmanager"
 	"time"
 
 	RPC "github.com/ethereum/go-ethereum/rpc"
@@ -71,6 +73,13 @@ var FlagSetInterface FlagSetUtils
 var FileInterface FileUtils
 var GasInterface GasUtils
 
+// TransactionManager tracks the transactions sent with GetTxnOpts when it is set, it is nil unless the vote process starts it
+var TransactionManager *txmanager.Manager
+
+// Lease elects the node which sends the transactions of a staker among its redundant vote nodes, GetTxnOpts fails on the other nodes.
+// It is nil unless the vote process is started with a lease file.
+var Lease *lease.Elector
+
 type Utils interface {
 	MultiplyFloatAndBigInt(bigIntVal *big.Int, floatingVal float64) *big.Int
 	GetTxnOpts(rpcParameters rpc.RPCParameters, transactionData types.TransactionOptions) (*bind.TransactOpts, error)
@@ -169,6 +178,7 @@ type Utils interface {
 	GetStakerSRZRBalance(rpcParameters rpc.RPCParameters, staker bindings.StructsStaker) (*big.Int, error)
 	CheckPassword(account types.Account) error
 	AccountManagerForKeystore() (types.AccountManagerInterface, error)
+	AccountManagerForRemoteSigner(signerURL string) (types.AccountManagerInterface, error)
 }
 
 type EthClientUtils interface {
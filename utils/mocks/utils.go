@@ -56,6 +56,30 @@ func (_m *Utils) AccountManagerForKeystore() (types.AccountManagerInterface, err
 	return r0, r1
 }
 
+// AccountManagerForRemoteSigner provides a mock function with given fields: signerURL
+func (_m *Utils) AccountManagerForRemoteSigner(signerURL string) (types.AccountManagerInterface, error) {
+	ret := _m.Called(signerURL)
+
+	var r0 types.AccountManagerInterface
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (types.AccountManagerInterface, error)); ok {
+		return rf(signerURL)
+	}
+	if rf, ok := ret.Get(0).(func(string) types.AccountManagerInterface); ok {
+		r0 = rf(signerURL)
+	} else {
+		r0 = ret.Get(0).(types.AccountManagerInterface)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(signerURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddJobToJSON provides a mock function with given fields: fileName, job
 func (_m *Utils) AddJobToJSON(fileName string, job *types.StructsJob) error {
 	ret := _m.Called(fileName, job)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"razor/core/types"
+	"razor/lease"
 	"razor/rpc"
+	"razor/txmanager"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
@@ -26,14 +28,18 @@ func (*UtilsStruct) GetOptions() bind.CallOpts {
 
 func (*UtilsStruct) GetTxnOpts(rpcParameters rpc.RPCParameters, transactionData types.TransactionOptions) (*bind.TransactOpts, error) {
 	log.Debug("Getting transaction options...")
+	if Lease != nil && !Lease.IsLeader() {
+		log.Errorf("Not sending %s transaction as the lease is not held by this node", transactionData.MethodName)
+		return nil, lease.ErrNotLeader
+	}
 	account := transactionData.Account
 	if account.AccountManager == nil {
 		log.Error("Account Manager in transaction data is not initialised")
 		return nil, errors.New("account manager not initialised")
 	}
-	privateKey, err := account.AccountManager.GetPrivateKey(account.Address, account.Password)
+	txnOpts, err := account.AccountManager.NewTransactor(account.Address, account.Password, transactionData.ChainId)
 	if err != nil {
-		log.Error("Error in fetching private key: ", err)
+		log.Error("Error in getting transactor: ", err)
 		return nil, err
 	}
 
@@ -42,13 +48,22 @@ func (*UtilsStruct) GetTxnOpts(rpcParameters rpc.RPCParameters, transactionData
 		log.Error("Error in fetching nonce: ", err)
 		return nil, err
 	}
+	txnOpts.NoSend = DryRun
+	if TransactionManager != nil {
+		nonce = TransactionManager.NextNonce(common.HexToAddress(account.Address), nonce)
+		client, err := rpcParameters.RPCManager.GetBestRPCClient()
+		if err != nil {
+			log.Error("Error in getting best RPC client to send transaction: ", err)
+			return nil, err
+		}
+		// The transaction manager sends the transaction itself so that it is tracked only once it is sent
+		txnOpts = TransactionManager.WrapTransactor(txnOpts, client, txmanager.Meta{
+			Action: transactionData.MethodName,
+			Window: stateWindowOf(transactionData),
+		})
+	}
 
 	gasPrice := GasInterface.GetGasPrice(rpcParameters, transactionData.Config)
-	txnOpts, err := BindInterface.NewKeyedTransactorWithChainID(privateKey, transactionData.ChainId)
-	if err != nil {
-		log.Error("Error in getting transactor: ", err)
-		return nil, err
-	}
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasPrice = gasPrice
 	txnOpts.Value = transactionData.EtherValue
@@ -66,15 +81,32 @@ func (*UtilsStruct) GetTxnOpts(rpcParameters rpc.RPCParameters, transactionData
 			txnOpts.GasLimit = latestBlock.GasLimit
 			log.Debug("Error occurred due to RPC issue, sending block gas limit...")
 			log.Debug("Gas Limit: ", txnOpts.GasLimit)
+			if DryRun {
+				simulateTransaction(rpcParameters, transactionData, txnOpts)
+			}
 			return txnOpts, nil
 		}
 		log.Error("Error in getting gas limit: ", err)
 	}
 	log.Debug("Gas after increment: ", gasLimit)
 	txnOpts.GasLimit = gasLimit
+	if DryRun {
+		simulateTransaction(rpcParameters, transactionData, txnOpts)
+	}
 	return txnOpts, nil
 }
 
+//This function returns the window of the transaction in the form tracked by the transaction manager
+func stateWindowOf(transactionData types.TransactionOptions) *txmanager.Window {
+	if transactionData.StateWindow == nil {
+		return nil
+	}
+	return &txmanager.Window{
+		Epoch: transactionData.StateWindow.Epoch,
+		State: transactionData.StateWindow.State,
+	}
+}
+
 func (*GasStruct) GetGasPrice(rpcParameters rpc.RPCParameters, config types.Configurations) *big.Int {
 	var gas *big.Int
 	if config.GasPrice != 0 {
@@ -6,8 +6,11 @@ import (
 	"crypto/rand"
 	"errors"
 	"math/big"
+	"path/filepath"
 	"razor/accounts"
+	accountsMocks "razor/accounts/mocks"
 	"razor/core/types"
+	"razor/lease"
 	"razor/utils/mocks"
 	"reflect"
 	"strings"
@@ -147,6 +150,7 @@ func Test_utils_GetTxnOpts(t *testing.T) {
 		gasLimitErr     error
 		latestHeader    *Types.Header
 		latestHeaderErr error
+		notLeader       bool
 	}
 	tests := []struct {
 		name    string
@@ -166,10 +170,11 @@ func Test_utils_GetTxnOpts(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Test 2: When there is an error in getting private key as address is not present in keystore",
+			name: "Test 2: When there is an error in getting transactor as address is not present in keystore",
 			args: args{
-				address: "0x77Baf83BAD5bee0F7F44d84669A50C35c57E3576",
-				nonce:   2,
+				address:    "0x77Baf83BAD5bee0F7F44d84669A50C35c57E3576",
+				nonce:      2,
+				txnOptsErr: errors.New("no keystore file found"),
 			},
 			want:    nil,
 			wantErr: true,
@@ -249,6 +254,18 @@ func Test_utils_GetTxnOpts(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test 9: When the lease is held by another node",
+			args: args{
+				address:   "0x57Baf83BAD5bee0F7F44d84669A50C35c57E3576",
+				nonce:     2,
+				txnOpts:   txnOpts,
+				gasLimit:  1,
+				notLeader: true,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	originalExitFunc := log.LogrusInstance.ExitFunc                   // Preserve the original ExitFunc
@@ -257,7 +274,7 @@ func Test_utils_GetTxnOpts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var account types.Account
-			accountManager := accounts.NewAccountManager("test_accounts")
+			accountManager := new(accountsMocks.AccountManagerInterface)
 			if tt.args.address != "" {
 				account = accounts.InitAccountStruct(tt.args.address, "Test@123", accountManager)
 			} else {
@@ -284,11 +301,23 @@ func Test_utils_GetTxnOpts(t *testing.T) {
 
 			clientMock.On("GetNonceAtWithRetry", mock.Anything, mock.Anything).Return(tt.args.nonce, tt.args.nonceErr)
 			gasMock.On("GetGasPrice", mock.Anything, mock.Anything).Return(gasPrice)
-			bindMock.On("NewKeyedTransactorWithChainID", mock.AnythingOfType("*ecdsa.PrivateKey"), mock.AnythingOfType("*big.Int")).Return(tt.args.txnOpts, tt.args.txnOptsErr)
+			accountManager.On("NewTransactor", tt.args.address, "Test@123", mock.Anything).Return(tt.args.txnOpts, tt.args.txnOptsErr)
 			gasMock.On("GetGasLimit", mock.Anything, transactionData, txnOpts).Return(tt.args.gasLimit, tt.args.gasLimitErr)
 			utilsMock.On("MultiplyFloatAndBigInt", mock.AnythingOfType("*big.Int"), mock.AnythingOfType("float64")).Return(big.NewInt(1))
 			clientMock.On("GetLatestBlockWithRetry", mock.Anything).Return(tt.args.latestHeader, tt.args.latestHeaderErr)
 
+			if tt.args.notLeader {
+				leaseBackend := lease.NewFileBackend(filepath.Join(t.TempDir(), "vote.lease"))
+				if _, err := lease.NewElector(leaseBackend, "leader", lease.DefaultConfig()).Renew(); err != nil {
+					t.Fatal(err)
+				}
+				Lease = lease.NewElector(leaseBackend, "standby", lease.DefaultConfig())
+				if _, err := Lease.Renew(); err != nil {
+					t.Fatal(err)
+				}
+				defer func() { Lease = nil }()
+			}
+
 			got, err := utils.GetTxnOpts(rpcParameters, transactionData)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetTxnOpts() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"razor/core"
+	"razor/metrics"
+	"razor/rpc"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	RPC "github.com/ethereum/go-ethereum/rpc"
+)
+
+// quorumRead is a read which is made to multiple endpoints when quorum reads are enabled in endpoints.json
+type quorumRead struct {
+	// value returns the value of the result which the endpoints have to agree on
+	value func(returnedValues []reflect.Value) string
+	// isPenalized is whether an endpoint which disagrees with the quorum is recorded as failing
+	isPenalized bool
+}
+
+// quorumReads are the reads of InvokeFunctionWithRetryAttempts which propose and dispute decisions depend on
+var quorumReads = map[string]quorumRead{
+	"GetSaltFromBlockchain":  {value: returnedValue, isPenalized: true},
+	"GetNumProposedBlocks":   {value: returnedValue, isPenalized: true},
+	"GetProposedBlock":       {value: returnedValue, isPenalized: true},
+	"SortedProposedBlockIds": {value: returnedValue, isPenalized: true},
+	"GetStakeSnapshot":       {value: returnedValue, isPenalized: true},
+	"GetNumStakers":          {value: returnedValue, isPenalized: true},
+	"GetEpochLastCommitted":  {value: returnedValue, isPenalized: true},
+	"GetEpochLastRevealed":   {value: returnedValue, isPenalized: true},
+	"GetEpochLastProposed":   {value: returnedValue, isPenalized: true},
+	// Endpoints can be a block apart at the start of an epoch, so the epoch of their header is logged but not penalized when it disagrees
+	"HeaderByNumber": {value: headerEpoch, isPenalized: false},
+}
+
+// quorumBatchReads are the contract methods whose batch calls are made to multiple endpoints when quorum reads are enabled
+var quorumBatchReads = map[string]bool{
+	core.GetStakeSnapshotMethod: true,
+}
+
+// quorumResponse is the result of a quorum read from one endpoint
+type quorumResponse struct {
+	client *ethclient.Client
+	value  string
+	// blockNumber is the block the value was read at, it is 0 if the block isn't known
+	blockNumber uint64
+	err         error
+}
+
+//This function returns the returned values of a read other than the error as the value to agree on
+func returnedValue(returnedValues []reflect.Value) string {
+	values := make([]interface{}, 0, len(returnedValues))
+	for _, returnedValue := range returnedValues {
+		if returnedValue.Type().Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+			continue
+		}
+		values = append(values, returnedValue.Interface())
+	}
+	return fmt.Sprintf("%+v", values)
+}
+
+//This function returns the epoch of the returned header as the value to agree on
+func headerEpoch(returnedValues []reflect.Value) string {
+	header, ok := returnedValues[0].Interface().(*types.Header)
+	if !ok || header == nil {
+		return ""
+	}
+	return fmt.Sprint(header.Time / core.EpochLength)
+}
+
+//This function makes the read to the quorum endpoints concurrently and returns the returned values of the best endpoint which agrees with the quorum
+func invokeFunctionWithQuorum(rpcParameters rpc.RPCParameters, read quorumRead, interfaceName interface{}, methodName string, args ...interface{}) ([]reflect.Value, error) {
+	clients, threshold, err := rpcParameters.RPCManager.GetQuorumClients()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]quorumResponse, len(clients))
+	returnedValues := make([][]reflect.Value, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *ethclient.Client) {
+			defer wg.Done()
+			inputs := make([]reflect.Value, len(args)+1)
+			inputs[0] = reflect.ValueOf(client)
+			for j := range args {
+				inputs[j+1] = reflect.ValueOf(args[j])
+			}
+			// The read is made at the latest block, which is known if the head of the endpoint is the same before and after the read
+			blockBefore, blockBeforeErr := client.BlockNumber(rpcParameters.Ctx)
+			start := time.Now()
+			returnedValues[i] = reflect.ValueOf(interfaceName).MethodByName(methodName).Call(inputs)
+			err := CheckIfAnyError(returnedValues[i])
+			rpcParameters.RPCManager.RecordCall(client, time.Since(start), err)
+			responses[i] = quorumResponse{client: client, err: err}
+			if err == nil {
+				responses[i].value = read.value(returnedValues[i])
+				blockAfter, blockAfterErr := client.BlockNumber(rpcParameters.Ctx)
+				if blockBeforeErr == nil && blockAfterErr == nil && blockBefore == blockAfter {
+					responses[i].blockNumber = blockBefore
+				}
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	agreedIndex, err := settleQuorum(rpcParameters, methodName, responses, threshold, read.isPenalized)
+	if err != nil {
+		return nil, err
+	}
+	return returnedValues[agreedIndex], nil
+}
+
+//This function makes the batch call to the quorum endpoints concurrently, with retries, and sets the results of the best endpoint which agrees with the quorum in the calls
+func performQuorumBatchCallWithRetry(rpcParameters rpc.RPCParameters, methodName string, calls []RPC.BatchElem) error {
+	err := retry.Do(func() error {
+		err := performQuorumBatchCall(rpcParameters, methodName, calls)
+		if err != nil {
+			log.Errorf("Error in performing quorum batch call, retrying: %v", err)
+		}
+		return err
+	}, retry.Attempts(core.MaxRetries))
+
+	if err != nil {
+		log.Errorf("All attempts failed to perform quorum batch call: %v", err)
+		return err
+	}
+	return nil
+}
+
+//This function makes the batch call to the quorum endpoints concurrently and sets the results of the best endpoint which agrees with the quorum in the calls.
+//The calls are made at the lowest head of the endpoints, so that endpoints which are a few blocks apart read the same state.
+func performQuorumBatchCall(rpcParameters rpc.RPCParameters, methodName string, calls []RPC.BatchElem) error {
+	clients, threshold, err := rpcParameters.RPCManager.GetQuorumClients()
+	if err != nil {
+		return err
+	}
+
+	responses := make([]quorumResponse, len(clients))
+	blockNumber, headErrs := getLowestHead(rpcParameters.Ctx, clients)
+	clientCalls := make([][]RPC.BatchElem, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		if headErrs[i] != nil {
+			responses[i] = quorumResponse{client: client, err: headErrs[i]}
+			continue
+		}
+		// Every endpoint needs its own results to compare them
+		clientCalls[i] = make([]RPC.BatchElem, len(calls))
+		for j, call := range calls {
+			args := append([]interface{}{}, call.Args...)
+			// The block of eth_call is its last argument
+			args[len(args)-1] = hexutil.EncodeUint64(blockNumber)
+			clientCalls[i][j] = RPC.BatchElem{Method: call.Method, Args: args, Result: new(string)}
+		}
+		wg.Add(1)
+		go func(i int, client *ethclient.Client) {
+			defer wg.Done()
+			ctx, cancel := withRPCTimeout(rpcParameters.Ctx)
+			defer cancel()
+			start := time.Now()
+			err := client.Client().BatchCallContext(ctx, clientCalls[i])
+			results := make([]string, len(clientCalls[i]))
+			for j, call := range clientCalls[i] {
+				if err == nil && call.Error != nil {
+					err = call.Error
+				}
+				results[j] = *call.Result.(*string)
+			}
+			rpcParameters.RPCManager.RecordCall(client, time.Since(start), err)
+			responses[i] = quorumResponse{client: client, value: strings.Join(results, ","), blockNumber: blockNumber, err: err}
+		}(i, client)
+	}
+	wg.Wait()
+
+	agreedIndex, err := settleQuorum(rpcParameters, methodName, responses, threshold, true)
+	if err != nil {
+		return err
+	}
+	for i := range calls {
+		calls[i].Result = clientCalls[agreedIndex][i].Result
+		calls[i].Error = nil
+	}
+	return nil
+}
+
+//This function returns the latest block which every client has, along with the error of every client whose head couldn't be fetched
+func getLowestHead(ctx context.Context, clients []*ethclient.Client) (uint64, []error) {
+	heads := make([]uint64, len(clients))
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *ethclient.Client) {
+			defer wg.Done()
+			headCtx, cancel := withRPCTimeout(ctx)
+			defer cancel()
+			heads[i], errs[i] = client.BlockNumber(headCtx)
+		}(i, client)
+	}
+	wg.Wait()
+
+	var lowestHead uint64
+	for i, head := range heads {
+		if errs[i] == nil && (lowestHead == 0 || head < lowestHead) {
+			lowestHead = head
+		}
+	}
+	return lowestHead, errs
+}
+
+//This function returns the given context with the RPC timeout as its deadline, so that an endpoint of the quorum which
+//doesn't respond fails its call as it does on the single endpoint path
+func withRPCTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if RPCTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(RPCTimeout)*time.Second)
+}
+
+//This function returns the index of the first response with the value which at least threshold endpoints agree on.
+//The endpoints which returned another value are logged and, if the read is penalized, recorded as failing if they read
+//the same block as an endpoint which returned the agreed value, as endpoints which are at different blocks can legitimately disagree.
+func settleQuorum(rpcParameters rpc.RPCParameters, methodName string, responses []quorumResponse, threshold int, isPenalized bool) (int, error) {
+	votes := make(map[string]int)
+	for _, response := range responses {
+		if response.err == nil {
+			votes[response.value]++
+		}
+	}
+	agreedIndex, agreements := -1, 0
+	for i, response := range responses {
+		if response.err == nil && votes[response.value] > agreements {
+			agreedIndex, agreements = i, votes[response.value]
+		}
+	}
+
+	if agreements < threshold {
+		metrics.RPCQuorumFailuresMetric.WithLabelValues(methodName).Inc()
+		for _, response := range responses {
+			endpointURL := rpcParameters.RPCManager.GetEndpointURL(response.client)
+			if response.err != nil {
+				log.Warnf("Quorum read %s from %s failed: %v", methodName, endpointURL, response.err)
+			} else {
+				log.Warnf("Quorum read %s from %s returned %s", methodName, endpointURL, shortenQuorumValue(response.value))
+			}
+		}
+		return -1, fmt.Errorf("%w for %s: %d of %d endpoints agreed, %d have to agree", rpc.ErrNoQuorum, methodName, agreements, len(responses), threshold)
+	}
+
+	agreedValue := responses[agreedIndex].value
+	agreedBlocks := make(map[uint64]bool)
+	for _, response := range responses {
+		if response.err == nil && response.value == agreedValue && response.blockNumber != 0 {
+			agreedBlocks[response.blockNumber] = true
+		}
+	}
+	for _, response := range responses {
+		if response.err != nil || response.value == agreedValue {
+			continue
+		}
+		endpointURL := rpcParameters.RPCManager.GetEndpointURL(response.client)
+		log.Warnf("Quorum read %s from %s at block %d returned %s, which disagrees with %s returned by %d of %d endpoints",
+			methodName, endpointURL, response.blockNumber, shortenQuorumValue(response.value), shortenQuorumValue(agreedValue), agreements, len(responses))
+		metrics.RPCQuorumDisagreementsMetric.WithLabelValues(methodName, metrics.EndpointLabel(endpointURL)).Inc()
+		if isPenalized && response.blockNumber != 0 && agreedBlocks[response.blockNumber] {
+			rpcParameters.RPCManager.RecordCall(response.client, 0, fmt.Errorf("%w for %s at block %d", rpc.ErrQuorumDisagreement, methodName, response.blockNumber))
+		}
+	}
+	return agreedIndex, nil
+}
+
+//This function shortens the value of a quorum read to log it, as the value of a batch call can be long
+func shortenQuorumValue(value string) string {
+	if len(value) > 100 {
+		return value[:100] + "..."
+	}
+	return value
+}
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"razor/rpc"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	RPC "github.com/ethereum/go-ethereum/rpc"
+)
+
+// newQuorumTestClient returns a client of an endpoint whose head is the given block.
+// The endpoint returns the block an eth_call is made at as its result.
+func newQuorumTestClient(t *testing.T, head uint64) *ethclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		isBatch := len(body) > 0 && body[0] == '['
+		var requests []map[string]interface{}
+		if !isBatch {
+			body = append(append([]byte{'['}, body...), ']')
+		}
+		if err := json.Unmarshal(body, &requests); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		responses := make([]map[string]interface{}, len(requests))
+		for i, request := range requests {
+			result := hexutil.EncodeUint64(head)
+			if request["method"] == "eth_call" {
+				params := request["params"].([]interface{})
+				result = params[len(params)-1].(string)
+			}
+			responses[i] = map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": result}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if isBatch {
+			_ = json.NewEncoder(w).Encode(responses)
+		} else {
+			_ = json.NewEncoder(w).Encode(responses[0])
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// newQuorumTestManager returns a manager with a quorum of three endpoints whose heads are the given blocks
+func newQuorumTestManager(t *testing.T, heads ...uint64) (*rpc.RPCManager, []*ethclient.Client) {
+	urls := []string{"https://best.example.com", "https://second.example.com", "https://third.example.com"}
+	var (
+		clients   []*ethclient.Client
+		endpoints []*rpc.RPCEndpoint
+	)
+	for i, head := range heads {
+		clients = append(clients, newQuorumTestClient(t, head))
+		endpoints = append(endpoints, &rpc.RPCEndpoint{URL: urls[i], Client: clients[i]})
+	}
+	return &rpc.RPCManager{Endpoints: endpoints, BestEndpoint: endpoints[0], Quorum: rpc.QuorumConfig{Size: 3}}, clients
+}
+
+// consecutiveErrors returns the number of consecutive errors of every endpoint by URL
+func consecutiveErrors(manager *rpc.RPCManager) map[string]int {
+	errorsByURL := make(map[string]int)
+	for _, status := range manager.GetEndpointsStatus() {
+		errorsByURL[status.URL] = status.Health.ConsecutiveErrors
+	}
+	return errorsByURL
+}
+
+// saltManager returns the salt of the client it is called with
+type saltManager struct {
+	salts map[*ethclient.Client][32]byte
+	errs  map[*ethclient.Client]error
+}
+
+func (s saltManager) GetSaltFromBlockchain(client *ethclient.Client) ([32]byte, error) {
+	return s.salts[client], s.errs[client]
+}
+
+func TestInvokeFunctionWithQuorum(t *testing.T) {
+	agreedSalt, otherSalt := [32]byte{1}, [32]byte{2}
+	rpcParameters := func(manager *rpc.RPCManager) rpc.RPCParameters {
+		return rpc.RPCParameters{RPCManager: manager, Ctx: context.Background()}
+	}
+
+	t.Run("Test 1: When the best endpoint disagrees with the quorum", func(t *testing.T) {
+		manager, clients := newQuorumTestManager(t, 100, 100, 100)
+		salts := saltManager{salts: map[*ethclient.Client][32]byte{clients[0]: otherSalt, clients[1]: agreedSalt, clients[2]: agreedSalt}}
+		returnedValues, err := invokeFunctionWithQuorum(rpcParameters(manager), quorumReads["GetSaltFromBlockchain"], salts, "GetSaltFromBlockchain")
+		if err != nil {
+			t.Fatalf("invokeFunctionWithQuorum() error = %v", err)
+		}
+		if got := returnedValues[0].Interface().([32]byte); got != agreedSalt {
+			t.Errorf("invokeFunctionWithQuorum() = %v, want %v", got, agreedSalt)
+		}
+		if got := consecutiveErrors(manager); got["https://best.example.com"] != 1 || got["https://second.example.com"] != 0 {
+			t.Errorf("Expected only the dissenting endpoint to be penalized, got consecutive errors %v", got)
+		}
+	})
+
+	t.Run("Test 2: When an endpoint fails and the rest agree", func(t *testing.T) {
+		manager, clients := newQuorumTestManager(t, 100, 100, 100)
+		salts := saltManager{
+			salts: map[*ethclient.Client][32]byte{clients[0]: agreedSalt, clients[1]: agreedSalt},
+			errs:  map[*ethclient.Client]error{clients[2]: rpc.ErrTimeout},
+		}
+		returnedValues, err := invokeFunctionWithQuorum(rpcParameters(manager), quorumReads["GetSaltFromBlockchain"], salts, "GetSaltFromBlockchain")
+		if err != nil || returnedValues[0].Interface().([32]byte) != agreedSalt {
+			t.Errorf("invokeFunctionWithQuorum() = %v, error %v, want %v", returnedValues, err, agreedSalt)
+		}
+	})
+
+	t.Run("Test 3: When no result reaches the threshold", func(t *testing.T) {
+		manager, clients := newQuorumTestManager(t, 100, 100, 100)
+		salts := saltManager{
+			salts: map[*ethclient.Client][32]byte{clients[0]: agreedSalt, clients[1]: otherSalt},
+			errs:  map[*ethclient.Client]error{clients[2]: rpc.ErrTimeout},
+		}
+		_, err := invokeFunctionWithQuorum(rpcParameters(manager), quorumReads["GetSaltFromBlockchain"], salts, "GetSaltFromBlockchain")
+		if !errors.Is(err, rpc.ErrNoQuorum) {
+			t.Errorf("invokeFunctionWithQuorum() error = %v, want %v", err, rpc.ErrNoQuorum)
+		}
+		if got := consecutiveErrors(manager); got["https://best.example.com"] != 0 || got["https://second.example.com"] != 0 {
+			t.Errorf("Expected no endpoint to be penalized without a quorum, got consecutive errors %v", got)
+		}
+	})
+
+	t.Run("Test 4: When an endpoint at another block disagrees with the quorum", func(t *testing.T) {
+		manager, clients := newQuorumTestManager(t, 99, 100, 100)
+		salts := saltManager{salts: map[*ethclient.Client][32]byte{clients[0]: otherSalt, clients[1]: agreedSalt, clients[2]: agreedSalt}}
+		returnedValues, err := invokeFunctionWithQuorum(rpcParameters(manager), quorumReads["GetSaltFromBlockchain"], salts, "GetSaltFromBlockchain")
+		if err != nil || returnedValues[0].Interface().([32]byte) != agreedSalt {
+			t.Errorf("invokeFunctionWithQuorum() = %v, error %v, want %v", returnedValues, err, agreedSalt)
+		}
+		if got := consecutiveErrors(manager); got["https://best.example.com"] != 0 {
+			t.Errorf("Expected the endpoint at another block not to be penalized, got consecutive errors %v", got)
+		}
+	})
+}
+
+func TestPerformQuorumBatchCall(t *testing.T) {
+	manager, _ := newQuorumTestManager(t, 101, 99, 100)
+	calls := []RPC.BatchElem{
+		{Method: "eth_call", Args: []interface{}{map[string]interface{}{"to": "0x01", "data": "0x"}, "latest"}, Result: new(string)},
+		{Method: "eth_call", Args: []interface{}{map[string]interface{}{"to": "0x01", "data": "0x"}, "latest"}, Result: new(string)},
+	}
+	err := performQuorumBatchCall(rpc.RPCParameters{RPCManager: manager, Ctx: context.Background()}, "getStakeSnapshot", calls)
+	if err != nil {
+		t.Fatalf("performQuorumBatchCall() error = %v", err)
+	}
+	for _, call := range calls {
+		if got := *call.Result.(*string); got != hexutil.EncodeUint64(99) {
+			t.Errorf("Expected the call to be made at the lowest head 0x63, got %s", got)
+		}
+	}
+	if got := consecutiveErrors(manager); got["https://best.example.com"] != 0 || got["https://second.example.com"] != 0 || got["https://third.example.com"] != 0 {
+		t.Errorf("Expected no endpoint to be penalized when the calls are made at the same block, got consecutive errors %v", got)
+	}
+}
+
+func TestPerformQuorumBatchCallWithUnresponsiveEndpoint(t *testing.T) {
+	manager, clients := newQuorumTestManager(t, 100, 100)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	unresponsiveClient, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(unresponsiveClient.Close)
+	manager.Endpoints = append(manager.Endpoints, &rpc.RPCEndpoint{URL: "https://third.example.com", Client: unresponsiveClient})
+	clients = append(clients, unresponsiveClient)
+
+	RPCTimeout = 1
+	defer func() { RPCTimeout = 0 }()
+
+	calls := []RPC.BatchElem{
+		{Method: "eth_call", Args: []interface{}{map[string]interface{}{"to": "0x01", "data": "0x"}, "latest"}, Result: new(string)},
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- performQuorumBatchCall(rpc.RPCParameters{RPCManager: manager, Ctx: context.Background()}, "getStakeSnapshot", calls)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("performQuorumBatchCall() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the call to the unresponsive endpoint to time out after the RPC timeout")
+	}
+	if got := *calls[0].Result.(*string); got != hexutil.EncodeUint64(100) {
+		t.Errorf("Expected the call to be made at the head 0x64 of the responsive endpoints, got %s", got)
+	}
+	if _, errs := getLowestHead(context.Background(), clients); errs[2] == nil {
+		t.Error("Expected the head of the unresponsive endpoint to time out")
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"razor/core"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/recording"
+	"regexp"
+	"time"
+
+	"github.com/antchfx/htmlquery"
+)
+
+// ResponseRecorder records the raw response of the data source of every job when it is set.
+// It is nil unless the vote process runs with --recordResponses.
+var ResponseRecorder *recording.Recorder
+
+// ResponseReplay serves the recorded responses in place of the data sources of the jobs when it is set, it is only set by the replay command
+var ResponseReplay *recording.Replay
+
+//This function returns the response of the data source of the job to be recorded or replayed, it is nil when responses are neither recorded nor replayed.
+//templateURL is the URL of the data source before API keys are substituted.
+func newJobResponse(job bindings.StructsJob, templateURL string, dataSourceURLStruct types.DataSourceURL) *recording.Response {
+	if ResponseRecorder == nil && ResponseReplay == nil {
+		return nil
+	}
+	return &recording.Response{
+		JobId:        job.Id,
+		JobName:      job.Name,
+		URL:          templateURL,
+		Type:         dataSourceURLStruct.Type,
+		Selector:     job.Selector,
+		SelectorType: job.SelectorType,
+		Header:       recording.RedactHeader(dataSourceURLStruct.Header, regexp.MustCompile(core.APIKeyRegex).MatchString),
+		Timestamp:    time.Now(),
+	}
+}
+
+//This function fetches the response of a JSON job from its data source, or returns the recorded response when responses are replayed
+func fetchJobResponse(commitParams *types.CommitParams, dataSourceURLStruct types.DataSourceURL, selector string, jobResponse *recording.Response) ([]byte, error) {
+	if ResponseReplay != nil {
+		return replayJobResponse(jobResponse)
+	}
+	response, err := GetDataFromSource(commitParams, dataSourceURLStruct, selector)
+	if err == nil && jobResponse != nil {
+		jobResponse.Body = string(response)
+	}
+	return response, err
+}
+
+//This function fetches the value of an XHTML job from its data source, or parses it from the recorded response when responses are replayed
+func fetchXHTMLJobValue(dataSourceURLStruct types.DataSourceURL, selector string, jobResponse *recording.Response) (string, error) {
+	if ResponseReplay != nil {
+		response, err := replayJobResponse(jobResponse)
+		if err != nil {
+			return "", err
+		}
+		return GetDataFromXHTMLResponse(response, selector)
+	}
+	dataPoint, response, err := getDataFromXHTML(dataSourceURLStruct, selector)
+	if jobResponse != nil {
+		jobResponse.Body = string(response)
+	}
+	return dataPoint, err
+}
+
+//This function returns the recorded response of the data source of the job and replaces the job response with the recorded one
+func replayJobResponse(jobResponse *recording.Response) ([]byte, error) {
+	recorded, ok := ResponseReplay.Response(jobResponse.JobId, jobResponse.URL, jobResponse.Selector)
+	if !ok {
+		return nil, fmt.Errorf("no response recorded for job %d from %s", jobResponse.JobId, jobResponse.URL)
+	}
+	*jobResponse = recorded
+	if recorded.Body == "" && recorded.Error != "" {
+		return nil, errors.New(recorded.Error)
+	}
+	return []byte(recorded.Body), nil
+}
+
+//This function records the response of the data source of the job along with the value parsed from it,
+//or collects the recomputed value when responses are replayed
+func finishJobResponse(jobResponse *recording.Response, data *big.Int, err error) {
+	if jobResponse == nil {
+		return
+	}
+	var value string
+	if data != nil {
+		value = data.String()
+	}
+	if ResponseReplay != nil {
+		ResponseReplay.Observe(*jobResponse, value, err)
+		return
+	}
+	jobResponse.Value = value
+	if err != nil {
+		jobResponse.Error = err.Error()
+	}
+	if recordErr := ResponseRecorder.Record(*jobResponse); recordErr != nil {
+		log.Errorf("Job ID: %d, Error in recording response: %v", jobResponse.JobId, recordErr)
+	}
+}
+
+//This function parses the value at the XPath selector from a recorded XHTML response, the text of the last matching element is returned as colly does
+func GetDataFromXHTMLResponse(response []byte, selector string) (string, error) {
+	doc, err := htmlquery.Parse(bytes.NewReader(response))
+	if err != nil {
+		return "", err
+	}
+	nodes, err := htmlquery.QueryAll(doc, selector)
+	if err != nil {
+		return "", err
+	}
+	var dataPoint string
+	for _, node := range nodes {
+		dataPoint = htmlquery.InnerText(node)
+	}
+	return dataPoint, nil
+}
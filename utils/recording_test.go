@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"razor/cache"
+	"razor/core/types"
+	"razor/pkg/bindings"
+	"razor/recording"
+	"testing"
+)
+
+func TestRecordAndReplayDataToCommitFromJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"last": "2000.5"}`))
+	}))
+	job := bindings.StructsJob{Id: 1, SelectorType: 0, Weight: 100, Power: 2, Name: "ethusd_sample", Selector: "last",
+		Url: fmt.Sprintf(`{"type": "GET","url": "%s/ethusd","body": {},"header": {"Authorization": "Bearer secret"}}`, server.URL),
+	}
+	newCommitParams := func() *types.CommitParams {
+		return &types.CommitParams{LocalCache: cache.NewLocalCache(), HttpClient: server.Client()}
+	}
+
+	recordingFilePath := filepath.Join(t.TempDir(), "10.jsonl")
+	ResponseRecorder = recording.NewRecorder(func(epoch uint32) (string, error) {
+		return recordingFilePath, nil
+	})
+	ResponseRecorder.SetEpoch(10)
+	data, err := getDataToCommitFromJob(job, newCommitParams())
+	ResponseRecorder.Close()
+	ResponseRecorder = nil
+	server.Close()
+	if err != nil || data.String() != "200050" {
+		t.Fatalf("getDataToCommitFromJob() = %v, %v, want 200050", data, err)
+	}
+
+	responses, err := recording.Load(recordingFilePath)
+	if err != nil {
+		t.Fatalf("Error in loading recording: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Fatalf("Recorded %d responses, want 1", len(responses))
+	}
+	recorded := responses[0]
+	if recorded.Epoch != 10 || recorded.JobId != 1 || recorded.URL != server.URL+"/ethusd" || recorded.Value != "200050" {
+		t.Errorf("Recorded response = %+v, want the response of job 1 in epoch 10 with value 200050", recorded)
+	}
+	if recorded.Header["Authorization"] != recording.RedactedValue {
+		t.Errorf("Recorded Authorization header = %s, want it redacted", recorded.Header["Authorization"])
+	}
+
+	// The server is closed, so the value can only be recomputed from the recorded response
+	ResponseReplay = recording.NewReplay(responses)
+	defer func() {
+		ResponseReplay = nil
+	}()
+	data, err = getDataToCommitFromJob(job, newCommitParams())
+	if err != nil || data.String() != "200050" {
+		t.Fatalf("getDataToCommitFromJob() in replay = %v, %v, want 200050", data, err)
+	}
+	replayed := ResponseReplay.TakeValues()
+	if len(replayed) != 1 || replayed[0].Value != "200050" || replayed[0].Response.BodyHash != recorded.BodyHash {
+		t.Errorf("Replayed values = %+v, want the value of the recorded response", replayed)
+	}
+
+	job.Id = 2
+	if _, err := getDataToCommitFromJob(job, newCommitParams()); err == nil {
+		t.Error("getDataToCommitFromJob() in replay of a job which wasn't recorded, expected an error")
+	}
+}
+
+func TestGetDataFromXHTMLResponse(t *testing.T) {
+	response := []byte(`<html><body><div class="price">$1,200.50</div><div class="price">$1,300.25</div></body></html>`)
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "Test 1: When the text of the last matching element is returned",
+			selector: `//div[@class="price"]`,
+			want:     "$1,300.25",
+		},
+		{
+			name:     "Test 2: When no element matches",
+			selector: `//span`,
+			want:     "",
+		},
+		{
+			name:     "Test 3: When the selector is invalid",
+			selector: `//div[`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDataFromXHTMLResponse(response, tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDataFromXHTMLResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetDataFromXHTMLResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
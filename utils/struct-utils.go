@@ -4,18 +4,17 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/json"
-	"errors"
 	"io"
 	"io/fs"
 	"math/big"
 	"os"
 	"razor/core"
 	coretypes "razor/core/types"
+	"razor/journal"
 	"razor/path"
 	"razor/pkg/bindings"
 	"razor/rpc"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/avast/retry-go"
@@ -86,7 +85,7 @@ func InvokeFunctionWithTimeout(interfaceName interface{}, methodName string, arg
 
 func CheckIfAnyError(result []reflect.Value) error {
 	if result == nil {
-		return errors.New("RPC timeout error")
+		return rpc.ErrTimeout
 	}
 
 	errorDataType := reflect.TypeOf((*error)(nil)).Elem()
@@ -118,16 +117,6 @@ func InvokeFunctionWithRetryAttempts(rpcParameters rpc.RPCParameters, interfaceN
 
 	// Ensure inputs has space for the client and any additional arguments
 	inputs := make([]reflect.Value, len(args)+1)
-
-	// Always use the current best client for each retry
-	client, err := rpcParameters.RPCManager.GetBestRPCClient()
-	if err != nil {
-		log.Errorf("Failed to get current best client: %v", err)
-		return returnedValues, err
-	}
-
-	// Set the client as the first argument
-	inputs[0] = reflect.ValueOf(client)
 	// Add the rest of the args to inputs starting from index 1
 	for i := 0; i < len(args); i++ {
 		inputs[i+1] = reflect.ValueOf(args[i])
@@ -143,9 +132,25 @@ func InvokeFunctionWithRetryAttempts(rpcParameters rpc.RPCParameters, interfaceN
 				contextError = true
 				return retry.Unrecoverable(rpcParameters.Ctx.Err())
 			default:
-				// Proceed with the RPC call
-				returnedValues = reflect.ValueOf(interfaceName).MethodByName(methodName).Call(inputs)
-				err = CheckIfAnyError(returnedValues)
+				if read, ok := quorumReads[methodName]; ok && rpcParameters.RPCManager.IsQuorumEnabled() {
+					// Reads which decisions depend on are only accepted if enough endpoints agree on them
+					returnedValues, err = invokeFunctionWithQuorum(rpcParameters, read, interfaceName, methodName, args...)
+				} else {
+					// Always use the current best client for each retry, as a quarantined endpoint is replaced by the next best endpoint
+					client, clientErr := rpcParameters.RPCManager.GetBestRPCClient()
+					if clientErr != nil {
+						log.Errorf("Failed to get current best client: %v", clientErr)
+						return retry.Unrecoverable(clientErr)
+					}
+					// Set the client as the first argument
+					inputs[0] = reflect.ValueOf(client)
+
+					// Proceed with the RPC call
+					start := time.Now()
+					returnedValues = reflect.ValueOf(interfaceName).MethodByName(methodName).Call(inputs)
+					err = CheckIfAnyError(returnedValues)
+					rpcParameters.RPCManager.RecordCall(client, time.Since(start), err)
+				}
 				if err != nil {
 					log.Debug("Function to retry: ", methodName)
 					log.Errorf("Error in %v....Retrying", methodName)
@@ -161,8 +166,8 @@ func InvokeFunctionWithRetryAttempts(rpcParameters rpc.RPCParameters, interfaceN
 			return returnedValues, err
 		}
 
-		// Only switch to the next best client if the error is identified as an RPC error
-		if isRPCError(err) {
+		// Only switch to the next best client if the error is caused by the endpoint
+		if rpc.IsEndpointError(err) {
 			log.Errorf("%v error after retries: %v", methodName, err)
 			log.Info("Attempting to switch to a new best RPC endpoint...")
 
@@ -183,40 +188,6 @@ func InvokeFunctionWithRetryAttempts(rpcParameters rpc.RPCParameters, interfaceN
 	return returnedValues, err
 }
 
-func isRPCError(err error) bool {
-	// Check for common RPC error patterns
-	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-		return true
-	}
-
-	// Add other checks based on specific RPC errors (timeouts, connection issues, etc.)
-	if strings.Contains(err.Error(), "connection refused") ||
-		strings.Contains(err.Error(), "connection reset by peer") ||
-		strings.Contains(err.Error(), "EOF") ||
-		strings.Contains(err.Error(), "dial") ||
-		strings.Contains(err.Error(), "no such host") ||
-		strings.Contains(err.Error(), "i/o timeout") {
-		return true
-	}
-
-	// Check for HTTP 500–504 errors
-	if strings.Contains(err.Error(), "500") ||
-		strings.Contains(err.Error(), "501") ||
-		strings.Contains(err.Error(), "502") ||
-		strings.Contains(err.Error(), "503") ||
-		strings.Contains(err.Error(), "504") {
-		return true
-	}
-
-	// Check for the custom RPC timeout error
-	if strings.Contains(err.Error(), "RPC timeout error") {
-		return true
-	}
-
-	// If it's not an RPC error, return false
-	return false
-}
-
 func (b BlockManagerStruct) GetBlockIndexToBeConfirmed(client *ethclient.Client) (int8, error) {
 	blockManager, opts := UtilsInterface.GetBlockManagerWithOpts(client)
 	returnedValues := InvokeFunctionWithTimeout(blockManager, "BlockIndexToBeConfirmed", &opts)
@@ -746,8 +717,9 @@ func (o OSStruct) Open(name string) (*os.File, error) {
 	return os.Open(name)
 }
 
+// WriteFile replaces the file atomically so that a crash never leaves a partially written file behind
 func (o OSStruct) WriteFile(name string, data []byte, perm fs.FileMode) error {
-	return os.WriteFile(name, data, perm)
+	return journal.WriteFileAtomically(name, data, perm)
 }
 
 func (o OSStruct) ReadFile(filename string) ([]byte, error) {